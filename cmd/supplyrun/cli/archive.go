@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/archive"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func exportCmd(version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Version: version,
+		Use:     "export config",
+		Short:   "write the recipe library, units and conversions to stdout",
+		Args:    cobra.ExactArgs(1),
+		RunE:    exportRun(),
+	}
+
+	cmd.Flags().String("format", string(archive.JSON), "archive format, json or yaml")
+
+	return cmd
+}
+
+const importLong = `Load a recipe library archive or another recipe manager's export straight into the configured database.
+
+A running server keeps its recipe search index and subscriptions in memory and does not see
+imported records until it is restarted.`
+
+// restartWarning is printed after an import that changed the database.
+const restartWarning = "warning: restart any running server so recipe search and subscriptions see the imported records"
+
+func importCmd(version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Version: version,
+		Use:     "import config file",
+		Short:   "load a recipe library archive or another recipe manager's export",
+		Long:    importLong,
+		Args:    cobra.ExactArgs(2), //nolint: mnd
+		RunE:    importRun(),
+	}
+
+	cmd.Flags().String("format", string(archive.JSON), "archive format, json or yaml")
+	cmd.Flags().Bool("dry-run", false, "report what would change without storing anything")
+	cmd.Flags().String("from", "", "read an export from another recipe manager instead, paprika, mealie or tandoor")
+	cmd.Flags().String("user", "", "id of the user who owns recipes imported from another recipe manager, required with --from")
+
+	return cmd
+}
+
+// withRepository opens the configured database, making sure its schema is current.
+func withRepository(cmd *cobra.Command, path string, fn func(repo repository) error) error {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = repo.Close() }()
+
+	log := zerolog.New(zerolog.ConsoleWriter{Out: cmd.ErrOrStderr(), TimeFormat: time.RFC3339}).With().Timestamp().Logger()
+	if err := checkSchema(cmd.Context(), repo.Migrator(), cfg.Database.AutoMigrate, log); err != nil {
+		return err
+	}
+
+	return fn(repo)
+}
+
+func archiveFormat(cmd *cobra.Command) (archive.Format, error) {
+	name, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+
+	return archive.ParseFormat(name)
+}
+
+func exportRun() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, err := archiveFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		return withRepository(cmd, args[0], func(repo repository) error {
+			writer, err := archive.NewWriter(cmd.OutOrStdout(), format)
+			if err != nil {
+				return err
+			}
+
+			queries := query.NewService(repo, repo, repo, repo, repo, repo)
+			if err := archive.Export(cmd.Context(), queries, writer); err != nil {
+				return err
+			}
+
+			return writer.Close()
+		})
+	}
+}
+
+func importRun() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		from, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return err
+		}
+
+		if from != "" {
+			return importFromRun(cmd, args, from)
+		}
+
+		format, err := archiveFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+
+		return withRepository(cmd, args[0], func(repo repository) error {
+			reader, err := archive.NewReader(file, format)
+			if err != nil {
+				return err
+			}
+
+			options := make([]archive.Option, 0)
+			if dryRun {
+				options = append(options, archive.DryRun())
+			}
+
+			report, err := archive.NewImporter(repo, options...).Import(cmd.Context(), reader)
+			for _, result := range report.Results {
+				if result.Action == archive.Invalid {
+					cmd.Printf("invalid %s %s: %v\n", result.Kind, result.ID, result.Err)
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+
+			summary := "created %d, updated %d, unchanged %d, invalid %d\n"
+			if dryRun {
+				summary = "dry run: " + summary
+			}
+
+			cmd.Printf(
+				summary,
+				report.Count(archive.Created),
+				report.Count(archive.Updated),
+				report.Count(archive.Unchanged),
+				report.Count(archive.Invalid),
+			)
+
+			if !dryRun && report.Count(archive.Created)+report.Count(archive.Updated) > 0 {
+				cmd.Println(restartWarning)
+			}
+
+			return nil
+		})
+	}
+}
+
+// errNoOwner is returned when an export from another recipe manager is imported without a user.
+var errNoOwner = errors.New("--user is required with --from")
+
+// importFromRun creates a recipe for everything in another recipe manager's export,
+// reporting any units and ingredients that could not be matched against the stored units.
+// Recipes get an ID seeded from the source and their URL, or name when they have none,
+// so importing the same export again leaves the recipes it already created unchanged.
+func importFromRun(cmd *cobra.Command, args []string, from string) error {
+	source, err := importer.NewSource(from)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	userID, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return err
+	}
+
+	if userID == "" {
+		return errNoOwner
+	}
+
+	export, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	recipes, err := source.Recipes(export)
+	if err != nil {
+		return err
+	}
+
+	return withRepository(cmd, args[0], func(repo repository) error {
+		owner := entity.NewID(userID)
+		if _, err := repo.GetUser(cmd.Context(), owner); err != nil {
+			return fmt.Errorf("user %q: %w", userID, err)
+		}
+
+		parser, err := query.NewService(repo, repo, repo, repo, repo, repo).IngredientParser(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		created, unchanged, invalid := 0, 0, 0
+		units := make(map[string]int)
+
+		for _, imported := range recipes {
+			lines := make([]ingredient.Line, len(imported.Ingredients))
+			for i := range imported.Ingredients {
+				lines[i] = parser.Parse(imported.Ingredients[i])
+			}
+
+			for _, unmatched := range imported.Unmatched(lines) {
+				if unmatched.Unit != "" {
+					units[unmatched.Unit]++
+
+					continue
+				}
+
+				cmd.Printf("unmatched ingredient in %q: %s\n", unmatched.Recipe, unmatched.Ingredient)
+			}
+
+			key := imported.URL
+			if key == "" {
+				key = imported.Name
+			}
+
+			id := entity.NewSeededID(from + ":" + key)
+
+			_, err := repo.GetRecipe(cmd.Context(), id)
+			if err == nil {
+				unchanged++
+
+				continue
+			}
+
+			if !errors.Is(err, entity.ErrNotFound) {
+				return err
+			}
+
+			result, err := recipe.New(id, imported.Name, time.Now(), owner, imported.Options(lines)...)
+
+			var validation *entity.ValidationError
+			if errors.As(err, &validation) {
+				cmd.Printf("invalid recipe %q: %v\n", imported.Name, err)
+
+				invalid++
+
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if !dryRun {
+				if err := repo.CreateRecipe(cmd.Context(), result); err != nil {
+					return err
+				}
+			}
+
+			created++
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(units)) {
+			cmd.Printf("unmatched unit %q in %d ingredient(s)\n", name, units[name])
+		}
+
+		summary := "created %d, unchanged %d, invalid %d\n"
+		if dryRun {
+			summary = "dry run: " + summary
+		}
+
+		cmd.Printf(summary, created, unchanged, invalid)
+
+		if !dryRun && created > 0 {
+			cmd.Println(restartWarning)
+		}
+
+		return nil
+	})
+}
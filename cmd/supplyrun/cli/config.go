@@ -1,35 +1,85 @@
 package cli
 
+import (
+	"github.com/b-sea/go-config/config"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+)
+
 const cfgEnvPrefix = "SUPPLYRUN"
 
 // Config is the supply run configuration.
 type Config struct {
 	Server struct {
-		Port         int `config:"port"`
-		ReadTimeout  int `config:"readTimeout"`
-		WriteTimeout int `config:"writeTimeout"`
+		Port         int    `config:"port"`
+		ReadTimeout  int    `config:"readTimeout"`
+		WriteTimeout int    `config:"writeTimeout"`
+		Secret       string `config:"secret"`
 	} `config:"server"`
 
 	Logger struct {
 		Level string `config:"level"`
 	} `config:"logger"`
+
+	GraphQL struct {
+		MaxDepth      int `config:"maxDepth"`
+		MaxComplexity int `config:"maxComplexity"`
+		MaxPageSize   int `config:"maxPageSize"`
+	} `config:"graphql"`
+
+	Auth struct {
+		Issuer   string `config:"issuer"`
+		JWKSURL  string `config:"jwksUrl"`
+		Audience string `config:"audience"`
+	} `config:"auth"`
+
+	Database struct {
+		Driver      string `config:"driver"`
+		AutoMigrate bool   `config:"autoMigrate"`
+		SQLite      struct {
+			Path string `config:"path"`
+		} `config:"sqlite"`
+		Postgres struct {
+			DSN              string `config:"dsn"`
+			MaxOpenConns     int    `config:"maxOpenConns"`
+			MaxIdleConns     int    `config:"maxIdleConns"`
+			ConnMaxLifetime  int    `config:"connMaxLifetime"`
+			ConnMaxIdleTime  int    `config:"connMaxIdleTime"`
+			ConnectTimeout   int    `config:"connectTimeout"`
+			StatementTimeout int    `config:"statementTimeout"`
+		} `config:"postgres"`
+	} `config:"database"`
 }
 
 func defaultConfig() Config {
-	return Config{
-		Server: struct {
-			Port         int `config:"port"`
-			ReadTimeout  int `config:"readTimeout"`
-			WriteTimeout int `config:"writeTimeout"`
-		}{
-			Port:         5000, //nolint: mnd
-			ReadTimeout:  5,    //nolint: mnd
-			WriteTimeout: 5,    //nolint: mnd
-		},
-		Logger: struct {
-			Level string `config:"level"`
-		}{
-			Level: "info",
-		},
+	cfg := Config{}
+
+	cfg.Server.Port = 5000      //nolint: mnd
+	cfg.Server.ReadTimeout = 5  //nolint: mnd
+	cfg.Server.WriteTimeout = 5 //nolint: mnd
+
+	cfg.Logger.Level = "info"
+
+	cfg.GraphQL.MaxDepth = graphql.DefaultMaxDepth
+	cfg.GraphQL.MaxComplexity = graphql.DefaultMaxComplexity
+	cfg.GraphQL.MaxPageSize = graphql.DefaultMaxPageSize
+
+	cfg.Database.Driver = sqliteDriver
+	cfg.Database.SQLite.Path = "supplyrun.db"
+	cfg.Database.Postgres.MaxOpenConns = 10      //nolint: mnd
+	cfg.Database.Postgres.MaxIdleConns = 5       //nolint: mnd
+	cfg.Database.Postgres.ConnMaxLifetime = 1800 //nolint: mnd
+	cfg.Database.Postgres.ConnMaxIdleTime = 300  //nolint: mnd
+	cfg.Database.Postgres.ConnectTimeout = 5     //nolint: mnd
+
+	return cfg
+}
+
+func loadConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+
+	if err := config.Load(&cfg, config.WithEnvPrefix(cfgEnvPrefix), config.WithFile(path)); err != nil {
+		return Config{}, err
 	}
+
+	return cfg, nil
 }
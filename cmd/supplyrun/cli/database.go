@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/b-sea/go-server/server"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/migrate"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/postgres"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/b-sea/supply-run-api/internal/sqlite"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+const (
+	sqliteDriver   = "sqlite"
+	postgresDriver = "postgres"
+)
+
+var errUnknownDriver = errors.New("unknown database driver")
+
+// repository is every data interaction the service needs from a database.
+type repository interface {
+	recipe.Repository
+	unit.Repository
+	user.Repository
+	shoppinglist.Repository
+	pantry.Repository
+	mealplan.Repository
+	query.RecipeRepository
+	query.UnitRepository
+	query.UserRepository
+	query.ShoppingListRepository
+	query.PantryRepository
+	query.MealRepository
+	server.HealthChecker
+	io.Closer
+
+	Migrator() *migrate.Migrator
+}
+
+func openRepository(cfg Config) (repository, error) { //nolint: ireturn
+	switch cfg.Database.Driver {
+	case sqliteDriver:
+		return sqlite.New(cfg.Database.SQLite.Path)
+	case postgresDriver:
+		return postgres.New(
+			cfg.Database.Postgres.DSN,
+			postgres.SetMaxOpenConns(cfg.Database.Postgres.MaxOpenConns),
+			postgres.SetMaxIdleConns(cfg.Database.Postgres.MaxIdleConns),
+			postgres.SetConnMaxLifetime(time.Duration(cfg.Database.Postgres.ConnMaxLifetime)*time.Second),
+			postgres.SetConnMaxIdleTime(time.Duration(cfg.Database.Postgres.ConnMaxIdleTime)*time.Second),
+			postgres.SetConnectTimeout(time.Duration(cfg.Database.Postgres.ConnectTimeout)*time.Second),
+			postgres.SetStatementTimeout(time.Duration(cfg.Database.Postgres.StatementTimeout)*time.Second),
+		)
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownDriver, cfg.Database.Driver)
+	}
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/migrate"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errSchemaBehind = errors.New("database schema is behind, run migrations or enable autoMigrate")
+	errSchemaAhead  = errors.New("database schema is newer than this version")
+)
+
+func migrateCmd(version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Version: version,
+		Use:     "migrate [COMMAND]",
+		Short:   "manage the Supply Run API database schema",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Version: version,
+			Use:     "up config",
+			Short:   "apply all pending migrations",
+			Args:    cobra.ExactArgs(1),
+			RunE:    migrateUpRun(),
+		},
+		&cobra.Command{
+			Version: version,
+			Use:     "down config",
+			Short:   "roll back the newest applied migration",
+			Args:    cobra.ExactArgs(1),
+			RunE:    migrateDownRun(),
+		},
+		&cobra.Command{
+			Version: version,
+			Use:     "status config",
+			Short:   "show the state of every migration",
+			Args:    cobra.ExactArgs(1),
+			RunE:    migrateStatusRun(),
+		},
+	)
+
+	return cmd
+}
+
+func withMigrator(path string, fn func(migrator *migrate.Migrator) error) error {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = repo.Close() }()
+
+	return fn(repo.Migrator())
+}
+
+func migrateUpRun() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return withMigrator(args[0], func(migrator *migrate.Migrator) error {
+			applied, err := migrator.Up(cmd.Context())
+			for _, migration := range applied {
+				cmd.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if len(applied) == 0 {
+				cmd.Println("database schema is up to date")
+			}
+
+			return nil
+		})
+	}
+}
+
+func migrateDownRun() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return withMigrator(args[0], func(migrator *migrate.Migrator) error {
+			migration, err := migrator.Down(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("rolled back %04d_%s\n", migration.Version, migration.Name)
+
+			return nil
+		})
+	}
+}
+
+func migrateStatusRun() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return withMigrator(args[0], func(migrator *migrate.Migrator) error {
+			status, err := migrator.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, item := range status {
+				state := "pending"
+				if item.Applied {
+					state = "applied " + item.AppliedAt.Format(time.RFC3339)
+				}
+
+				cmd.Printf("%04d_%s\t%s\n", item.Version, item.Name, state)
+			}
+
+			return nil
+		})
+	}
+}
+
+// checkSchema makes sure the database schema matches the migrations known to this binary.
+func checkSchema(ctx context.Context, migrator *migrate.Migrator, autoMigrate bool, log zerolog.Logger) error {
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version > migrator.Latest() {
+		return fmt.Errorf("%w: database at %d, expected %d", errSchemaAhead, version, migrator.Latest())
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if !autoMigrate {
+		return fmt.Errorf("%w: %d pending migration(s)", errSchemaBehind, len(pending))
+	}
+
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		log.Info().Int("version", migration.Version).Str("name", migration.Name).Msg("applied migration")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -12,6 +12,9 @@ func New(version string) *cobra.Command {
 	}
 
 	root.AddCommand(startCmd(version))
+	root.AddCommand(migrateCmd(version))
+	root.AddCommand(exportCmd(version))
+	root.AddCommand(importCmd(version))
 
 	return root
 }
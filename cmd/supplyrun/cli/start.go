@@ -1,18 +1,24 @@
 package cli
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/b-sea/go-config/config"
 	"github.com/b-sea/go-server/server"
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/command"
 	"github.com/b-sea/supply-run-api/internal/graphql"
 	"github.com/b-sea/supply-run-api/internal/metrics"
-	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/render"
+	"github.com/b-sea/supply-run-api/internal/search"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
 	"github.com/spf13/cobra"
@@ -32,30 +38,78 @@ func startCmd(version string) *cobra.Command {
 
 func startRun() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		cfg := defaultConfig()
-
-		if err := config.Load(&cfg, config.WithEnvPrefix(cfgEnvPrefix), config.WithFile(args[0])); err != nil {
+		cfg, err := loadConfig(args[0])
+		if err != nil {
 			return err
 		}
 
 		log := setupLogger(cfg)
 		recorder := metrics.NewPrometheus()
 
+		repo, err := openRepository(cfg)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = repo.Close() }()
+
+		if err := checkSchema(cmd.Context(), repo.Migrator(), cfg.Database.AutoMigrate, log); err != nil {
+			return err
+		}
+
+		index := search.NewIndex()
+		broker := pubsub.NewMemory()
+		commands := command.NewService(
+			repo, repo, repo, repo, repo, repo,
+			command.WithRecipeIndex(index),
+			command.WithPublisher(broker),
+		)
+		queries := query.NewService(repo, repo, repo, repo, repo, repo, query.WithRecipeSearch(index))
+
+		if err := index.Load(cmd.Context(), queries); err != nil {
+			return err
+		}
+
+		authenticator, err := authenticate(cmd.Context(), cfg, commands, log)
+		if err != nil {
+			return err
+		}
+
+		middleware := auth.Anonymous
+		if authenticator != nil {
+			middleware = authenticator.Middleware
+		}
+
+		if cfg.Server.Secret == "" {
+			log.Warn().Msg("no server secret configured, calendar feed links and page cursors will change on every start")
+		}
+
+		feeds := calendar.NewSigner([]byte(cfg.Server.Secret))
+
+		options := []graphql.Option{
+			graphql.WithBroker(broker),
+			graphql.WithFeedSigner(feeds),
+			graphql.WithCursorSecret([]byte(cfg.Server.Secret)),
+			graphql.WithMaxDepth(cfg.GraphQL.MaxDepth),
+			graphql.WithMaxComplexity(cfg.GraphQL.MaxComplexity),
+			graphql.WithMaxPageSize(cfg.GraphQL.MaxPageSize),
+		}
+		if authenticator != nil {
+			options = append(options, graphql.WithAuthenticator(authenticator))
+		}
+
+		api := graphql.New(queries, commands, recorder, options...)
+
 		svr := server.New(log, recorder,
 			server.SetPort(cfg.Server.Port),
 			server.SetReadTimeout(time.Duration(cfg.Server.ReadTimeout)*time.Second),
 			server.SetWriteTimeout(time.Duration(cfg.Server.WriteTimeout)*time.Second),
 			server.SetVersion(cmd.Version),
-			server.AddHandler(
-				"/graphql",
-				graphql.New(
-					query.NewService(
-						&mock.QueryRecipeRepository{},
-						&mock.QueryUnitRepository{},
-						&mock.QueryUserRepository{},
-					), recorder),
-				http.MethodPost,
-			),
+			server.AddMiddleware(upgrades("/graphql", api)),
+			server.AddHandler("/graphql", middleware(api), http.MethodGet, http.MethodPost),
+			server.AddHandler(calendar.Path, calendar.New(queries, feeds), http.MethodGet),
+			server.AddHandler("/recipes/{id}.{format}", middleware(render.NewHandler(queries)), http.MethodGet),
+			server.AddHealthDependency("database", repo),
 		)
 
 		channel := make(chan os.Signal, 1)
@@ -81,6 +135,55 @@ func startRun() func(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// authenticate creates the Authenticator for the configured issuer.
+// It returns nil when no issuer is configured and requests should be anonymous.
+func authenticate(
+	ctx context.Context,
+	cfg Config,
+	commands *command.Service,
+	log zerolog.Logger,
+) (*auth.Authenticator, error) {
+	if cfg.Auth.Issuer == "" {
+		log.Warn().Msg("no auth issuer configured, accepting anonymous requests")
+
+		return nil, nil //nolint: nilnil
+	}
+
+	authenticator, err := auth.New(
+		ctx,
+		cfg.Auth.Issuer,
+		auth.SetJWKSURL(cfg.Auth.JWKSURL),
+		auth.SetAudience(cfg.Auth.Audience),
+		auth.SetProvisioner(func(ctx context.Context, identity auth.Identity) error {
+			_, err := commands.ProvisionUser(ctx, identity.ID, identity.Username)
+
+			return err
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return authenticator, nil
+}
+
+// upgrades serves WebSocket upgrades of a path before any other middleware,
+// since the server's telemetry cannot hand over the underlying connection.
+// Upgrades skip bearer authentication, as browsers cannot set headers on them; the handler logs in on connection_init.
+func upgrades(path string, handler http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path != path || !strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+				next.ServeHTTP(writer, request)
+
+				return
+			}
+
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
+
 func setupLogger(cfg Config) zerolog.Logger {
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack //nolint: reassign
 	zerolog.TimeFieldFormat = time.RFC3339Nano
@@ -0,0 +1,85 @@
+// Package archive reads and writes portable copies of the recipe library.
+//
+// An archive is a stream of records: a Header first, then every unit, conversion and recipe as its own Record.
+// JSON archives hold one record per line, and YAML archives hold one record per document.
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// FormatName identifies supply run archives.
+	FormatName = "supplyrun"
+	// Version is the archive version written by this package.
+	Version = 1
+)
+
+var (
+	// ErrArchive is raised when an archive cannot be read or written.
+	ErrArchive = errors.New("archive error")
+	// ErrUnsupportedVersion is raised when an archive was written by an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrUnknownFormat is raised for archive encodings other than json or yaml.
+	ErrUnknownFormat = errors.New("unknown archive format")
+)
+
+func archiveError(err error) error {
+	return fmt.Errorf("%w: %w", ErrArchive, err)
+}
+
+// Header describes an archive.
+type Header struct {
+	Format  string `json:"format"  yaml:"format"`
+	Version int    `json:"version" yaml:"version"`
+}
+
+// Record is a single archived item. Only one of its fields is set.
+type Record struct {
+	Unit       *Unit       `json:"unit,omitempty"       yaml:"unit,omitempty"`
+	Conversion *Conversion `json:"conversion,omitempty" yaml:"conversion,omitempty"`
+	Recipe     *Recipe     `json:"recipe,omitempty"     yaml:"recipe,omitempty"`
+}
+
+// Unit is an archived unit.
+// Unit ids are rebuilt from the unit itself, so the archived id is only informational.
+type Unit struct {
+	ID       string `json:"id,omitempty"       yaml:"id,omitempty"`
+	Name     string `json:"name"               yaml:"name"`
+	Plural   string `json:"plural,omitempty"   yaml:"plural,omitempty"`
+	Symbol   string `json:"symbol"             yaml:"symbol"`
+	BaseType string `json:"baseType,omitempty" yaml:"baseType,omitempty"`
+	System   string `json:"system,omitempty"   yaml:"system,omitempty"`
+}
+
+// Conversion is an archived unit conversion between two unit ids.
+type Conversion struct {
+	From  string  `json:"from"  yaml:"from"`
+	To    string  `json:"to"    yaml:"to"`
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+}
+
+// Recipe is an archived recipe.
+// Recipes without an id are given one seeded from their name.
+type Recipe struct {
+	ID          string       `json:"id,omitempty"        yaml:"id,omitempty"`
+	Name        string       `json:"name"                yaml:"name"`
+	URL         string       `json:"url,omitempty"       yaml:"url,omitempty"`
+	NumServings int          `json:"numServings"         yaml:"numServings"`
+	Steps       []string     `json:"steps"               yaml:"steps"`
+	Ingredients []Ingredient `json:"ingredients"         yaml:"ingredients"`
+	Tags        []string     `json:"tags"                yaml:"tags"`
+	CreatedAt   time.Time    `json:"createdAt"           yaml:"createdAt"`
+	CreatedBy   string       `json:"createdBy,omitempty" yaml:"createdBy,omitempty"`
+	UpdatedAt   time.Time    `json:"updatedAt"           yaml:"updatedAt"`
+	UpdatedBy   string       `json:"updatedBy,omitempty" yaml:"updatedBy,omitempty"`
+}
+
+// Ingredient is an archived recipe ingredient.
+type Ingredient struct {
+	Name     string  `json:"name"           yaml:"name"`
+	Quantity float64 `json:"quantity"       yaml:"quantity"`
+	Unit     string  `json:"unit,omitempty" yaml:"unit,omitempty"`
+}
@@ -0,0 +1,332 @@
+package archive_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/archive"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/sqlite"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepository(t *testing.T) *sqlite.Repository {
+	t.Helper()
+
+	repo, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = repo.Close() })
+
+	if _, err := repo.Migrator().Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return repo
+}
+
+func newQueries(repo *sqlite.Repository) *query.Service {
+	return query.NewService(repo, repo, repo, repo, repo, repo)
+}
+
+func importArchive(t *testing.T, repo archive.Repository, document string, options ...archive.Option) *archive.Report {
+	t.Helper()
+
+	reader, err := archive.NewReader(strings.NewReader(document), archive.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := archive.NewImporter(repo, options...).Import(context.Background(), reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return report
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := archive.ParseFormat("JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, archive.JSON, format)
+
+	format, err = archive.ParseFormat("yml")
+	assert.NoError(t, err)
+	assert.Equal(t, archive.YAML, format)
+
+	_, err = archive.ParseFormat("xml")
+	assert.ErrorIs(t, err, archive.ErrUnknownFormat)
+}
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+
+	records := []archive.Record{
+		{Unit: &archive.Unit{ID: "gram", Name: "gram", Plural: "grams", Symbol: "g", BaseType: "mass", System: "metric"}},
+		{Conversion: &archive.Conversion{From: "gram", To: "kilogram", Ratio: 1000}},
+		{Recipe: &archive.Recipe{
+			ID:          "recipe-1",
+			Name:        "pancakes",
+			NumServings: 4,
+			Steps:       []string{"mix", "cook"},
+			Ingredients: []archive.Ingredient{{Name: "flour", Quantity: 200, Unit: "gram"}},
+			Tags:        []string{"breakfast"},
+			CreatedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			CreatedBy:   "user-1",
+			UpdatedAt:   time.Date(2025, 1, 3, 3, 4, 5, 0, time.UTC),
+			UpdatedBy:   "user-2",
+		}},
+	}
+
+	for _, format := range []archive.Format{archive.JSON, archive.YAML} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			writer, err := archive.NewWriter(&buffer, format)
+			assert.NoError(t, err)
+
+			for _, record := range records {
+				assert.NoError(t, writer.Write(record))
+			}
+
+			assert.NoError(t, writer.Close())
+
+			reader, err := archive.NewReader(&buffer, format)
+			assert.NoError(t, err)
+
+			for _, record := range records {
+				found, err := reader.Read()
+				assert.NoError(t, err)
+				assert.Equal(t, record, found)
+			}
+
+			_, err = reader.Read()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+
+	_, err := archive.NewReader(strings.NewReader(`{"format": "supplyrun", "version": 99}`), archive.JSON)
+	assert.ErrorIs(t, err, archive.ErrUnsupportedVersion)
+
+	_, err = archive.NewReader(strings.NewReader(`{"name": "pancakes"}`), archive.JSON)
+	assert.ErrorIs(t, err, archive.ErrUnsupportedVersion)
+
+	_, err = archive.NewReader(strings.NewReader(`not json`), archive.JSON)
+	assert.ErrorIs(t, err, archive.ErrArchive)
+
+	_, err = archive.NewWriter(io.Discard, "xml")
+	assert.ErrorIs(t, err, archive.ErrUnknownFormat)
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := newRepository(t)
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+	cup := unit.New("cup", "c", unit.US, unit.Volume, unit.WithNoPlural())
+	assert.NoError(t, source.CreateUnit(ctx, cup))
+	assert.NoError(t, source.CreateConversion(ctx, unit.Kilo(gram)))
+
+	for _, name := range []string{"pancakes", "waffles", "crepes"} {
+		result, err := recipe.New(
+			entity.NewID(name), name, created, entity.NewID("user-1"),
+			recipe.SetNumServings(2),
+			recipe.AddStep("mix"),
+			recipe.AddIngredient("flour", 200, gram.ID()),
+			recipe.AddIngredient("milk", 1, cup.ID()),
+			recipe.AddTag("breakfast"),
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, source.CreateRecipe(ctx, result))
+
+		created = created.Add(time.Hour)
+	}
+
+	for _, format := range []archive.Format{archive.JSON, archive.YAML} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			writer, err := archive.NewWriter(&buffer, format)
+			assert.NoError(t, err)
+			assert.NoError(t, archive.Export(ctx, newQueries(source), writer))
+			assert.NoError(t, writer.Close())
+
+			document := buffer.Bytes()
+			target := newRepository(t)
+
+			// A dry run stores nothing
+			reader, err := archive.NewReader(bytes.NewReader(document), format)
+			assert.NoError(t, err)
+
+			report, err := archive.NewImporter(target, archive.DryRun()).Import(ctx, reader)
+			assert.NoError(t, err)
+			assert.Equal(t, 7, report.Count(archive.Created))
+
+			units, err := target.AllUnits(ctx)
+			assert.NoError(t, err)
+			assert.Empty(t, units)
+
+			// The first import creates everything
+			reader, err = archive.NewReader(bytes.NewReader(document), format)
+			assert.NoError(t, err)
+
+			report, err = archive.NewImporter(target).Import(ctx, reader)
+			assert.NoError(t, err)
+			assert.Equal(t, 7, report.Count(archive.Created))
+			assert.Equal(t, 0, report.Count(archive.Invalid))
+
+			expected, err := source.FindRecipes(
+				ctx, query.RecipeFilter{}, query.Pagination{Size: 10}, query.Order{Sort: query.NameSort},
+			)
+			assert.NoError(t, err)
+
+			found, err := target.FindRecipes(
+				ctx, query.RecipeFilter{}, query.Pagination{Size: 10}, query.Order{Sort: query.NameSort},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, found)
+
+			expectedUnits, err := source.AllUnits(ctx)
+			assert.NoError(t, err)
+
+			units, err = target.AllUnits(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUnits, units)
+
+			// Importing again changes nothing
+			reader, err = archive.NewReader(bytes.NewReader(document), format)
+			assert.NoError(t, err)
+
+			report, err = archive.NewImporter(target).Import(ctx, reader)
+			assert.NoError(t, err)
+			assert.Equal(t, 7, report.Count(archive.Unchanged))
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newRepository(t)
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	report := importArchive(t, repo, `{"format": "supplyrun", "version": 1}
+		{"unit": {"name": "gram", "symbol": "g", "baseType": "mass", "system": "metric"}}
+		{"unit": {"name": "", "symbol": ""}}
+		{"conversion": {"from": "metricmassgram", "to": "missing", "ratio": 1000}}
+		{"recipe": {"name": "pancakes", "ingredients": [{"name": "flour", "quantity": 200, "unit": "metricmassgram"}]}}
+		{"recipe": {"id": "recipe-2", "name": ""}}
+		{}`,
+		archive.WithClock(func() time.Time { return now }),
+	)
+
+	seeded := entity.NewSeededID("pancakes")
+
+	assert.Equal(t, []archive.Result{
+		{Kind: "unit", ID: "metricmassgram", Action: archive.Created},
+		{
+			Kind:   "unit",
+			Action: archive.Invalid,
+			Err: &entity.ValidationError{InnerErrors: []error{
+				entity.NewFieldError("name", "unit name cannot be empty"),
+				entity.NewFieldError("symbol", "unit symbol cannot be empty"),
+			}},
+		},
+		{
+			Kind:   "conversion",
+			ID:     "metricmassgram:missing",
+			Action: archive.Invalid,
+			Err: &entity.ValidationError{InnerErrors: []error{
+				entity.NewFieldError("to", "unit does not exist"),
+			}},
+		},
+		{Kind: "recipe", ID: seeded.String(), Action: archive.Created},
+		{
+			Kind:   "recipe",
+			ID:     "recipe-2",
+			Action: archive.Invalid,
+			Err: &entity.ValidationError{InnerErrors: []error{
+				entity.NewFieldError("name", "recipe name cannot be empty"),
+			}},
+		},
+	}, report.Results)
+
+	found, err := repo.GetRecipes(ctx, []entity.ID{seeded})
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, now, found[0].CreatedAt)
+	assert.Equal(t, now, found[0].UpdatedAt)
+
+	// Recipes without an id match on their seeded id, and only newer versions replace them
+	report = importArchive(t, repo, `{"format": "supplyrun", "version": 1}
+		{"recipe": {"name": "pancakes", "numServings": 2}}
+		{"recipe": {"name": "pancakes", "numServings": 2, "updatedAt": "2025-01-01T00:00:00Z"}}
+		{"recipe": {"name": "pancakes", "numServings": 6, "updatedAt": "2025-07-01T00:00:00Z"}}`,
+	)
+	assert.Equal(t, []archive.Action{archive.Unchanged, archive.Unchanged, archive.Updated}, []archive.Action{
+		report.Results[0].Action, report.Results[1].Action, report.Results[2].Action,
+	})
+
+	found, err = repo.GetRecipes(ctx, []entity.ID{seeded})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, found[0].NumServings)
+	assert.Empty(t, found[0].Ingredients)
+}
+
+func TestImportRepoError(t *testing.T) {
+	t.Parallel()
+
+	type repository struct {
+		*mock.RecipeRepository
+		*mock.UnitRepository
+		*mock.QueryUnitRepository
+	}
+
+	repo := repository{
+		RecipeRepository:    &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+		UnitRepository:      &mock.UnitRepository{CreateUnitErr: errors.New("some random error")},
+		QueryUnitRepository: &mock.QueryUnitRepository{},
+	}
+
+	reader, err := archive.NewReader(strings.NewReader(`{"format": "supplyrun", "version": 1}
+		{"recipe": {"name": "pancakes"}}
+		{"unit": {"name": "gram", "symbol": "g"}}
+		{"recipe": {"name": "waffles"}}`,
+	), archive.JSON)
+	assert.NoError(t, err)
+
+	report, err := archive.NewImporter(repo).Import(context.Background(), reader)
+	assert.ErrorIs(t, err, archive.ErrArchive)
+	assert.Len(t, report.Results, 1)
+
+	// Broken records stop the import as well
+	reader, err = archive.NewReader(strings.NewReader(`{"format": "supplyrun", "version": 1}
+		{"recipe": "pancakes"}`,
+	), archive.JSON)
+	assert.NoError(t, err)
+
+	_, err = archive.NewImporter(repo).Import(context.Background(), reader)
+	assert.ErrorIs(t, err, archive.ErrArchive)
+}
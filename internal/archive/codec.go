@@ -0,0 +1,135 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Format is an archive encoding.
+type Format string
+
+// JSON and YAML are the supported archive encodings.
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// ParseFormat returns the archive Format with the given name.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case JSON:
+		return JSON, nil
+	case YAML, "yml":
+		return YAML, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+}
+
+type encoder interface {
+	Encode(value any) error
+}
+
+type decoder interface {
+	Decode(value any) error
+}
+
+// Writer streams records into an archive.
+type Writer struct {
+	encoder encoder
+	closer  io.Closer
+}
+
+// NewWriter creates a new Writer and writes the archive Header.
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	writer := &Writer{}
+
+	switch format {
+	case JSON:
+		writer.encoder = json.NewEncoder(w)
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		writer.encoder = encoder
+		writer.closer = encoder
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err := writer.encoder.Encode(Header{Format: FormatName, Version: Version}); err != nil {
+		return nil, archiveError(err)
+	}
+
+	return writer, nil
+}
+
+// Write adds a record to the archive.
+func (w *Writer) Write(record Record) error {
+	if err := w.encoder.Encode(record); err != nil {
+		return archiveError(err)
+	}
+
+	return nil
+}
+
+// Close flushes anything left to write.
+func (w *Writer) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+
+	if err := w.closer.Close(); err != nil {
+		return archiveError(err)
+	}
+
+	return nil
+}
+
+// Reader streams records out of an archive.
+type Reader struct {
+	decoder decoder
+}
+
+// NewReader creates a new Reader, checking the archive Header.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	reader := &Reader{}
+
+	switch format {
+	case JSON:
+		reader.decoder = json.NewDecoder(r)
+	case YAML:
+		reader.decoder = yaml.NewDecoder(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	var header Header
+	if err := reader.decoder.Decode(&header); err != nil {
+		return nil, archiveError(err)
+	}
+
+	if header.Format != FormatName || header.Version < 1 || header.Version > Version {
+		return nil, fmt.Errorf("%w: %s %d", ErrUnsupportedVersion, header.Format, header.Version)
+	}
+
+	return reader, nil
+}
+
+// Read returns the next record in the archive, or io.EOF once there are none left.
+func (r *Reader) Read() (Record, error) {
+	var record Record
+
+	if err := r.decoder.Decode(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+
+		return Record{}, archiveError(err)
+	}
+
+	return record, nil
+}
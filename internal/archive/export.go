@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// exportPageSize is how many recipes are loaded at a time while exporting.
+const exportPageSize = 100
+
+// Export writes every unit, conversion and recipe to an archive.
+// Recipes are paged through oldest first, so the library never has to fit in memory.
+func Export(ctx context.Context, queries *query.Service, writer *Writer) error {
+	units, err := queries.FindUnits(ctx, query.UnitFilter{})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]entity.ID, len(units))
+
+	for i, found := range units {
+		ids[i] = found.ID
+
+		if err := writer.Write(Record{Unit: newUnit(found)}); err != nil {
+			return err
+		}
+	}
+
+	conversions, err := queries.GetConversions(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, found := range conversions {
+		if err := writer.Write(Record{Conversion: newConversion(found)}); err != nil {
+			return err
+		}
+	}
+
+	page := query.Pagination{Cursor: nil, Size: exportPageSize, Backward: false}
+	order := query.Order{Sort: query.CreatedSort, Direction: query.AscDirection}
+
+	for {
+		found, err := queries.FindRecipes(ctx, query.RecipeFilter{}, page, order)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range found.Items {
+			if err := writer.Write(Record{Recipe: newRecipe(item)}); err != nil {
+				return err
+			}
+		}
+
+		if !found.Info.HasNextPage {
+			return nil
+		}
+
+		page.Cursor = found.Info.EndCursor
+	}
+}
+
+func newUnit(found *query.Unit) *Unit {
+	return &Unit{
+		ID:       found.ID.String(),
+		Name:     found.Name,
+		Plural:   found.Plural,
+		Symbol:   found.Symbol,
+		BaseType: found.BaseType,
+		System:   found.System,
+	}
+}
+
+func newConversion(found *query.Conversion) *Conversion {
+	return &Conversion{
+		From:  found.FromID.String(),
+		To:    found.ToID.String(),
+		Ratio: found.Ratio,
+	}
+}
+
+func newRecipe(found *query.Recipe) *Recipe {
+	result := &Recipe{
+		ID:          found.ID.String(),
+		Name:        found.Name,
+		URL:         found.URL,
+		NumServings: found.NumServings,
+		Steps:       found.Steps,
+		Ingredients: make([]Ingredient, len(found.Ingredients)),
+		Tags:        found.Tags,
+		CreatedAt:   found.CreatedAt,
+		CreatedBy:   found.CreatedBy.String(),
+		UpdatedAt:   found.UpdatedAt,
+		UpdatedBy:   found.UpdatedBy.String(),
+	}
+
+	for i, ingredient := range found.Ingredients {
+		result.Ingredients[i] = Ingredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.UnitID.String(),
+		}
+	}
+
+	return result
+}
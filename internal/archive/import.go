@@ -0,0 +1,332 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// Repository defines all data interactions required for importing an archive.
+type Repository interface {
+	recipe.Repository
+	unit.Repository
+	GetConversions(ctx context.Context, ids []entity.ID) ([]*query.Conversion, error)
+}
+
+// Action is what an import did with a record.
+type Action string
+
+// Created, et al. are the possible import actions.
+const (
+	Created   Action = "created"
+	Updated   Action = "updated"
+	Unchanged Action = "unchanged"
+	Invalid   Action = "invalid"
+)
+
+// Result is the outcome of importing a single record.
+// Err is only set for invalid records.
+type Result struct {
+	Kind   string
+	ID     string
+	Action Action
+	Err    error
+}
+
+// Report is the outcome of importing a whole archive.
+type Report struct {
+	Results []Result
+}
+
+// Count returns how many records had the given action.
+func (r *Report) Count(action Action) int {
+	count := 0
+
+	for _, result := range r.Results {
+		if result.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Importer loads archives into a Repository.
+// Records are written straight to the Repository, bypassing the command Service, so recipe search indexes and
+// change subscribers never hear about them; they must be rebuilt, e.g. by restarting the server.
+type Importer struct {
+	repo   Repository
+	dryRun bool
+	now    func() time.Time
+	units  map[entity.ID]*unit.Unit
+}
+
+// NewImporter creates a new Importer.
+func NewImporter(repo Repository, options ...Option) *Importer {
+	importer := &Importer{
+		repo:   repo,
+		dryRun: false,
+		now:    time.Now,
+		units:  make(map[entity.ID]*unit.Unit),
+	}
+
+	for _, option := range options {
+		option(importer)
+	}
+
+	return importer
+}
+
+// Import reads every record from an archive and stores it.
+// Units and recipes are matched on their id, so importing the same archive again changes nothing.
+// Existing recipes are only replaced by newer versions, so recipes archived without timestamps are never replaced.
+// Invalid records are reported in the Report without stopping the import; any other error stops it.
+func (i *Importer) Import(ctx context.Context, reader *Reader) (*Report, error) {
+	report := &Report{
+		Results: make([]Result, 0),
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+
+		if err != nil {
+			return report, err
+		}
+
+		var result Result
+
+		switch {
+		case record.Unit != nil:
+			result, err = i.importUnit(ctx, record.Unit)
+		case record.Conversion != nil:
+			result, err = i.importConversion(ctx, record.Conversion)
+		case record.Recipe != nil:
+			result, err = i.importRecipe(ctx, record.Recipe)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return report, err
+		}
+
+		report.Results = append(report.Results, result)
+	}
+}
+
+func (i *Importer) importUnit(ctx context.Context, record *Unit) (Result, error) {
+	result := Result{Kind: "unit", ID: record.ID, Action: Unchanged, Err: nil}
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	if record.Name == "" {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("name", "unit name cannot be empty"))
+	}
+
+	if record.Symbol == "" {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("symbol", "unit symbol cannot be empty"))
+	}
+
+	if !validation.IsEmpty() {
+		return invalid(result, validation), nil
+	}
+
+	options := []unit.Option{unit.SetSystem(record.System), unit.SetBaseType(record.BaseType)}
+	if record.Plural != "" {
+		options = append(options, unit.WithCustomPlural(record.Plural))
+	}
+
+	found := unit.New(record.Name, record.Symbol, options...)
+	result.ID = found.ID().String()
+	i.units[found.ID()] = found
+
+	_, err := i.repo.GetUnit(ctx, found.ID())
+	if err == nil {
+		return result, nil
+	}
+
+	if !errors.Is(err, entity.ErrNotFound) {
+		return result, archiveError(err)
+	}
+
+	result.Action = Created
+
+	if i.dryRun {
+		return result, nil
+	}
+
+	if err := i.repo.CreateUnit(ctx, found); err != nil {
+		return result, archiveError(err)
+	}
+
+	return result, nil
+}
+
+func (i *Importer) importConversion(ctx context.Context, record *Conversion) (Result, error) {
+	result := Result{Kind: "conversion", ID: record.From + ":" + record.To, Action: Unchanged, Err: nil}
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	from, err := i.getUnit(ctx, "from", entity.NewID(record.From), validation)
+	if err != nil {
+		return result, err
+	}
+
+	to, err := i.getUnit(ctx, "to", entity.NewID(record.To), validation)
+	if err != nil {
+		return result, err
+	}
+
+	if !validation.IsEmpty() {
+		return invalid(result, validation), nil
+	}
+
+	conversion, err := unit.NewConversion(from, to, record.Ratio)
+	if err != nil {
+		return invalid(result, err), nil
+	}
+
+	existing, err := i.repo.GetConversions(ctx, []entity.ID{from.ID()})
+	if err != nil {
+		return result, archiveError(err)
+	}
+
+	for _, found := range existing {
+		if found.FromID == from.ID() && found.ToID == to.ID() {
+			return result, nil
+		}
+	}
+
+	result.Action = Created
+
+	if i.dryRun {
+		return result, nil
+	}
+
+	if err := i.repo.CreateConversion(ctx, conversion); err != nil {
+		return result, archiveError(err)
+	}
+
+	return result, nil
+}
+
+// getUnit finds a unit from earlier in the archive or already stored, recording a field error if neither has it.
+func (i *Importer) getUnit(
+	ctx context.Context,
+	field string,
+	id entity.ID,
+	validation *entity.ValidationError,
+) (*unit.Unit, error) {
+	if found, ok := i.units[id]; ok {
+		return found, nil
+	}
+
+	found, err := i.repo.GetUnit(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError(field, "unit does not exist"))
+
+			return nil, nil //nolint: nilnil
+		}
+
+		return nil, archiveError(err)
+	}
+
+	return found, nil
+}
+
+func (i *Importer) importRecipe(ctx context.Context, record *Recipe) (Result, error) {
+	id := entity.NewID(record.ID)
+	if id.String() == "" {
+		id = entity.NewSeededID(record.Name)
+	}
+
+	result := Result{Kind: "recipe", ID: id.String(), Action: Unchanged, Err: nil}
+
+	createdAt, updatedAt := record.CreatedAt, record.UpdatedAt
+	if createdAt.IsZero() {
+		createdAt = i.now()
+	}
+
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+
+	options := []recipe.Option{
+		recipe.SetNumServings(record.NumServings),
+		recipe.SetUpdated(updatedAt, entity.NewID(record.UpdatedBy)),
+	}
+
+	if record.URL != "" {
+		options = append(options, recipe.SetURL(record.URL))
+	}
+
+	for _, step := range record.Steps {
+		options = append(options, recipe.AddStep(step))
+	}
+
+	for _, ingredient := range record.Ingredients {
+		options = append(
+			options,
+			recipe.AddIngredient(ingredient.Name, ingredient.Quantity, entity.NewID(ingredient.Unit)),
+		)
+	}
+
+	for _, tag := range record.Tags {
+		options = append(options, recipe.AddTag(tag))
+	}
+
+	imported, err := recipe.New(id, record.Name, createdAt, entity.NewID(record.CreatedBy), options...)
+	if err != nil {
+		return invalid(result, err), nil
+	}
+
+	existing, err := i.repo.GetRecipe(ctx, id)
+
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		result.Action = Created
+	case err != nil:
+		return result, archiveError(err)
+	case !record.UpdatedAt.IsZero() && imported.UpdatedAt().After(existing.UpdatedAt()):
+		result.Action = Updated
+	default:
+		return result, nil
+	}
+
+	if i.dryRun {
+		return result, nil
+	}
+
+	if result.Action == Created {
+		err = i.repo.CreateRecipe(ctx, imported)
+	} else {
+		err = i.repo.UpdateRecipe(ctx, imported)
+	}
+
+	if err != nil {
+		return result, archiveError(err)
+	}
+
+	return result, nil
+}
+
+func invalid(result Result, err error) Result {
+	result.Action = Invalid
+	result.Err = err
+
+	return result
+}
@@ -0,0 +1,20 @@
+package archive
+
+import "time"
+
+// Option is an Importer creation option.
+type Option func(i *Importer)
+
+// DryRun checks every record and reports what would change, without storing anything.
+func DryRun() Option {
+	return func(i *Importer) {
+		i.dryRun = true
+	}
+}
+
+// WithClock sets the clock used for recipes archived without timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(i *Importer) {
+		i.now = now
+	}
+}
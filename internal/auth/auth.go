@@ -0,0 +1,222 @@
+// Package auth implements bearer token authentication against an OpenID Connect issuer.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/rs/zerolog"
+)
+
+type ctxKey string
+
+const userKey = ctxKey("user")
+
+// ErrUnauthorized is raised when a request cannot be authenticated.
+var ErrUnauthorized = errors.New("unauthorized")
+
+func unauthorizedError(err error) error {
+	return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+}
+
+// Identity is the authenticated user behind a token.
+type Identity struct {
+	ID       entity.ID
+	Username string
+}
+
+// Provisioner creates or updates the user behind an Identity.
+type Provisioner func(ctx context.Context, identity Identity) error
+
+// Authenticator validates bearer tokens.
+type Authenticator struct {
+	issuer      string
+	jwksURL     string
+	audience    string
+	now         func() time.Time
+	verifier    *oidc.IDTokenVerifier
+	provisioner Provisioner
+	provisioned sync.Map
+}
+
+// New creates a new Authenticator for tokens from the given issuer.
+// Signing keys are fetched from the issuer's discovery document unless a JWKS url is set.
+// Keys are cached and refreshed whenever a token is signed with an unknown key.
+func New(ctx context.Context, issuer string, options ...Option) (*Authenticator, error) {
+	authenticator := &Authenticator{
+		issuer:      issuer,
+		jwksURL:     "",
+		audience:    "",
+		now:         time.Now,
+		verifier:    nil,
+		provisioner: nil,
+		provisioned: sync.Map{},
+	}
+
+	for _, option := range options {
+		option(authenticator)
+	}
+
+	config := &oidc.Config{
+		ClientID:          authenticator.audience,
+		SkipClientIDCheck: authenticator.audience == "",
+		Now:               authenticator.now,
+	}
+
+	if authenticator.jwksURL != "" {
+		authenticator.verifier = oidc.NewVerifier(issuer, oidc.NewRemoteKeySet(ctx, authenticator.jwksURL), config)
+
+		return authenticator, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover %q: %w", issuer, err)
+	}
+
+	authenticator.verifier = provider.Verifier(config)
+
+	return authenticator, nil
+}
+
+// Authenticate validates a raw token and returns the identity of the user it belongs to.
+// The username is taken from the preferred_username claim, falling back to name and then the subject.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (Identity, error) {
+	verified, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return Identity{}, unauthorizedError(err)
+	}
+
+	var claims struct {
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+	}
+
+	if err := verified.Claims(&claims); err != nil {
+		return Identity{}, unauthorizedError(err)
+	}
+
+	identity := Identity{
+		ID:       UserID(verified.Issuer, verified.Subject),
+		Username: verified.Subject,
+	}
+
+	switch {
+	case claims.PreferredUsername != "":
+		identity.Username = claims.PreferredUsername
+	case claims.Name != "":
+		identity.Username = claims.Name
+	}
+
+	return identity, nil
+}
+
+// provision runs the Provisioner for identities not yet seen with their current username.
+func (a *Authenticator) provision(ctx context.Context, identity Identity) error {
+	if a.provisioner == nil {
+		return nil
+	}
+
+	if username, ok := a.provisioned.Load(identity.ID); ok && username == identity.Username {
+		return nil
+	}
+
+	if err := a.provisioner(ctx, identity); err != nil {
+		return err
+	}
+
+	a.provisioned.Store(identity.ID, identity.Username)
+
+	return nil
+}
+
+// Login validates a raw token, provisions its user if needed and stores the user id in the returned Context.
+func (a *Authenticator) Login(ctx context.Context, token string) (context.Context, error) {
+	identity, err := a.Authenticate(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+
+	if err := a.provision(ctx, identity); err != nil {
+		return ctx, err
+	}
+
+	return ToContext(ctx, identity.ID), nil
+}
+
+// Middleware rejects any request without a valid bearer token.
+// Unknown users are provisioned and the authenticated user id is stored in the request Context.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		token, ok := BearerToken(request.Header.Get("Authorization"))
+		if !ok {
+			writer.Header().Set("WWW-Authenticate", `Bearer`)
+			http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		identity, err := a.Authenticate(request.Context(), token)
+		if err != nil {
+			zerolog.Ctx(request.Context()).Warn().Err(err).Msg("rejected bearer token")
+			writer.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		if err := a.provision(request.Context(), identity); err != nil {
+			zerolog.Ctx(request.Context()).Error().Err(err).Msg("failed to provision user")
+			http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		next.ServeHTTP(writer, request.WithContext(ToContext(request.Context(), identity.ID)))
+	})
+}
+
+// Anonymous serves every request as the anonymous user, for servers running without authentication.
+func Anonymous(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		next.ServeHTTP(writer, request.WithContext(ToContext(request.Context(), entity.NewID(""))))
+	})
+}
+
+// BearerToken returns the token of an Authorization value using the bearer scheme.
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+// UserID returns the deterministic user id for a subject of an issuer. The two are separated by a NUL byte so that
+// different issuer and subject pairs can never share a seed.
+func UserID(issuer string, subject string) entity.ID {
+	return entity.NewSeededID(issuer + "\x00" + subject)
+}
+
+// FromContext returns the authenticated user id if it exists in the given Context.
+func FromContext(ctx context.Context) (entity.ID, error) {
+	id, ok := ctx.Value(userKey).(entity.ID)
+	if !ok {
+		return entity.ID{}, ErrUnauthorized
+	}
+
+	return id, nil
+}
+
+// ToContext stores an authenticated user id in the given Context.
+func ToContext(ctx context.Context, id entity.ID) context.Context {
+	return context.WithValue(ctx, userKey, id)
+}
@@ -0,0 +1,453 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const issuer = "http://keycloak.test/realms/supplyrun"
+
+var now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// keySet is a local JWKS endpoint whose keys can be rotated.
+type keySet struct {
+	mutex sync.Mutex
+	keys  map[string]*rsa.PrivateKey
+}
+
+func newKeySet(t *testing.T, kids ...string) (*keySet, *httptest.Server) {
+	t.Helper()
+
+	set := &keySet{keys: make(map[string]*rsa.PrivateKey)}
+	for _, kid := range kids {
+		set.add(t, kid)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		set.mutex.Lock()
+		defer set.mutex.Unlock()
+
+		result := jose.JSONWebKeySet{}
+		for kid, key := range set.keys {
+			result.Keys = append(result.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: "RS256", Use: "sig"})
+		}
+
+		_ = json.NewEncoder(writer).Encode(result)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return set, server
+}
+
+func (s *keySet) add(t *testing.T, kid string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.keys[kid] = key
+}
+
+func (s *keySet) sign(t *testing.T, kid string, claims jwt.Claims, extra ...any) string {
+	t.Helper()
+
+	s.mutex.Lock()
+	key := s.keys[kid]
+	s.mutex.Unlock()
+
+	if key == nil {
+		var err error
+
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := jwt.Signed(signer).Claims(claims)
+	for _, item := range extra {
+		builder = builder.Claims(item)
+	}
+
+	token, err := builder.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func newClaims(subject string) jwt.Claims {
+	return jwt.Claims{
+		Issuer:   issuer,
+		Subject:  subject,
+		Audience: jwt.Audience{"supplyrun"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+}
+
+func newAuthenticator(t *testing.T, jwksURL string, options ...auth.Option) *auth.Authenticator {
+	t.Helper()
+
+	options = append(
+		[]auth.Option{auth.SetJWKSURL(jwksURL), auth.WithClock(func() time.Time { return now })},
+		options...,
+	)
+
+	authenticator, err := auth.New(context.Background(), issuer, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return authenticator
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	keys, server := newKeySet(t, "key-1")
+
+	type testCase struct {
+		token  func() string
+		result auth.Identity
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"valid": {
+			token: func() string {
+				return keys.sign(t, "key-1", newClaims("user-123"), map[string]any{"preferred_username": "tester"})
+			},
+			result: auth.Identity{ID: auth.UserID(issuer, "user-123"), Username: "tester"},
+			err:    nil,
+		},
+		"name fallback": {
+			token: func() string {
+				return keys.sign(t, "key-1", newClaims("user-123"), map[string]any{"name": "Test User"})
+			},
+			result: auth.Identity{ID: auth.UserID(issuer, "user-123"), Username: "Test User"},
+			err:    nil,
+		},
+		"subject fallback": {
+			token:  func() string { return keys.sign(t, "key-1", newClaims("user-123")) },
+			result: auth.Identity{ID: auth.UserID(issuer, "user-123"), Username: "user-123"},
+			err:    nil,
+		},
+		"expired": {
+			token: func() string {
+				claims := newClaims("user-123")
+				claims.Expiry = jwt.NewNumericDate(now.Add(-time.Minute))
+
+				return keys.sign(t, "key-1", claims)
+			},
+			err: auth.ErrUnauthorized,
+		},
+		"wrong issuer": {
+			token: func() string {
+				claims := newClaims("user-123")
+				claims.Issuer = "http://someone.else"
+
+				return keys.sign(t, "key-1", claims)
+			},
+			err: auth.ErrUnauthorized,
+		},
+		"wrong audience": {
+			token: func() string {
+				claims := newClaims("user-123")
+				claims.Audience = jwt.Audience{"another-app"}
+
+				return keys.sign(t, "key-1", claims)
+			},
+			err: auth.ErrUnauthorized,
+		},
+		"unknown key": {
+			token: func() string { return keys.sign(t, "forged", newClaims("user-123")) },
+			err:   auth.ErrUnauthorized,
+		},
+		"garbage": {
+			token: func() string { return "not.a.token" },
+			err:   auth.ErrUnauthorized,
+		},
+	}
+
+	authenticator := newAuthenticator(t, server.URL, auth.SetAudience("supplyrun"))
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := authenticator.Authenticate(context.Background(), test.token())
+
+			if test.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, test.result, result)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	keys, server := newKeySet(t, "key-1")
+	authenticator := newAuthenticator(t, server.URL)
+
+	// Cache the first key
+	_, err := authenticator.Authenticate(context.Background(), keys.sign(t, "key-1", newClaims("user-123")))
+	assert.NoError(t, err)
+
+	// Rotate in a new key
+	keys.add(t, "key-2")
+
+	result, err := authenticator.Authenticate(context.Background(), keys.sign(t, "key-2", newClaims("user-123")))
+	assert.NoError(t, err)
+	assert.Equal(t, auth.UserID(issuer, "user-123"), result.ID)
+}
+
+func TestNewDiscoveryError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	_, err := auth.New(context.Background(), server.URL)
+	assert.Error(t, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	keys, server := newKeySet(t, "key-1")
+	authenticator := newAuthenticator(t, server.URL)
+
+	type testCase struct {
+		header string
+		status int
+		user   entity.ID
+	}
+
+	tests := map[string]testCase{
+		"valid token": {
+			header: "Bearer " + keys.sign(t, "key-1", newClaims("user-123")),
+			status: http.StatusOK,
+			user:   auth.UserID(issuer, "user-123"),
+		},
+		"lowercase scheme": {
+			header: "bearer " + keys.sign(t, "key-1", newClaims("user-123")),
+			status: http.StatusOK,
+			user:   auth.UserID(issuer, "user-123"),
+		},
+		"missing header": {
+			header: "",
+			status: http.StatusUnauthorized,
+		},
+		"wrong scheme": {
+			header: "Basic dXNlcjpwYXNz",
+			status: http.StatusUnauthorized,
+		},
+		"invalid token": {
+			header: "Bearer " + keys.sign(t, "forged", newClaims("user-123")),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var user entity.ID
+
+			handler := authenticator.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+				user, _ = auth.FromContext(request.Context())
+			}))
+
+			request := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			if test.header != "" {
+				request.Header.Set("Authorization", test.header)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.user, user)
+
+			if test.status == http.StatusUnauthorized {
+				assert.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	keys, server := newKeySet(t, "key-1")
+
+	var provisioned []auth.Identity
+
+	provisioner := func(_ context.Context, identity auth.Identity) error {
+		provisioned = append(provisioned, identity)
+
+		return nil
+	}
+
+	authenticator := newAuthenticator(t, server.URL, auth.SetProvisioner(provisioner))
+
+	ctx, err := authenticator.Login(context.Background(), keys.sign(t, "key-1", newClaims("user-123")))
+	assert.NoError(t, err)
+
+	user, err := auth.FromContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, auth.UserID(issuer, "user-123"), user)
+	assert.Equal(t, []auth.Identity{{ID: auth.UserID(issuer, "user-123"), Username: "user-123"}}, provisioned)
+
+	ctx, err = authenticator.Login(context.Background(), keys.sign(t, "forged", newClaims("user-123")))
+	assert.ErrorIs(t, err, auth.ErrUnauthorized)
+
+	_, err = auth.FromContext(ctx)
+	assert.ErrorIs(t, err, auth.ErrUnauthorized)
+}
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		authorization string
+		token         string
+		ok            bool
+	}
+
+	tests := map[string]testCase{
+		"bearer":         {authorization: "Bearer abc", token: "abc", ok: true},
+		"lowercase":      {authorization: "bearer abc", token: "abc", ok: true},
+		"empty":          {authorization: "", token: "", ok: false},
+		"missing token":  {authorization: "Bearer ", token: "", ok: false},
+		"another scheme": {authorization: "Basic dXNlcjpwYXNz", token: "", ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			token, ok := auth.BearerToken(test.authorization)
+
+			assert.Equal(t, test.token, token)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestAnonymous(t *testing.T) {
+	t.Parallel()
+
+	var (
+		user entity.ID
+		err  error
+	)
+
+	handler := auth.Anonymous(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+		user, err = auth.FromContext(request.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID(""), user)
+}
+
+func TestMiddlewareProvisioning(t *testing.T) {
+	t.Parallel()
+
+	keys, server := newKeySet(t, "key-1")
+
+	var (
+		provisioned []auth.Identity
+		fail        bool
+	)
+
+	authenticator := newAuthenticator(t, server.URL, auth.SetProvisioner(func(_ context.Context, identity auth.Identity) error {
+		if fail {
+			return errors.New("some random error")
+		}
+
+		provisioned = append(provisioned, identity)
+
+		return nil
+	}))
+
+	handler := authenticator.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	serve := func(username string) int {
+		request := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		request.Header.Set(
+			"Authorization",
+			"Bearer "+keys.sign(t, "key-1", newClaims("user-123"), map[string]any{"preferred_username": username}),
+		)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	// Provision once per username
+	assert.Equal(t, http.StatusOK, serve("tester"))
+	assert.Equal(t, http.StatusOK, serve("tester"))
+	assert.Equal(t, http.StatusOK, serve("renamed"))
+	assert.Equal(t, []auth.Identity{
+		{ID: auth.UserID(issuer, "user-123"), Username: "tester"},
+		{ID: auth.UserID(issuer, "user-123"), Username: "renamed"},
+	}, provisioned)
+
+	// Provisioning failures reject the request
+	fail = true
+
+	assert.Equal(t, http.StatusInternalServerError, serve("another"))
+}
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	_, err := auth.FromContext(context.Background())
+	assert.ErrorIs(t, err, auth.ErrUnauthorized)
+
+	id, err := auth.FromContext(auth.ToContext(context.Background(), entity.NewID("user-123")))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID("user-123"), id)
+}
+
+func TestUserID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, auth.UserID("https://issuer", "user-123"), auth.UserID("https://issuer", "user-123"))
+	assert.NotEqual(t, auth.UserID("https://issuer", "user-123"), auth.UserID("https://issuer/", "user-123"))
+	assert.NotEqual(t, auth.UserID("https://a", "bc"), auth.UserID("https://ab", "c"))
+}
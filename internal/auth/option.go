@@ -0,0 +1,34 @@
+package auth
+
+import "time"
+
+// Option is an Authenticator creation option.
+type Option func(a *Authenticator)
+
+// SetJWKSURL sets where signing keys are fetched from, skipping issuer discovery.
+func SetJWKSURL(url string) Option {
+	return func(a *Authenticator) {
+		a.jwksURL = url
+	}
+}
+
+// SetAudience requires tokens to be issued for the given audience.
+func SetAudience(audience string) Option {
+	return func(a *Authenticator) {
+		a.audience = audience
+	}
+}
+
+// WithClock overrides how the Authenticator gets the current time.
+func WithClock(now func() time.Time) Option {
+	return func(a *Authenticator) {
+		a.now = now
+	}
+}
+
+// SetProvisioner runs the given Provisioner whenever a new user, or a new username, authenticates.
+func SetProvisioner(provisioner Provisioner) Option {
+	return func(a *Authenticator) {
+		a.provisioner = provisioner
+	}
+}
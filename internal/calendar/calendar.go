@@ -0,0 +1,146 @@
+// Package calendar serves planned meals as an iCalendar (RFC 5545) feed.
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/rs/zerolog"
+)
+
+const (
+	contentType = "text/calendar; charset=utf-8"
+	uidDomain   = "supply-run"
+	pastDays    = 30
+	futureDays  = 365
+)
+
+// Feed is an HTTP handler serving the meal plan of a user.
+// Requests are authenticated by the signed user and token query parameters instead of a bearer token.
+type Feed struct {
+	queries *query.Service
+	signer  *Signer
+}
+
+// New creates a new meal plan Feed.
+func New(queries *query.Service, signer *Signer) *Feed {
+	return &Feed{
+		queries: queries,
+		signer:  signer,
+	}
+}
+
+// ServeHTTP writes the meals planned from a month ago up to a year from now.
+func (f *Feed) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+
+	userID := entity.NewID(request.URL.Query().Get("user"))
+	if !f.signer.Verify(userID, request.URL.Query().Get("token")) {
+		http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour) //nolint: mnd
+
+	meals, err := f.queries.FindMeals(ctx, userID, today.AddDate(0, 0, -pastDays), today.AddDate(0, 0, futureDays))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to find meals")
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	names, err := f.recipeNames(ctx, meals)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to find recipes")
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	var body bytes.Buffer
+
+	encoder := newEncoder(&body)
+	encoder.begin("VCALENDAR")
+	encoder.property("VERSION", "2.0")
+	encoder.property("PRODID", "-//b-sea//Supply Run//EN")
+	encoder.property("CALSCALE", "GREGORIAN")
+	encoder.text("X-WR-CALNAME", "Meal Plan")
+
+	for _, meal := range meals {
+		writeMeal(encoder, meal, names[meal.RecipeID])
+	}
+
+	encoder.end("VCALENDAR")
+
+	writer.Header().Set("Content-Type", contentType)
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(body.Bytes())
+}
+
+func (f *Feed) recipeNames(ctx context.Context, meals []*query.Meal) (map[entity.ID]string, error) {
+	ids := make([]entity.ID, 0, len(meals))
+	seen := make(map[entity.ID]bool, len(meals))
+
+	for _, meal := range meals {
+		if seen[meal.RecipeID] {
+			continue
+		}
+
+		seen[meal.RecipeID] = true
+		ids = append(ids, meal.RecipeID)
+	}
+
+	result := make(map[entity.ID]string, len(ids))
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	recipes, err := f.queries.GetRecipes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recipe := range recipes {
+		result[recipe.ID] = recipe.Name
+	}
+
+	return result, nil
+}
+
+// writeMeal writes a meal as an all-day event.
+func writeMeal(encoder *encoder, meal *query.Meal, recipeName string) {
+	summary := slotTitle(meal.Slot)
+	if recipeName != "" {
+		summary += ": " + recipeName
+	}
+
+	encoder.begin("VEVENT")
+	encoder.property("UID", meal.ID.String()+"@"+uidDomain)
+	encoder.property("DTSTAMP", meal.UpdatedAt.UTC().Format("20060102T150405Z"))
+	encoder.property("DTSTART;VALUE=DATE", meal.Date.Format("20060102"))
+	encoder.property("DTEND;VALUE=DATE", meal.Date.AddDate(0, 0, 1).Format("20060102"))
+	encoder.text("SUMMARY", summary)
+
+	if meal.Servings > 0 {
+		encoder.text("DESCRIPTION", "Servings: "+strconv.Itoa(meal.Servings))
+	}
+
+	encoder.end("VEVENT")
+}
+
+func slotTitle(slot string) string {
+	if slot == "" {
+		return "Meal"
+	}
+
+	return strings.ToUpper(slot[:1]) + slot[1:]
+}
@@ -0,0 +1,179 @@
+package calendar_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeed(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 2, 1, 12, 30, 0, 0, time.UTC)
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{ID: entity.NewID("R1"), Name: "Pancakes, with syrup; extra"},
+			{ID: entity.NewID("R2"), Name: strings.Repeat("très long ", 10)},
+		},
+	}
+
+	signer := calendar.NewSigner([]byte("secret"))
+	link := signer.Link(entity.NewID("user-1"))
+
+	type testCase struct {
+		recipes query.RecipeRepository
+		meals   query.MealRepository
+		path    string
+		status  int
+		body    string
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			recipes: recipes,
+			meals:   &mock.QueryMealRepository{},
+			path:    link,
+			status:  http.StatusOK,
+			body: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//b-sea//Supply Run//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"X-WR-CALNAME:Meal Plan\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		"meals": {
+			recipes: recipes,
+			meals: &mock.QueryMealRepository{
+				FindMealsResult: []*query.Meal{
+					{
+						ID:        entity.NewID("M1"),
+						Date:      monday,
+						Slot:      "breakfast",
+						RecipeID:  entity.NewID("R1"),
+						Servings:  4,
+						UpdatedAt: updated,
+					},
+					{
+						ID:        entity.NewID("M2"),
+						Date:      monday.AddDate(0, 0, 1),
+						Slot:      "dinner",
+						RecipeID:  entity.NewID("R2"),
+						UpdatedAt: updated,
+					},
+				},
+			},
+			path:   link,
+			status: http.StatusOK,
+			body: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//b-sea//Supply Run//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"X-WR-CALNAME:Meal Plan\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:M1@supply-run\r\n" +
+				"DTSTAMP:20250201T123000Z\r\n" +
+				"DTSTART;VALUE=DATE:20250303\r\n" +
+				"DTEND;VALUE=DATE:20250304\r\n" +
+				"SUMMARY:Breakfast: Pancakes\\, with syrup\\; extra\r\n" +
+				"DESCRIPTION:Servings: 4\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:M2@supply-run\r\n" +
+				"DTSTAMP:20250201T123000Z\r\n" +
+				"DTSTART;VALUE=DATE:20250304\r\n" +
+				"DTEND;VALUE=DATE:20250305\r\n" +
+				"SUMMARY:Dinner: très long très long très long très long très long trè\r\n" +
+				" s long très long très long très long très long \r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		"meal error": {
+			recipes: recipes,
+			meals:   &mock.QueryMealRepository{FindMealsErr: errors.New("some random error")},
+			path:    link,
+			status:  http.StatusInternalServerError,
+			body:    "Internal Server Error\n",
+		},
+		"recipe error": {
+			recipes: &mock.QueryRecipeRepository{GetRecipesErr: errors.New("some random error")},
+			meals: &mock.QueryMealRepository{
+				FindMealsResult: []*query.Meal{{ID: entity.NewID("M1"), RecipeID: entity.NewID("R1")}},
+			},
+			path:   link,
+			status: http.StatusInternalServerError,
+			body:   "Internal Server Error\n",
+		},
+		"missing token": {
+			recipes: recipes,
+			meals:   &mock.QueryMealRepository{},
+			path:    "/calendar.ics?user=user-1",
+			status:  http.StatusUnauthorized,
+			body:    "Unauthorized\n",
+		},
+		"wrong token": {
+			recipes: recipes,
+			meals:   &mock.QueryMealRepository{},
+			path:    "/calendar.ics?user=user-2&token=" + signer.Token(entity.NewID("user-1")),
+			status:  http.StatusUnauthorized,
+			body:    "Unauthorized\n",
+		},
+		"wrong secret": {
+			recipes: recipes,
+			meals:   &mock.QueryMealRepository{},
+			path:    calendar.NewSigner([]byte("another")).Link(entity.NewID("user-1")),
+			status:  http.StatusUnauthorized,
+			body:    "Unauthorized\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			feed := calendar.New(
+				query.NewService(
+					test.recipes,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					test.meals,
+				),
+				signer,
+			)
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			recorder := httptest.NewRecorder()
+
+			feed.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.body, recorder.Body.String())
+
+			for _, line := range strings.Split(recorder.Body.String(), "\r\n") {
+				assert.LessOrEqual(t, len(line), 75)
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	signer := calendar.NewSigner([]byte("secret"))
+	token := signer.Token(entity.NewID("user-1"))
+
+	assert.True(t, signer.Verify(entity.NewID("user-1"), token))
+	assert.False(t, signer.Verify(entity.NewID("user-2"), token))
+	assert.False(t, signer.Verify(entity.NewID("user-1"), "not base64!"))
+	assert.Equal(t, "/calendar.ics?token="+token+"&user=user-1", signer.Link(entity.NewID("user-1")))
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest a content line may be before it has to be folded.
+const maxLineOctets = 75
+
+var textEscaper = strings.NewReplacer( //nolint: gochecknoglobals
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// encoder writes iCalendar content lines.
+type encoder struct {
+	writer io.Writer
+}
+
+func newEncoder(writer io.Writer) *encoder {
+	return &encoder{writer: writer}
+}
+
+func (e *encoder) begin(component string) {
+	e.property("BEGIN", component)
+}
+
+func (e *encoder) end(component string) {
+	e.property("END", component)
+}
+
+// text writes a property with a TEXT value, escaping any special characters.
+func (e *encoder) text(name string, value string) {
+	e.property(name, textEscaper.Replace(value))
+}
+
+// property writes a property line, folded so no line exceeds 75 octets.
+// Lines are never split within a multi-byte character.
+func (e *encoder) property(name string, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		_, _ = io.WriteString(e.writer, line[:cut]+"\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+
+	_, _ = io.WriteString(e.writer, line+"\r\n")
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+const randomSecretSize = 32
+
+// Path is where the meal plan Feed is served.
+const Path = "/calendar.ics"
+
+// Signer signs meal plan feed links, so calendar apps can subscribe without sending a bearer token.
+// Links stay valid until the secret changes.
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner creates a new feed link Signer from a server-side secret.
+// An empty secret is replaced by a random one, so links only last until restart.
+func NewSigner(secret []byte) *Signer {
+	if len(secret) == 0 {
+		secret = make([]byte, randomSecretSize)
+		_, _ = rand.Read(secret)
+	}
+
+	return &Signer{
+		secret: secret,
+	}
+}
+
+// Token returns the feed token of a user.
+func (s *Signer) Token(userID entity.ID) string {
+	return base64.RawURLEncoding.EncodeToString(s.sign(userID))
+}
+
+// Verify reports if a token belongs to a user.
+func (s *Signer) Verify(userID entity.ID, token string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, s.sign(userID))
+}
+
+// Link returns the signed feed path and query of a user.
+func (s *Signer) Link(userID entity.ID) string {
+	values := url.Values{}
+	values.Set("user", userID.String())
+	values.Set("token", s.Token(userID))
+
+	return Path + "?" + values.Encode()
+}
+
+func (s *Signer) sign(userID entity.ID) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = mac.Write([]byte("calendar:" + userID.String()))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,13 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCommand is raised when a command fails.
+var ErrCommand = errors.New("command error")
+
+func commandError(err error) error {
+	return fmt.Errorf("%w: %w", ErrCommand, err)
+}
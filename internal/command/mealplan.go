@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+)
+
+// CreateMeal plans a recipe for a meal on a given day.
+func (s *Service) CreateMeal(
+	ctx context.Context,
+	userID entity.ID,
+	date time.Time,
+	recipeID entity.ID,
+	options ...mealplan.Option,
+) (*mealplan.Meal, error) {
+	result, err := mealplan.New(s.newID(), date, recipeID, s.now(), userID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRecipe(ctx, result.RecipeID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.meals.CreateMeal(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// UpdateMeal applies changes to an existing planned meal.
+func (s *Service) UpdateMeal(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	options ...mealplan.Option,
+) (*mealplan.Meal, error) {
+	result, err := s.ownMeal(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := result.UpdatedAt()
+	recipeID := result.RecipeID()
+
+	if err := result.Update(s.now(), userID, options...); err != nil {
+		return nil, err
+	}
+
+	if result.UpdatedAt().Equal(updatedAt) {
+		return result, nil
+	}
+
+	if result.RecipeID() != recipeID {
+		if err := s.checkRecipe(ctx, result.RecipeID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.meals.UpdateMeal(ctx, result); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// DeleteMeal removes a planned meal.
+func (s *Service) DeleteMeal(ctx context.Context, userID entity.ID, id entity.ID) error {
+	if _, err := s.ownMeal(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.meals.DeleteMeal(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.ErrNotFound
+		}
+
+		return commandError(err)
+	}
+
+	return nil
+}
+
+// ownMeal loads a planned meal, refusing meals that belong to another user.
+func (s *Service) ownMeal(ctx context.Context, userID entity.ID, id entity.ID) (*mealplan.Meal, error) {
+	result, err := s.meals.GetMeal(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	if result.CreatedBy() != userID {
+		return nil, entity.ErrForbidden
+	}
+
+	return result, nil
+}
+
+// checkRecipe reports a validation error if a planned recipe does not exist.
+func (s *Service) checkRecipe(ctx context.Context, recipeID entity.ID) error {
+	if _, err := s.recipes.GetRecipe(ctx, recipeID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("recipe", "recipe does not exist")},
+			}
+		}
+
+		return commandError(err)
+	}
+
+	return nil
+}
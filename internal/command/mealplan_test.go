@@ -0,0 +1,273 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/stretchr/testify/assert"
+)
+
+var monday = time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+func newMeal(t *testing.T, options ...mealplan.Option) *mealplan.Meal {
+	t.Helper()
+
+	result, err := mealplan.New(
+		entity.NewID("meal-1"), monday, entity.NewID("recipe-1"), created, entity.NewID("user-1"), options...,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newAnotherMeal(t *testing.T) *mealplan.Meal {
+	t.Helper()
+
+	result, err := mealplan.New(entity.NewID("meal-1"), monday, entity.NewID("recipe-1"), created, entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newMealService(recipes recipe.Repository, repo mealplan.Repository, now time.Time) *command.Service {
+	return command.NewService(
+		recipes,
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		&mock.ShoppingListRepository{},
+		&mock.PantryRepository{},
+		repo,
+		command.WithClock(func() time.Time { return now }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("meal-1") }),
+	)
+}
+
+func TestCreateMeal(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes recipe.Repository
+		repo    mealplan.Repository
+		date    time.Time
+		options []mealplan.Option
+		result  *mealplan.Meal
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes: &mock.RecipeRepository{},
+			repo:    &mock.MealRepository{},
+			date:    monday,
+			options: []mealplan.Option{mealplan.SetServings(4)},
+			result:  newMeal(t, mealplan.SetServings(4)),
+			err:     nil,
+		},
+		"validation error": {
+			recipes: &mock.RecipeRepository{},
+			repo:    &mock.MealRepository{},
+			date:    time.Time{},
+			options: []mealplan.Option{mealplan.SetSlot("brunch")},
+			result:  nil,
+			err:     &entity.ValidationError{},
+		},
+		"missing recipe": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			repo:    &mock.MealRepository{},
+			date:    monday,
+			result:  nil,
+			err:     &entity.ValidationError{},
+		},
+		"recipe error": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: errors.New("some random error")},
+			repo:    &mock.MealRepository{},
+			date:    monday,
+			result:  nil,
+			err:     command.ErrCommand,
+		},
+		"repo error": {
+			recipes: &mock.RecipeRepository{},
+			repo:    &mock.MealRepository{CreateMealErr: errors.New("some random error")},
+			date:    monday,
+			result:  nil,
+			err:     command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newMealService(test.recipes, test.repo, created)
+			result, err := service.CreateMeal(
+				context.Background(), entity.NewID("user-1"), test.date, entity.NewID("recipe-1"), test.options...,
+			)
+
+			assert.Equal(t, test.result, result)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+			default:
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUpdateMeal(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes   recipe.Repository
+		repo      mealplan.Repository
+		options   []mealplan.Option
+		slot      mealplan.Slot
+		updatedAt time.Time
+		err       error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes:   &mock.RecipeRepository{GetRecipeErr: errors.New("should not be called")},
+			repo:      &mock.MealRepository{GetMealResult: newMeal(t)},
+			options:   []mealplan.Option{mealplan.SetSlot(mealplan.Lunch)},
+			slot:      mealplan.Lunch,
+			updatedAt: updated,
+			err:       nil,
+		},
+		"no changes": {
+			recipes: &mock.RecipeRepository{},
+			repo: &mock.MealRepository{
+				GetMealResult: newMeal(t),
+				UpdateMealErr: errors.New("should not be called"),
+			},
+			options:   []mealplan.Option{mealplan.SetSlot(mealplan.Dinner)},
+			slot:      mealplan.Dinner,
+			updatedAt: created,
+			err:       nil,
+		},
+		"missing recipe": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			repo:    &mock.MealRepository{GetMealResult: newMeal(t)},
+			options: []mealplan.Option{mealplan.SetRecipe(entity.NewID("recipe-2"))},
+			err:     &entity.ValidationError{},
+		},
+		"not found": {
+			recipes: &mock.RecipeRepository{},
+			repo:    &mock.MealRepository{GetMealErr: entity.ErrNotFound},
+			options: []mealplan.Option{mealplan.SetSlot(mealplan.Lunch)},
+			err:     entity.ErrNotFound,
+		},
+		"another user": {
+			recipes: &mock.RecipeRepository{},
+			repo:    &mock.MealRepository{GetMealResult: newAnotherMeal(t)},
+			options: []mealplan.Option{mealplan.SetSlot(mealplan.Lunch)},
+			err:     entity.ErrForbidden,
+		},
+		"update error": {
+			recipes: &mock.RecipeRepository{},
+			repo: &mock.MealRepository{
+				GetMealResult: newMeal(t),
+				UpdateMealErr: errors.New("some random error"),
+			},
+			options: []mealplan.Option{mealplan.SetSlot(mealplan.Lunch)},
+			err:     command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newMealService(test.recipes, test.repo, updated)
+			result, err := service.UpdateMeal(
+				context.Background(), entity.NewID("user-1"), entity.NewID("meal-1"), test.options...,
+			)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+				assert.Equal(t, test.slot, result.Slot())
+				assert.Equal(t, test.updatedAt, result.UpdatedAt())
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Nil(t, result)
+			default:
+				assert.ErrorIs(t, err, test.err)
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestDeleteMeal(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo mealplan.Repository
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.MealRepository{GetMealResult: newMeal(t)},
+			err:  nil,
+		},
+		"not found": {
+			repo: &mock.MealRepository{GetMealErr: entity.ErrNotFound},
+			err:  entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.MealRepository{
+				GetMealResult: newAnotherMeal(t),
+				DeleteMealErr: errors.New("should not be called"),
+			},
+			err: entity.ErrForbidden,
+		},
+		"get error": {
+			repo: &mock.MealRepository{GetMealErr: errors.New("some random error")},
+			err:  command.ErrCommand,
+		},
+		"deleted concurrently": {
+			repo: &mock.MealRepository{GetMealResult: newMeal(t), DeleteMealErr: entity.ErrNotFound},
+			err:  entity.ErrNotFound,
+		},
+		"repo error": {
+			repo: &mock.MealRepository{GetMealResult: newMeal(t), DeleteMealErr: errors.New("some random error")},
+			err:  command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newMealService(&mock.RecipeRepository{}, test.repo, updated).DeleteMeal(
+				context.Background(), entity.NewID("user-1"), entity.NewID("meal-1"),
+			)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
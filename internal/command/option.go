@@ -0,0 +1,38 @@
+package command
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Option is a command Service creation option.
+type Option func(s *Service)
+
+// WithClock overrides how the Service gets the current time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithIDGenerator overrides how the Service creates new ids.
+func WithIDGenerator(newID func() entity.ID) Option {
+	return func(s *Service) {
+		s.newID = newID
+	}
+}
+
+// WithRecipeIndex sets the full-text index kept in sync with recipe changes.
+func WithRecipeIndex(index RecipeIndex) Option {
+	return func(s *Service) {
+		s.index = index
+	}
+}
+
+// WithPublisher sets where changes to recipes and shopping lists are announced.
+func WithPublisher(publisher Publisher) Option {
+	return func(s *Service) {
+		s.events = publisher
+	}
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+)
+
+// CreatePantryItem creates and stores a new pantry item.
+func (s *Service) CreatePantryItem(
+	ctx context.Context,
+	userID entity.ID,
+	name string,
+	options ...pantry.Option,
+) (*pantry.Item, error) {
+	result, err := pantry.New(s.newID(), name, s.now(), userID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pantries.CreatePantryItem(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// UpdatePantryItem applies changes to an existing pantry item.
+func (s *Service) UpdatePantryItem(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	options ...pantry.Option,
+) (*pantry.Item, error) {
+	result, err := s.ownPantryItem(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := result.UpdatedAt()
+
+	if err := result.Update(s.now(), userID, options...); err != nil {
+		return nil, err
+	}
+
+	if result.UpdatedAt().Equal(updatedAt) {
+		return result, nil
+	}
+
+	if err := s.pantries.UpdatePantryItem(ctx, result); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// DeletePantryItem removes a pantry item.
+func (s *Service) DeletePantryItem(ctx context.Context, userID entity.ID, id entity.ID) error {
+	if _, err := s.ownPantryItem(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.pantries.DeletePantryItem(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.ErrNotFound
+		}
+
+		return commandError(err)
+	}
+
+	return nil
+}
+
+// ownPantryItem loads a pantry item, refusing items that belong to another user.
+func (s *Service) ownPantryItem(ctx context.Context, userID entity.ID, id entity.ID) (*pantry.Item, error) {
+	result, err := s.pantries.GetPantryItem(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	if result.CreatedBy() != userID {
+		return nil, entity.ErrForbidden
+	}
+
+	return result, nil
+}
@@ -0,0 +1,263 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPantryItem(t *testing.T, options ...pantry.Option) *pantry.Item {
+	t.Helper()
+
+	result, err := pantry.New(entity.NewID("item-1"), "flour", created, entity.NewID("user-1"), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newAnotherPantryItem(t *testing.T) *pantry.Item {
+	t.Helper()
+
+	result, err := pantry.New(entity.NewID("item-1"), "flour", created, entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newPantryService(repo pantry.Repository, now time.Time) *command.Service {
+	return command.NewService(
+		&mock.RecipeRepository{},
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		&mock.ShoppingListRepository{},
+		repo,
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return now }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("item-1") }),
+	)
+}
+
+func TestCreatePantryItem(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo    pantry.Repository
+		name    string
+		options []pantry.Option
+		result  *pantry.Item
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:    &mock.PantryRepository{},
+			name:    "flour",
+			options: []pantry.Option{pantry.SetQuantity(2)},
+			result:  newPantryItem(t, pantry.SetQuantity(2)),
+			err:     nil,
+		},
+		"validation error": {
+			repo:    &mock.PantryRepository{},
+			name:    "",
+			options: []pantry.Option{pantry.SetQuantity(-1)},
+			result:  nil,
+			err:     &entity.ValidationError{},
+		},
+		"repo error": {
+			repo:   &mock.PantryRepository{CreatePantryItemErr: errors.New("some random error")},
+			name:   "flour",
+			result: nil,
+			err:    command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newPantryService(test.repo, created)
+			result, err := service.CreatePantryItem(context.Background(), entity.NewID("user-1"), test.name, test.options...)
+
+			assert.Equal(t, test.result, result)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Equal(t, 2, len(validation.InnerErrors))
+			default:
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUpdatePantryItem(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo      pantry.Repository
+		options   []pantry.Option
+		quantity  float64
+		updatedAt time.Time
+		err       error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:      &mock.PantryRepository{GetPantryItemResult: newPantryItem(t)},
+			options:   []pantry.Option{pantry.SetQuantity(3)},
+			quantity:  3,
+			updatedAt: updated,
+			err:       nil,
+		},
+		"no changes": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newPantryItem(t, pantry.SetQuantity(3)),
+				UpdatePantryItemErr: errors.New("should not be called"),
+			},
+			options:   []pantry.Option{pantry.SetQuantity(3)},
+			quantity:  3,
+			updatedAt: created,
+			err:       nil,
+		},
+		"validation error": {
+			repo:    &mock.PantryRepository{GetPantryItemResult: newPantryItem(t)},
+			options: []pantry.Option{pantry.SetQuantity(-1)},
+			err:     &entity.ValidationError{},
+		},
+		"not found": {
+			repo:    &mock.PantryRepository{GetPantryItemErr: entity.ErrNotFound},
+			options: []pantry.Option{pantry.SetQuantity(3)},
+			err:     entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newAnotherPantryItem(t),
+			},
+			options: []pantry.Option{pantry.SetQuantity(3)},
+			err:     entity.ErrForbidden,
+		},
+		"get error": {
+			repo:    &mock.PantryRepository{GetPantryItemErr: errors.New("some random error")},
+			options: []pantry.Option{pantry.SetQuantity(3)},
+			err:     command.ErrCommand,
+		},
+		"deleted during update": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newPantryItem(t),
+				UpdatePantryItemErr: entity.ErrNotFound,
+			},
+			options: []pantry.Option{pantry.SetQuantity(3)},
+			err:     entity.ErrNotFound,
+		},
+		"update error": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newPantryItem(t),
+				UpdatePantryItemErr: errors.New("some random error"),
+			},
+			options: []pantry.Option{pantry.SetQuantity(3)},
+			err:     command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newPantryService(test.repo, updated)
+			result, err := service.UpdatePantryItem(
+				context.Background(), entity.NewID("user-1"), entity.NewID("item-1"), test.options...,
+			)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+				assert.Equal(t, test.quantity, *result.Quantity())
+				assert.Equal(t, test.updatedAt, result.UpdatedAt())
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Nil(t, result)
+			default:
+				assert.ErrorIs(t, err, test.err)
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestDeletePantryItem(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo pantry.Repository
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.PantryRepository{GetPantryItemResult: newPantryItem(t)},
+			err:  nil,
+		},
+		"not found": {
+			repo: &mock.PantryRepository{GetPantryItemErr: entity.ErrNotFound},
+			err:  entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newAnotherPantryItem(t),
+				DeletePantryItemErr: errors.New("should not be called"),
+			},
+			err: entity.ErrForbidden,
+		},
+		"get error": {
+			repo: &mock.PantryRepository{GetPantryItemErr: errors.New("some random error")},
+			err:  command.ErrCommand,
+		},
+		"deleted concurrently": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newPantryItem(t),
+				DeletePantryItemErr: entity.ErrNotFound,
+			},
+			err: entity.ErrNotFound,
+		},
+		"repo error": {
+			repo: &mock.PantryRepository{
+				GetPantryItemResult: newPantryItem(t),
+				DeletePantryItemErr: errors.New("some random error"),
+			},
+			err: command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newPantryService(test.repo, updated).DeletePantryItem(
+				context.Background(), entity.NewID("user-1"), entity.NewID("item-1"),
+			)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
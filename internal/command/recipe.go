@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+)
+
+// CreateRecipe creates and stores a new recipe.
+func (s *Service) CreateRecipe(
+	ctx context.Context,
+	userID entity.ID,
+	name string,
+	options ...recipe.Option,
+) (*recipe.Recipe, error) {
+	result, err := recipe.New(s.newID(), name, s.now(), userID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recipes.CreateRecipe(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	s.index.IndexRecipe(result)
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.CreatedAction, ID: result.ID()})
+
+	return result, nil
+}
+
+// UpdateRecipe applies changes to an existing recipe.
+func (s *Service) UpdateRecipe(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	options ...recipe.Option,
+) (*recipe.Recipe, error) {
+	result, err := s.recipes.GetRecipe(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	updatedAt := result.UpdatedAt()
+
+	if err := result.Update(s.now(), userID, options...); err != nil {
+		return nil, err
+	}
+
+	if result.UpdatedAt().Equal(updatedAt) {
+		return result, nil
+	}
+
+	if err := s.recipes.UpdateRecipe(ctx, result); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	s.index.IndexRecipe(result)
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.UpdatedAction, ID: result.ID()})
+
+	return result, nil
+}
+
+// DeleteRecipe removes a recipe.
+func (s *Service) DeleteRecipe(ctx context.Context, id entity.ID) error {
+	if err := s.recipes.DeleteRecipe(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.ErrNotFound
+		}
+
+		return commandError(err)
+	}
+
+	s.index.RemoveRecipe(id)
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.DeletedAction, ID: id})
+
+	return nil
+}
+
+func (s *Service) recipeExists(ctx context.Context, id entity.ID) error {
+	if _, err := s.recipes.GetRecipe(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.ErrNotFound
+		}
+
+		return commandError(err)
+	}
+
+	return nil
+}
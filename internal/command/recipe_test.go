@@ -0,0 +1,310 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	created = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+)
+
+func newRecipe(t *testing.T, options ...recipe.Option) *recipe.Recipe {
+	t.Helper()
+
+	result, err := recipe.New(entity.NewID("recipe-1"), "pancakes", created, entity.NewID("user-1"), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newService(repo recipe.Repository, now time.Time) *command.Service {
+	return command.NewService(
+		repo,
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		&mock.ShoppingListRepository{},
+		&mock.PantryRepository{},
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return now }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("recipe-1") }),
+	)
+}
+
+func TestCreateRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo    recipe.Repository
+		name    string
+		options []recipe.Option
+		result  *recipe.Recipe
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:    &mock.RecipeRepository{},
+			name:    "pancakes",
+			options: []recipe.Option{recipe.AddTag("breakfast")},
+			result:  newRecipe(t, recipe.AddTag("breakfast")),
+			err:     nil,
+		},
+		"validation error": {
+			repo:    &mock.RecipeRepository{},
+			name:    "",
+			options: []recipe.Option{recipe.AddTag("")},
+			result:  nil,
+			err:     &entity.ValidationError{},
+		},
+		"repo error": {
+			repo: &mock.RecipeRepository{
+				CreateRecipeErr: errors.New("some random error"),
+			},
+			name:   "pancakes",
+			result: nil,
+			err:    command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newService(test.repo, created)
+			result, err := service.CreateRecipe(context.Background(), entity.NewID("user-1"), test.name, test.options...)
+
+			assert.Equal(t, test.result, result)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Equal(t, 2, len(validation.InnerErrors))
+			default:
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUpdateRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo      recipe.Repository
+		options   []recipe.Option
+		name      string
+		updatedAt time.Time
+		err       error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:      &mock.RecipeRepository{GetRecipeResult: newRecipe(t)},
+			options:   []recipe.Option{recipe.SetName("crepes")},
+			name:      "crepes",
+			updatedAt: updated,
+			err:       nil,
+		},
+		"no changes": {
+			repo: &mock.RecipeRepository{
+				GetRecipeResult: newRecipe(t),
+				UpdateRecipeErr: errors.New("should not be called"),
+			},
+			options:   []recipe.Option{recipe.SetName("pancakes")},
+			name:      "pancakes",
+			updatedAt: created,
+			err:       nil,
+		},
+		"validation error": {
+			repo:    &mock.RecipeRepository{GetRecipeResult: newRecipe(t)},
+			options: []recipe.Option{recipe.SetName("")},
+			err:     &entity.ValidationError{},
+		},
+		"not found": {
+			repo:    &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			options: []recipe.Option{recipe.SetName("crepes")},
+			err:     entity.ErrNotFound,
+		},
+		"get error": {
+			repo:    &mock.RecipeRepository{GetRecipeErr: errors.New("some random error")},
+			options: []recipe.Option{recipe.SetName("crepes")},
+			err:     command.ErrCommand,
+		},
+		"deleted during update": {
+			repo: &mock.RecipeRepository{
+				GetRecipeResult: newRecipe(t),
+				UpdateRecipeErr: entity.ErrNotFound,
+			},
+			options: []recipe.Option{recipe.SetName("crepes")},
+			err:     entity.ErrNotFound,
+		},
+		"update error": {
+			repo: &mock.RecipeRepository{
+				GetRecipeResult: newRecipe(t),
+				UpdateRecipeErr: errors.New("some random error"),
+			},
+			options: []recipe.Option{recipe.SetName("crepes")},
+			err:     command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newService(test.repo, updated)
+			result, err := service.UpdateRecipe(
+				context.Background(), entity.NewID("user-2"), entity.NewID("recipe-1"), test.options...,
+			)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+				assert.Equal(t, test.name, result.Name())
+				assert.Equal(t, test.updatedAt, result.UpdatedAt())
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Nil(t, result)
+			default:
+				assert.ErrorIs(t, err, test.err)
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestDeleteRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo recipe.Repository
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.RecipeRepository{},
+			err:  nil,
+		},
+		"not found": {
+			repo: &mock.RecipeRepository{DeleteRecipeErr: entity.ErrNotFound},
+			err:  entity.ErrNotFound,
+		},
+		"repo error": {
+			repo: &mock.RecipeRepository{DeleteRecipeErr: errors.New("some random error")},
+			err:  command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newService(test.repo, updated).DeleteRecipe(context.Background(), entity.NewID("recipe-1"))
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestRecipeIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	index := search.NewIndex()
+	repo := &mock.RecipeRepository{}
+	service := command.NewService(
+		repo,
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		&mock.ShoppingListRepository{},
+		&mock.PantryRepository{},
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return updated }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("recipe-1") }),
+		command.WithRecipeIndex(index),
+	)
+
+	_, err := service.CreateRecipe(ctx, entity.NewID("user-1"), "pancakes")
+	assert.NoError(t, err)
+	assert.Len(t, index.SearchRecipes("pancake"), 1)
+
+	repo.GetRecipeResult = newRecipe(t)
+
+	_, err = service.UpdateRecipe(ctx, entity.NewID("user-1"), entity.NewID("recipe-1"), recipe.SetName("crepes"))
+	assert.NoError(t, err)
+	assert.Empty(t, index.SearchRecipes("pancake"))
+	assert.Len(t, index.SearchRecipes("crepe"), 1)
+
+	assert.NoError(t, service.DeleteRecipe(ctx, entity.NewID("recipe-1")))
+	assert.Empty(t, index.SearchRecipes("crepe"))
+
+	repo.CreateRecipeErr = errors.New("some random error")
+
+	_, err = service.CreateRecipe(ctx, entity.NewID("user-1"), "waffles")
+	assert.ErrorIs(t, err, command.ErrCommand)
+	assert.Empty(t, index.SearchRecipes("waffle"))
+}
+
+func TestRecipeEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	broker := pubsub.NewMemory()
+	events := broker.Subscribe(ctx, pubsub.RecipeTopic)
+	repo := &mock.RecipeRepository{}
+	service := command.NewService(
+		repo,
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		&mock.ShoppingListRepository{},
+		&mock.PantryRepository{},
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return updated }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("recipe-1") }),
+		command.WithPublisher(broker),
+	)
+	id := entity.NewID("recipe-1")
+
+	_, err := service.CreateRecipe(ctx, entity.NewID("user-1"), "pancakes")
+	assert.NoError(t, err)
+	assert.Equal(t, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.CreatedAction, ID: id}, <-events)
+
+	repo.GetRecipeResult = newRecipe(t)
+
+	_, err = service.UpdateRecipe(ctx, entity.NewID("user-1"), id, recipe.SetName("crepes"))
+	assert.NoError(t, err)
+	assert.Equal(t, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.UpdatedAction, ID: id}, <-events)
+
+	assert.NoError(t, service.DeleteRecipe(ctx, id))
+	assert.Equal(t, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.DeletedAction, ID: id}, <-events)
+
+	repo.CreateRecipeErr = errors.New("some random error")
+
+	_, err = service.CreateRecipe(ctx, entity.NewID("user-1"), "waffles")
+	assert.ErrorIs(t, err, command.ErrCommand)
+	assert.Empty(t, events)
+}
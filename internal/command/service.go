@@ -0,0 +1,83 @@
+// Package command implements all data changes.
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+// Service is the business logic for commands.
+type Service struct {
+	recipes  recipe.Repository
+	units    unit.Repository
+	users    user.Repository
+	lists    shoppinglist.Repository
+	pantries pantry.Repository
+	meals    mealplan.Repository
+	now      func() time.Time
+	newID    func() entity.ID
+	index    RecipeIndex
+	events   Publisher
+}
+
+// RecipeIndex defines a full-text index of recipes.
+type RecipeIndex interface {
+	IndexRecipe(item *recipe.Recipe)
+	RemoveRecipe(id entity.ID)
+}
+
+// noIndex is used when the Service has no RecipeIndex.
+type noIndex struct{}
+
+func (noIndex) IndexRecipe(*recipe.Recipe) {}
+
+func (noIndex) RemoveRecipe(entity.ID) {}
+
+// Publisher announces changes to entities.
+type Publisher interface {
+	Publish(ctx context.Context, event pubsub.Event)
+}
+
+// noPublisher is used when the Service has no Publisher.
+type noPublisher struct{}
+
+func (noPublisher) Publish(context.Context, pubsub.Event) {}
+
+// NewService creates a new command Service.
+func NewService(
+	recipes recipe.Repository,
+	units unit.Repository,
+	users user.Repository,
+	lists shoppinglist.Repository,
+	pantries pantry.Repository,
+	meals mealplan.Repository,
+	options ...Option,
+) *Service {
+	service := &Service{
+		recipes:  recipes,
+		units:    units,
+		users:    users,
+		lists:    lists,
+		pantries: pantries,
+		meals:    meals,
+		now:      time.Now,
+		newID:    entity.NewRandomID,
+		index:    noIndex{},
+		events:   noPublisher{},
+	}
+
+	for _, option := range options {
+		option(service)
+	}
+
+	return service
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+)
+
+// ShoppingItem is a new item for a shopping list.
+type ShoppingItem struct {
+	Name     string
+	Quantity float64
+	UnitID   entity.ID
+}
+
+// CreateShoppingList creates and stores a new shopping list.
+func (s *Service) CreateShoppingList(
+	ctx context.Context,
+	userID entity.ID,
+	name string,
+	items ...ShoppingItem,
+) (*shoppinglist.List, error) {
+	result, err := shoppinglist.New(s.newID(), name, s.now(), userID, s.itemOptions(items)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.lists.CreateShoppingList(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.CreatedAction, ID: result.ID()})
+
+	return result, nil
+}
+
+// UpdateShoppingList applies changes to an existing shopping list.
+func (s *Service) UpdateShoppingList(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	options ...shoppinglist.Option,
+) (*shoppinglist.List, error) {
+	result, err := s.ownShoppingList(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := result.UpdatedAt()
+
+	if err := result.Update(s.now(), userID, options...); err != nil {
+		return nil, err
+	}
+
+	if result.UpdatedAt().Equal(updatedAt) {
+		return result, nil
+	}
+
+	if err := s.lists.UpdateShoppingList(ctx, result); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.UpdatedAction, ID: result.ID()})
+
+	return result, nil
+}
+
+// AddShoppingItems adds new items to an existing shopping list.
+func (s *Service) AddShoppingItems(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	items ...ShoppingItem,
+) (*shoppinglist.List, error) {
+	return s.UpdateShoppingList(ctx, userID, id, s.itemOptions(items)...)
+}
+
+// DeleteShoppingList removes a shopping list.
+func (s *Service) DeleteShoppingList(ctx context.Context, userID entity.ID, id entity.ID) error {
+	if _, err := s.ownShoppingList(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.lists.DeleteShoppingList(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.ErrNotFound
+		}
+
+		return commandError(err)
+	}
+
+	s.events.Publish(ctx, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.DeletedAction, ID: id})
+
+	return nil
+}
+
+// ownShoppingList loads a shopping list, hiding lists that belong to another user.
+func (s *Service) ownShoppingList(ctx context.Context, userID entity.ID, id entity.ID) (*shoppinglist.List, error) {
+	result, err := s.lists.GetShoppingList(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, commandError(err)
+	}
+
+	if result.CreatedBy() != userID {
+		return nil, entity.ErrNotFound
+	}
+
+	return result, nil
+}
+
+func (s *Service) itemOptions(items []ShoppingItem) []shoppinglist.Option {
+	options := make([]shoppinglist.Option, len(items))
+	for i, item := range items {
+		options[i] = shoppinglist.AddItem(s.newID(), item.Name, item.Quantity, item.UnitID)
+	}
+
+	return options
+}
@@ -0,0 +1,327 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/stretchr/testify/assert"
+)
+
+func newList(t *testing.T, options ...shoppinglist.Option) *shoppinglist.List {
+	t.Helper()
+
+	result, err := shoppinglist.New(entity.NewID("list-1"), "groceries", created, entity.NewID("user-1"), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newAnotherList(t *testing.T) *shoppinglist.List {
+	t.Helper()
+
+	result, err := shoppinglist.New(entity.NewID("list-1"), "groceries", created, entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func newListService(repo shoppinglist.Repository, now time.Time) *command.Service {
+	return command.NewService(
+		&mock.RecipeRepository{},
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		repo,
+		&mock.PantryRepository{},
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return now }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("item-1") }),
+	)
+}
+
+func TestCreateShoppingList(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   shoppinglist.Repository
+		name   string
+		items  []command.ShoppingItem
+		result *shoppinglist.List
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:  &mock.ShoppingListRepository{},
+			name:  "groceries",
+			items: []command.ShoppingItem{{Name: "flour", Quantity: 2}},
+			result: func() *shoppinglist.List {
+				result, _ := shoppinglist.New(
+					entity.NewID("item-1"), "groceries", created, entity.NewID("user-1"),
+					shoppinglist.AddItem(entity.NewID("item-1"), "flour", 2, entity.NewID("")),
+				)
+
+				return result
+			}(),
+			err: nil,
+		},
+		"validation error": {
+			repo:   &mock.ShoppingListRepository{},
+			name:   "",
+			items:  []command.ShoppingItem{{Name: ""}},
+			result: nil,
+			err:    &entity.ValidationError{},
+		},
+		"repo error": {
+			repo:   &mock.ShoppingListRepository{CreateShoppingListErr: errors.New("some random error")},
+			name:   "groceries",
+			result: nil,
+			err:    command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newListService(test.repo, created)
+			result, err := service.CreateShoppingList(context.Background(), entity.NewID("user-1"), test.name, test.items...)
+
+			assert.Equal(t, test.result, result)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Equal(t, 2, len(validation.InnerErrors))
+			default:
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUpdateShoppingList(t *testing.T) {
+	t.Parallel()
+
+	item := entity.NewID("item-1")
+
+	type testCase struct {
+		repo      shoppinglist.Repository
+		options   []shoppinglist.Option
+		checked   bool
+		updatedAt time.Time
+		err       error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t, shoppinglist.AddItem(item, "flour", 2, entity.NewID(""))),
+			},
+			options:   []shoppinglist.Option{shoppinglist.SetChecked(item, true)},
+			checked:   true,
+			updatedAt: updated,
+			err:       nil,
+		},
+		"no changes": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t, shoppinglist.AddItem(item, "flour", 2, entity.NewID(""))),
+				UpdateShoppingListErr: errors.New("should not be called"),
+			},
+			options:   []shoppinglist.Option{shoppinglist.SetChecked(item, false)},
+			checked:   false,
+			updatedAt: created,
+			err:       nil,
+		},
+		"validation error": {
+			repo:    &mock.ShoppingListRepository{GetShoppingListResult: newList(t)},
+			options: []shoppinglist.Option{shoppinglist.SetChecked(item, true)},
+			err:     &entity.ValidationError{},
+		},
+		"not found": {
+			repo:    &mock.ShoppingListRepository{GetShoppingListErr: entity.ErrNotFound},
+			options: []shoppinglist.Option{shoppinglist.SetName("weekly")},
+			err:     entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newAnotherList(t),
+				UpdateShoppingListErr: errors.New("should not be called"),
+			},
+			options: []shoppinglist.Option{shoppinglist.SetName("weekly")},
+			err:     entity.ErrNotFound,
+		},
+		"get error": {
+			repo:    &mock.ShoppingListRepository{GetShoppingListErr: errors.New("some random error")},
+			options: []shoppinglist.Option{shoppinglist.SetName("weekly")},
+			err:     command.ErrCommand,
+		},
+		"deleted during update": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t),
+				UpdateShoppingListErr: entity.ErrNotFound,
+			},
+			options: []shoppinglist.Option{shoppinglist.SetName("weekly")},
+			err:     entity.ErrNotFound,
+		},
+		"update error": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t),
+				UpdateShoppingListErr: errors.New("some random error"),
+			},
+			options: []shoppinglist.Option{shoppinglist.SetName("weekly")},
+			err:     command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newListService(test.repo, updated)
+			result, err := service.UpdateShoppingList(
+				context.Background(), entity.NewID("user-1"), entity.NewID("list-1"), test.options...,
+			)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+				assert.Equal(t, test.checked, result.Items()[0].Checked())
+				assert.Equal(t, test.updatedAt, result.UpdatedAt())
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+				assert.Nil(t, result)
+			default:
+				assert.ErrorIs(t, err, test.err)
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestAddShoppingItems(t *testing.T) {
+	t.Parallel()
+
+	service := newListService(&mock.ShoppingListRepository{GetShoppingListResult: newList(t)}, updated)
+	result, err := service.AddShoppingItems(
+		context.Background(), entity.NewID("user-1"), entity.NewID("list-1"),
+		command.ShoppingItem{Name: "milk", Quantity: 1, UnitID: entity.NewID("gallon")},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Items()))
+	assert.Equal(t, entity.NewID("item-1"), result.Items()[0].ID())
+	assert.Equal(t, "milk", result.Items()[0].Name())
+	assert.Equal(t, entity.NewID("gallon"), result.Items()[0].UnitID())
+	assert.Equal(t, updated, result.UpdatedAt())
+}
+
+func TestDeleteShoppingList(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo shoppinglist.Repository
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.ShoppingListRepository{GetShoppingListResult: newList(t)},
+			err:  nil,
+		},
+		"not found": {
+			repo: &mock.ShoppingListRepository{GetShoppingListErr: entity.ErrNotFound},
+			err:  entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newAnotherList(t),
+				DeleteShoppingListErr: errors.New("should not be called"),
+			},
+			err: entity.ErrNotFound,
+		},
+		"get error": {
+			repo: &mock.ShoppingListRepository{GetShoppingListErr: errors.New("some random error")},
+			err:  command.ErrCommand,
+		},
+		"deleted concurrently": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t),
+				DeleteShoppingListErr: entity.ErrNotFound,
+			},
+			err: entity.ErrNotFound,
+		},
+		"repo error": {
+			repo: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList(t),
+				DeleteShoppingListErr: errors.New("some random error"),
+			},
+			err: command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newListService(test.repo, updated).DeleteShoppingList(
+				context.Background(), entity.NewID("user-1"), entity.NewID("list-1"),
+			)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestShoppingListEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	broker := pubsub.NewMemory()
+	events := broker.Subscribe(ctx, pubsub.ShoppingListTopic)
+	repo := &mock.ShoppingListRepository{}
+	service := command.NewService(
+		&mock.RecipeRepository{},
+		&mock.UnitRepository{},
+		&mock.UserRepository{},
+		repo,
+		&mock.PantryRepository{},
+		&mock.MealRepository{},
+		command.WithClock(func() time.Time { return updated }),
+		command.WithIDGenerator(func() entity.ID { return entity.NewID("list-1") }),
+		command.WithPublisher(broker),
+	)
+	id := entity.NewID("list-1")
+
+	_, err := service.CreateShoppingList(ctx, entity.NewID("user-1"), "groceries")
+	assert.NoError(t, err)
+	assert.Equal(t, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.CreatedAction, ID: id}, <-events)
+
+	repo.GetShoppingListResult = newList(t)
+
+	_, err = service.UpdateShoppingList(ctx, entity.NewID("user-1"), id, shoppinglist.SetName("party"))
+	assert.NoError(t, err)
+	assert.Equal(t, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.UpdatedAction, ID: id}, <-events)
+
+	assert.NoError(t, service.DeleteShoppingList(ctx, entity.NewID("user-1"), id))
+	assert.Equal(t, pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.DeletedAction, ID: id}, <-events)
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// CreateUnit creates and stores a new unit.
+func (s *Service) CreateUnit(
+	ctx context.Context,
+	name string,
+	symbol string,
+	options ...unit.Option,
+) (*unit.Unit, error) {
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	if name == "" {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("name", "unit name cannot be empty"))
+	}
+
+	if symbol == "" {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("symbol", "unit symbol cannot be empty"))
+	}
+
+	if !validation.IsEmpty() {
+		return nil, validation
+	}
+
+	result := unit.New(name, symbol, options...)
+
+	_, err := s.units.GetUnit(ctx, result.ID())
+	if err == nil {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("name", "unit already exists"))
+
+		return nil, validation
+	}
+
+	if !errors.Is(err, entity.ErrNotFound) {
+		return nil, commandError(err)
+	}
+
+	if err := s.units.CreateUnit(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// CreateConversion creates and stores a new conversion between two existing units.
+func (s *Service) CreateConversion(
+	ctx context.Context,
+	fromID entity.ID,
+	toID entity.ID,
+	ratio float64,
+) (*unit.Conversion, error) {
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	from, err := s.getUnit(ctx, "from", fromID, validation)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getUnit(ctx, "to", toID, validation)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validation.IsEmpty() {
+		return nil, validation
+	}
+
+	result, err := unit.NewConversion(from, to, ratio)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.units.CreateConversion(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// getUnit loads a unit, recording a field error if it does not exist.
+func (s *Service) getUnit(
+	ctx context.Context,
+	field string,
+	id entity.ID,
+	validation *entity.ValidationError,
+) (*unit.Unit, error) {
+	found, err := s.units.GetUnit(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError(field, "unit does not exist"))
+
+			return nil, nil //nolint: nilnil
+		}
+
+		return nil, commandError(err)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,194 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldErrors(t *testing.T, err error) []string {
+	t.Helper()
+
+	var validation *entity.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	result := make([]string, len(validation.InnerErrors))
+
+	for i, inner := range validation.InnerErrors {
+		var field *entity.FieldError
+		if errors.As(inner, &field) {
+			result[i] = field.Field
+		}
+	}
+
+	return result
+}
+
+func TestCreateUnit(t *testing.T) {
+	t.Parallel()
+
+	stick := unit.New("stick", "stk", unit.US, unit.Mass)
+
+	type testCase struct {
+		repo   unit.Repository
+		name   string
+		symbol string
+		result *unit.Unit
+		fields []string
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:   &mock.UnitRepository{},
+			name:   "stick",
+			symbol: "stk",
+			result: stick,
+		},
+		"missing fields": {
+			repo:   &mock.UnitRepository{},
+			fields: []string{"name", "symbol"},
+		},
+		"already exists": {
+			repo:   &mock.UnitRepository{GetUnitResult: map[entity.ID]*unit.Unit{stick.ID(): stick}},
+			name:   "stick",
+			symbol: "stk",
+			fields: []string{"name"},
+		},
+		"get error": {
+			repo:   &mock.UnitRepository{GetUnitErr: errors.New("some random error")},
+			name:   "stick",
+			symbol: "stk",
+			err:    command.ErrCommand,
+		},
+		"create error": {
+			repo:   &mock.UnitRepository{CreateUnitErr: errors.New("some random error")},
+			name:   "stick",
+			symbol: "stk",
+			err:    command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := command.NewService(
+				&mock.RecipeRepository{},
+				test.repo,
+				&mock.UserRepository{},
+				&mock.ShoppingListRepository{},
+				&mock.PantryRepository{},
+				&mock.MealRepository{},
+			)
+			result, err := service.CreateUnit(context.Background(), test.name, test.symbol, unit.US, unit.Mass)
+
+			assert.Equal(t, test.result, result)
+
+			switch {
+			case test.fields != nil:
+				assert.Equal(t, test.fields, fieldErrors(t, err))
+			case test.err != nil:
+				assert.ErrorIs(t, err, test.err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateConversion(t *testing.T) {
+	t.Parallel()
+
+	stick := unit.New("stick", "stk", unit.US, unit.Mass)
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+	liter := unit.New("liter", "l", unit.Metric, unit.Volume)
+	units := map[entity.ID]*unit.Unit{stick.ID(): stick, gram.ID(): gram, liter.ID(): liter}
+
+	type testCase struct {
+		repo   unit.Repository
+		from   entity.ID
+		to     entity.ID
+		ratio  float64
+		fields []string
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:  &mock.UnitRepository{GetUnitResult: units},
+			from:  stick.ID(),
+			to:    gram.ID(),
+			ratio: 113,
+		},
+		"unknown units": {
+			repo:   &mock.UnitRepository{GetUnitResult: units},
+			from:   entity.NewID("unknown"),
+			to:     entity.NewID("other"),
+			ratio:  113,
+			fields: []string{"from", "to"},
+		},
+		"invalid conversion": {
+			repo:   &mock.UnitRepository{GetUnitResult: units},
+			from:   stick.ID(),
+			to:     liter.ID(),
+			ratio:  -1,
+			fields: []string{"to", "ratio"},
+		},
+		"get error": {
+			repo:  &mock.UnitRepository{GetUnitErr: errors.New("some random error")},
+			from:  stick.ID(),
+			to:    gram.ID(),
+			ratio: 113,
+			err:   command.ErrCommand,
+		},
+		"create error": {
+			repo: &mock.UnitRepository{
+				GetUnitResult:       units,
+				CreateConversionErr: errors.New("some random error"),
+			},
+			from:  stick.ID(),
+			to:    gram.ID(),
+			ratio: 113,
+			err:   command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := command.NewService(
+				&mock.RecipeRepository{},
+				test.repo,
+				&mock.UserRepository{},
+				&mock.ShoppingListRepository{},
+				&mock.PantryRepository{},
+				&mock.MealRepository{},
+			)
+			result, err := service.CreateConversion(context.Background(), test.from, test.to, test.ratio)
+
+			switch {
+			case test.fields != nil:
+				assert.Nil(t, result)
+				assert.Equal(t, test.fields, fieldErrors(t, err))
+			case test.err != nil:
+				assert.Nil(t, result)
+				assert.ErrorIs(t, err, test.err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.from, result.From().ID())
+				assert.Equal(t, test.to, result.To().ID())
+				assert.Equal(t, test.ratio, result.Ratio())
+			}
+		})
+	}
+}
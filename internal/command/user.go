@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+// ProvisionUser makes sure an authenticated user exists with an up to date username.
+func (s *Service) ProvisionUser(ctx context.Context, id entity.ID, username string) (*user.User, error) {
+	result := user.New(id, username)
+
+	found, err := s.users.GetUser(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		err = s.users.CreateUser(ctx, result)
+		if err == nil {
+			return result, nil
+		}
+
+		// Another request may have created the same user in the meantime.
+		found, err = s.users.GetUser(ctx, id)
+	}
+
+	if err != nil {
+		return nil, commandError(err)
+	}
+
+	if found.Username() == username {
+		return found, nil
+	}
+
+	if err := s.users.UpdateUser(ctx, result); err != nil {
+		return nil, commandError(err)
+	}
+
+	return result, nil
+}
+
+// FavoriteRecipe marks an existing recipe as a favorite of a user.
+func (s *Service) FavoriteRecipe(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	if err := s.recipeExists(ctx, recipeID); err != nil {
+		return err
+	}
+
+	if err := s.users.AddFavorite(ctx, userID, recipeID); err != nil {
+		return commandError(err)
+	}
+
+	return nil
+}
+
+// UnfavoriteRecipe unmarks an existing recipe as a favorite of a user.
+func (s *Service) UnfavoriteRecipe(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	if err := s.recipeExists(ctx, recipeID); err != nil {
+		return err
+	}
+
+	if err := s.users.RemoveFavorite(ctx, userID, recipeID); err != nil {
+		return commandError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionUser(t *testing.T) {
+	t.Parallel()
+
+	id := entity.NewID("user-1")
+
+	type testCase struct {
+		repo   user.Repository
+		result *user.User
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"new user": {
+			repo:   &mock.UserRepository{},
+			result: user.New(id, "tester"),
+			err:    nil,
+		},
+		"existing user": {
+			repo: &mock.UserRepository{
+				GetUserResult: user.New(id, "tester"),
+				UpdateUserErr: errors.New("should not be called"),
+			},
+			result: user.New(id, "tester"),
+			err:    nil,
+		},
+		"renamed user": {
+			repo:   &mock.UserRepository{GetUserResult: user.New(id, "old-name")},
+			result: user.New(id, "tester"),
+			err:    nil,
+		},
+		"get error": {
+			repo:   &mock.UserRepository{GetUserErr: errors.New("some random error")},
+			result: nil,
+			err:    command.ErrCommand,
+		},
+		"create error": {
+			repo:   &mock.UserRepository{CreateUserErr: errors.New("some random error")},
+			result: nil,
+			err:    command.ErrCommand,
+		},
+		"update error": {
+			repo: &mock.UserRepository{
+				GetUserResult: user.New(id, "old-name"),
+				UpdateUserErr: errors.New("some random error"),
+			},
+			result: nil,
+			err:    command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := command.NewService(
+				&mock.RecipeRepository{},
+				&mock.UnitRepository{},
+				test.repo,
+				&mock.ShoppingListRepository{},
+				&mock.PantryRepository{},
+				&mock.MealRepository{},
+			)
+			result, err := service.ProvisionUser(context.Background(), id, "tester")
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestFavoriteRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes recipe.Repository
+		users   user.Repository
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes: &mock.RecipeRepository{GetRecipeResult: newRecipe(t)},
+			users:   &mock.UserRepository{},
+			err:     nil,
+		},
+		"not found": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			users:   &mock.UserRepository{},
+			err:     entity.ErrNotFound,
+		},
+		"get error": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: errors.New("some random error")},
+			users:   &mock.UserRepository{},
+			err:     command.ErrCommand,
+		},
+		"favorite error": {
+			recipes: &mock.RecipeRepository{GetRecipeResult: newRecipe(t)},
+			users: &mock.UserRepository{
+				AddFavoriteErr:    errors.New("some random error"),
+				RemoveFavoriteErr: errors.New("some random error"),
+			},
+			err: command.ErrCommand,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := command.NewService(
+				test.recipes,
+				&mock.UnitRepository{},
+				test.users,
+				&mock.ShoppingListRepository{},
+				&mock.PantryRepository{},
+				&mock.MealRepository{},
+			)
+
+			for _, fn := range []func(context.Context, entity.ID, entity.ID) error{
+				service.FavoriteRecipe,
+				service.UnfavoriteRecipe,
+			} {
+				err := fn(context.Background(), entity.NewID("user-1"), entity.NewID("recipe-1"))
+				if test.err == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, test.err)
+				}
+			}
+		})
+	}
+}
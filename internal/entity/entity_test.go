@@ -41,3 +41,11 @@ func TestValidationError(t *testing.T) {
 	}
 	assert.True(t, err.IsEmpty())
 }
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	err := entity.NewFieldError("name", "name cannot be empty")
+	assert.Equal(t, "name", err.Field)
+	assert.Equal(t, "name cannot be empty", err.Error())
+}
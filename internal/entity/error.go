@@ -8,6 +8,9 @@ import (
 // ErrNotFound is raised when an entity cannot be found.
 var ErrNotFound = errors.New("not found")
 
+// ErrForbidden is raised when a user is not allowed to act on an entity.
+var ErrForbidden = errors.New("forbidden")
+
 // ValidationError is holds validation errors.
 type ValidationError struct {
 	InnerErrors []error
@@ -27,3 +30,21 @@ func (e *ValidationError) Error() string {
 func (e *ValidationError) IsEmpty() bool {
 	return len(e.InnerErrors) == 0
 }
+
+// FieldError is a validation error on a single field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// NewFieldError creates a new FieldError.
+func NewFieldError(field string, message string) *FieldError {
+	return &FieldError{
+		Field:   field,
+		Message: message,
+	}
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
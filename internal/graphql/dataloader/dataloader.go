@@ -28,15 +28,91 @@ func dataloaderError(v any) error {
 
 // Dataloader batches and consolidates data calls.
 type Dataloader struct {
-	getUnit *dataloader.Loader
-	getUser *dataloader.Loader
+	getRecipe      *dataloader.Loader
+	getUnit        *dataloader.Loader
+	getConversions *dataloader.Loader
+	getUser        *dataloader.Loader
+	isFavorite     *dataloader.Loader
 }
 
 // New creates a new Dataloader.
 func New(queries *query.Service) *Dataloader {
 	return &Dataloader{
-		getUnit: dataloader.NewBatchedLoader(batchGetUnit(queries)),
-		getUser: dataloader.NewBatchedLoader(batchGetUser(queries)),
+		getRecipe:      dataloader.NewBatchedLoader(batchGetRecipe(queries)),
+		getUnit:        dataloader.NewBatchedLoader(batchGetUnit(queries)),
+		getConversions: dataloader.NewBatchedLoader(batchGetConversions(queries)),
+		getUser:        dataloader.NewBatchedLoader(batchGetUser(queries)),
+		isFavorite:     dataloader.NewBatchedLoader(batchIsFavorite(queries)),
+	}
+}
+
+// GetRecipe returns a RecipeResult from an ID.
+func GetRecipe(ctx context.Context, id entity.ID) (model.RecipeResult, error) { //nolint: ireturn
+	loader, err := FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := loader.getRecipe.Load(ctx, dataloader.StringKey(id.String()))()
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := data.(model.RecipeResult)
+
+	return result, nil
+}
+
+func batchGetRecipe(queries *query.Service) dataloader.BatchFunc { //nolint: dupl
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		start := time.Now()
+
+		defer func() {
+			zerolog.Ctx(ctx).Info().
+				Dur("duration_ms", time.Since(start)).
+				Int("batch", len(keys)).
+				Msg("recipe dataloader complete")
+		}()
+
+		keyOrder := make(map[entity.ID]int, len(keys))
+		ids := make([]entity.ID, len(keys))
+
+		for i, key := range keys {
+			ids[i] = entity.NewID(key.String())
+			keyOrder[ids[i]] = i
+		}
+
+		results := make([]*dataloader.Result, len(keys))
+
+		recipes, err := queries.GetRecipes(ctx, ids)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result{Error: err}
+			}
+
+			return results
+		}
+
+		for _, recipe := range recipes {
+			i, ok := keyOrder[recipe.ID]
+			if !ok {
+				continue
+			}
+
+			results[i] = &dataloader.Result{
+				Data: model.NewRecipe(recipe),
+			}
+
+			delete(keyOrder, recipe.ID)
+		}
+
+		for id, i := range keyOrder {
+			results[i] = &dataloader.Result{
+				Data: &model.NotFoundError{ID: model.NewRecipeID(id)},
+			}
+		}
+
+		return results
 	}
 }
 
@@ -114,6 +190,71 @@ func batchGetUnit(queries *query.Service) dataloader.BatchFunc { //nolint: dupl
 	}
 }
 
+// GetConversions returns all Conversions to or from a unit ID.
+func GetConversions(ctx context.Context, id entity.ID) ([]*model.Conversion, error) {
+	loader, err := FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := loader.getConversions.Load(ctx, dataloader.StringKey(id.String()))()
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := data.([]*model.Conversion)
+
+	return result, nil
+}
+
+func batchGetConversions(queries *query.Service) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		start := time.Now()
+
+		defer func() {
+			zerolog.Ctx(ctx).Info().
+				Dur("duration_ms", time.Since(start)).
+				Int("batch", len(keys)).
+				Msg("conversion dataloader complete")
+		}()
+
+		ids := make([]entity.ID, len(keys))
+		lookup := make(map[entity.ID][]*model.Conversion, len(keys))
+
+		for i, key := range keys {
+			ids[i] = entity.NewID(key.String())
+			lookup[ids[i]] = make([]*model.Conversion, 0)
+		}
+
+		results := make([]*dataloader.Result, len(keys))
+
+		conversions, err := queries.GetConversions(ctx, ids)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result{Error: err}
+			}
+
+			return results
+		}
+
+		for _, conversion := range conversions {
+			for _, id := range []entity.ID{conversion.FromID, conversion.ToID} {
+				if _, ok := lookup[id]; !ok {
+					continue
+				}
+
+				lookup[id] = append(lookup[id], model.NewConversion(conversion))
+			}
+		}
+
+		for i, id := range ids {
+			results[i] = &dataloader.Result{Data: lookup[id]}
+		}
+
+		return results
+	}
+}
+
 // GetUser returns a UserResult from an ID.
 func GetUser(ctx context.Context, id entity.ID) (model.UserResult, error) { //nolint: ireturn
 	loader, err := FromContext(ctx)
@@ -188,6 +329,81 @@ func batchGetUser(queries *query.Service) dataloader.BatchFunc { //nolint: dupl
 	}
 }
 
+// favoriteKey identifies a recipe favorited by a user.
+type favoriteKey struct {
+	userID   entity.ID
+	recipeID entity.ID
+}
+
+func (k favoriteKey) String() string {
+	return k.userID.String() + "/" + k.recipeID.String()
+}
+
+func (k favoriteKey) Raw() any {
+	return k
+}
+
+// IsFavorite returns whether a recipe ID is a favorite of a user ID.
+func IsFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) (bool, error) {
+	loader, err := FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := loader.isFavorite.Load(ctx, favoriteKey{userID: userID, recipeID: recipeID})()
+	if err != nil {
+		return false, err
+	}
+
+	result, _ := data.(bool)
+
+	return result, nil
+}
+
+func batchIsFavorite(queries *query.Service) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		start := time.Now()
+
+		defer func() {
+			zerolog.Ctx(ctx).Info().
+				Dur("duration_ms", time.Since(start)).
+				Int("batch", len(keys)).
+				Msg("favorite dataloader complete")
+		}()
+
+		results := make([]*dataloader.Result, len(keys))
+		recipeIDs := make(map[entity.ID][]entity.ID)
+
+		for _, key := range keys {
+			favorite, _ := key.Raw().(favoriteKey)
+			recipeIDs[favorite.userID] = append(recipeIDs[favorite.userID], favorite.recipeID)
+		}
+
+		favorites := make(map[string]bool, len(keys))
+
+		for userID, ids := range recipeIDs {
+			found, err := queries.GetFavorites(ctx, userID, ids)
+			if err != nil {
+				for i := range keys {
+					results[i] = &dataloader.Result{Error: err}
+				}
+
+				return results
+			}
+
+			for _, id := range found {
+				favorites[favoriteKey{userID: userID, recipeID: id}.String()] = true
+			}
+		}
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result{Data: favorites[key.String()]}
+		}
+
+		return results
+	}
+}
+
 // FromContext returns a Dataloader if it exists in the given Context.
 func FromContext(ctx context.Context) (*Dataloader, error) {
 	loader, ok := ctx.Value(loaderKey).(*Dataloader)
@@ -210,3 +426,17 @@ func Middleware(queries *query.Service, next http.Handler) http.Handler {
 		next.ServeHTTP(writer, request)
 	})
 }
+
+// ClearCache forgets everything loaded by the Dataloader in the given Context, if there is one.
+func ClearCache(ctx context.Context) {
+	loader, err := FromContext(ctx)
+	if err != nil {
+		return
+	}
+
+	loader.getRecipe.ClearAll()
+	loader.getUnit.ClearAll()
+	loader.getConversions.ClearAll()
+	loader.getUser.ClearAll()
+	loader.isFavorite.ClearAll()
+}
@@ -34,6 +34,9 @@ func TestGetUnit(t *testing.T) {
 							GetUnitsResult: []*query.Unit{{ID: entity.NewID("1234")}},
 						},
 						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -57,6 +60,9 @@ func TestGetUnit(t *testing.T) {
 							GetUnitsErr: errors.New("something went wrong"),
 						},
 						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -74,6 +80,9 @@ func TestGetUnit(t *testing.T) {
 							GetUnitsResult: []*query.Unit{{ID: entity.NewID("9999")}},
 						},
 						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -97,6 +106,103 @@ func TestGetUnit(t *testing.T) {
 	}
 }
 
+func TestGetConversions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		ctx    context.Context
+		id     entity.ID
+		result []*model.Conversion
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			ctx: dataloader.ToContext(
+				context.Background(),
+				dataloader.New(
+					query.NewService(
+						&mock.QueryRecipeRepository{},
+						&mock.QueryUnitRepository{
+							GetConversionsResult: []*query.Conversion{
+								{FromID: entity.NewID("1234"), ToID: entity.NewID("5678"), Ratio: 2},
+								{FromID: entity.NewID("9999"), ToID: entity.NewID("1234"), Ratio: 3},
+							},
+						},
+						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
+					),
+				),
+			),
+			id: entity.NewID("1234"),
+			result: []*model.Conversion{
+				{FromID: entity.NewID("1234"), ToID: entity.NewID("5678"), Ratio: 2},
+				{FromID: entity.NewID("9999"), ToID: entity.NewID("1234"), Ratio: 3},
+			},
+			err: nil,
+		},
+		"empty context": {
+			ctx:    context.Background(),
+			id:     entity.NewID("1234"),
+			result: nil,
+			err:    dataloader.ErrDataloader,
+		},
+		"repo error": {
+			ctx: dataloader.ToContext(
+				context.Background(),
+				dataloader.New(
+					query.NewService(
+						&mock.QueryRecipeRepository{},
+						&mock.QueryUnitRepository{
+							GetConversionsErr: errors.New("something went wrong"),
+						},
+						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
+					),
+				),
+			),
+			id:     entity.NewID("1234"),
+			result: nil,
+			err:    errors.New("something went wrong"),
+		},
+		"no conversions": {
+			ctx: dataloader.ToContext(
+				context.Background(),
+				dataloader.New(
+					query.NewService(
+						&mock.QueryRecipeRepository{},
+						&mock.QueryUnitRepository{},
+						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
+					),
+				),
+			),
+			id:     entity.NewID("1234"),
+			result: []*model.Conversion{},
+			err:    nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := dataloader.GetConversions(test.ctx, test.id)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
 func TestGetUser(t *testing.T) {
 	t.Parallel()
 
@@ -118,6 +224,9 @@ func TestGetUser(t *testing.T) {
 						&mock.QueryUserRepository{
 							GetUsersResult: []*query.User{{ID: entity.NewID("1234")}},
 						},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -141,6 +250,9 @@ func TestGetUser(t *testing.T) {
 						&mock.QueryUserRepository{
 							GetUsersErr: errors.New("something went wrong"),
 						},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -158,6 +270,9 @@ func TestGetUser(t *testing.T) {
 						&mock.QueryUserRepository{
 							GetUsersResult: []*query.User{{ID: entity.NewID("9999")}},
 						},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
 					),
 				),
 			),
@@ -180,3 +295,109 @@ func TestGetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFavorite(t *testing.T) {
+	t.Parallel()
+
+	newContext := func(users *mock.QueryUserRepository) context.Context {
+		return dataloader.ToContext(
+			context.Background(),
+			dataloader.New(query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				users,
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)),
+		)
+	}
+
+	type testCase struct {
+		ctx    context.Context
+		id     entity.ID
+		result bool
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"favorite": {
+			ctx: newContext(&mock.QueryUserRepository{
+				GetFavoritesResult: []entity.ID{entity.NewID("1234")},
+			}),
+			id:     entity.NewID("1234"),
+			result: true,
+			err:    nil,
+		},
+		"not favorite": {
+			ctx: newContext(&mock.QueryUserRepository{
+				GetFavoritesResult: []entity.ID{entity.NewID("9999")},
+			}),
+			id:     entity.NewID("1234"),
+			result: false,
+			err:    nil,
+		},
+		"empty context": {
+			ctx:    context.Background(),
+			id:     entity.NewID("1234"),
+			result: false,
+			err:    dataloader.ErrDataloader,
+		},
+		"repo error": {
+			ctx: newContext(&mock.QueryUserRepository{
+				GetFavoritesErr: errors.New("something went wrong"),
+			}),
+			id:     entity.NewID("1234"),
+			result: false,
+			err:    errors.New("something went wrong"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := dataloader.IsFavorite(test.ctx, entity.NewID("user-123"), test.id)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestClearCache(t *testing.T) {
+	t.Parallel()
+
+	units := &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{{ID: entity.NewID("1234"), Name: "cup"}}}
+	ctx := dataloader.ToContext(
+		context.Background(),
+		dataloader.New(
+			query.NewService(
+				&mock.QueryRecipeRepository{},
+				units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			),
+		),
+	)
+
+	_, err := dataloader.GetUnit(ctx, entity.NewID("1234"))
+	assert.NoError(t, err)
+
+	units.GetUnitsResult = []*query.Unit{{ID: entity.NewID("1234"), Name: "mug"}}
+
+	result, err := dataloader.GetUnit(ctx, entity.NewID("1234"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cup", result.(*model.Unit).Name)
+
+	dataloader.ClearCache(ctx)
+	dataloader.ClearCache(context.Background())
+
+	result, err = dataloader.GetUnit(ctx, entity.NewID("1234"))
+	assert.NoError(t, err)
+	assert.Equal(t, "mug", result.(*model.Unit).Name)
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorCode is a machine readable error category, returned in the code extension of every GraphQL error.
+type ErrorCode string
+
+// NotFoundCode, et al. are the error codes clients can switch on.
+const (
+	NotFoundCode        = ErrorCode("NOT_FOUND")
+	ValidationCode      = ErrorCode("VALIDATION")
+	UnauthenticatedCode = ErrorCode("UNAUTHENTICATED")
+	ForbiddenCode       = ErrorCode("FORBIDDEN")
+	InternalCode        = ErrorCode("INTERNAL")
+	InvalidIDCode       = ErrorCode("INVALID_ID")
+	InvalidCursorCode   = ErrorCode("INVALID_CURSOR")
+	InvalidDateCode     = ErrorCode("INVALID_DATE")
+	LimitExceededCode   = ErrorCode("LIMIT_EXCEEDED")
+
+	codeExtension = "code"
+)
+
+// errorPresenter adds an error code to every error that does not already have one.
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	if _, ok := presented.Extensions[codeExtension]; ok {
+		return presented
+	}
+
+	if presented.Extensions == nil {
+		presented.Extensions = make(map[string]any)
+	}
+
+	presented.Extensions[codeExtension] = errorCode(err)
+
+	return presented
+}
+
+// errorCode finds the ErrorCode of an error, falling back to InternalCode.
+func errorCode(err error) ErrorCode {
+	var validation *entity.ValidationError
+
+	switch {
+	case errors.Is(err, model.ErrInvalidID):
+		return InvalidIDCode
+	case errors.Is(err, model.ErrInvalidCursor), errors.Is(err, query.ErrInvalidCursor):
+		return InvalidCursorCode
+	case errors.Is(err, model.ErrInvalidDate):
+		return InvalidDateCode
+	case errors.Is(err, entity.ErrNotFound):
+		return NotFoundCode
+	case errors.As(err, &validation):
+		return ValidationCode
+	case errors.Is(err, auth.ErrUnauthorized):
+		return UnauthenticatedCode
+	case errors.Is(err, entity.ErrForbidden):
+		return ForbiddenCode
+	default:
+		return InternalCode
+	}
+}
@@ -2,34 +2,131 @@
 package graphql
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
 	"github.com/b-sea/supply-run-api/internal/graphql/resolver"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
+const (
+	fetchTimeout      = 10 * time.Second
+	keepAliveInterval = 10 * time.Second
+	queryCacheSize    = 1000
+	persistedSize     = 100
+)
+
+// Authenticator logs in the user behind a raw bearer token.
+type Authenticator interface {
+	Login(ctx context.Context, token string) (context.Context, error)
+}
+
 // GraphQL is an GraphQL API handler.
 type GraphQL struct {
 	http.Handler
+
+	fetcher       importer.Fetcher
+	broker        pubsub.Broker
+	feeds         *calendar.Signer
+	cursors       *model.CursorSigner
+	authenticator Authenticator
+	limits        *limits
 }
 
 // New creates a new GraphQL API handler.
-func New(queries *query.Service, recorder Recorder) *GraphQL {
-	graphql := &GraphQL{}
+// Subscriptions are served over WebSockets, using the graphql-transport-ws protocol.
+// WebSocket connections authenticate with the Authorization value of their connection_init payload.
+// Operations that exceed the depth, complexity or page size limits are rejected before they run.
+func New(queries *query.Service, commands *command.Service, recorder Recorder, options ...Option) *GraphQL {
+	graphql := &GraphQL{
+		fetcher:       importer.NewHTTPFetcher(importer.NewPublicClient(fetchTimeout)),
+		broker:        pubsub.NewMemory(),
+		feeds:         calendar.NewSigner(nil),
+		cursors:       model.NewCursorSigner(nil),
+		authenticator: nil,
+		limits: &limits{
+			maxDepth:      DefaultMaxDepth,
+			maxComplexity: DefaultMaxComplexity,
+			maxPageSize:   DefaultMaxPageSize,
+			recorder:      recorder,
+			schema:        nil,
+		},
+	}
+
+	for _, option := range options {
+		option(graphql)
+	}
 
 	schema := resolver.NewExecutableSchema(
 		resolver.Config{
-			Resolvers: resolver.NewResolver(queries),
+			Resolvers: resolver.NewResolver(
+				queries, commands, importer.New(graphql.fetcher), graphql.broker, graphql.feeds,
+			),
+			Directives: resolver.DirectiveRoot{},
+			Complexity: fieldCosts(),
 		},
 	)
 
-	server := handler.NewDefaultServer(schema)
+	server := handler.New(schema)
+	server.AddTransport(transport.Websocket{KeepAlivePingInterval: keepAliveInterval, InitFunc: graphql.initSocket})
+	server.AddTransport(transport.Options{})
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.AddTransport(transport.MultipartForm{})
+	server.SetQueryCache(lru.New[*ast.QueryDocument](queryCacheSize))
+	server.Use(extension.Introspection{})
+	server.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](persistedSize)})
+	server.Use(graphql.limits)
 	server.AroundFields(fieldTelemetry(recorder))
 	server.SetRecoverFunc(recoverTelemetry(recorder))
+	server.SetErrorPresenter(errorPresenter)
 
-	graphql.Handler = dataloader.Middleware(queries, server)
+	graphql.Handler = dataloader.Middleware(queries, graphql.signCursors(server))
 
 	return graphql
 }
+
+// signCursors makes the cursors of a request signed with the handler's secret.
+func (g *GraphQL) signCursors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		request = request.WithContext(model.CursorSignerToContext(request.Context(), g.cursors))
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// initSocket logs in the user of a WebSocket connection from its connection_init payload.
+// Connections are anonymous when no Authenticator is set.
+func (g *GraphQL) initSocket(
+	ctx context.Context,
+	payload transport.InitPayload,
+) (context.Context, *transport.InitPayload, error) {
+	if g.authenticator == nil {
+		return auth.ToContext(ctx, entity.NewID("")), nil, nil
+	}
+
+	token, ok := auth.BearerToken(payload.Authorization())
+	if !ok {
+		return ctx, nil, auth.ErrUnauthorized
+	}
+
+	ctx, err := g.authenticator.Login(ctx, token)
+	if err != nil {
+		return ctx, nil, err //nolint: wrapcheck
+	}
+
+	return ctx, nil, nil
+}
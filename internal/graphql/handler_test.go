@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type noopRecorder struct{}
+
+func (noopRecorder) ObserveResolverDuration(string, string, string, time.Duration) {}
+
+func (noopRecorder) ObserveGraphqlError() {}
+
+func (noopRecorder) ObserveQueryRejected(string) {}
+
+type tokenAuthenticator map[string]entity.ID
+
+func (a tokenAuthenticator) Login(ctx context.Context, token string) (context.Context, error) {
+	id, ok := a[token]
+	if !ok {
+		return ctx, auth.ErrUnauthorized
+	}
+
+	return auth.ToContext(ctx, id), nil
+}
+
+func newTestServer(recorder Recorder, options ...Option) *GraphQL {
+	return New(
+		query.NewService(
+			&mock.QueryRecipeRepository{},
+			&mock.QueryUnitRepository{},
+			&mock.QueryUserRepository{},
+			&mock.QueryShoppingListRepository{},
+			&mock.QueryPantryRepository{},
+			&mock.QueryMealRepository{},
+		),
+		command.NewService(
+			&mock.RecipeRepository{},
+			&mock.UnitRepository{},
+			&mock.UserRepository{},
+			&mock.ShoppingListRepository{},
+			&mock.PantryRepository{},
+			&mock.MealRepository{},
+		),
+		recorder,
+		options...,
+	)
+}
+
+func TestWebsocketAuthentication(t *testing.T) {
+	t.Parallel()
+
+	feeds := calendar.NewSigner([]byte("secret"))
+	authenticator := tokenAuthenticator{"valid": entity.NewID("user-1")}
+
+	type testCase struct {
+		options  []Option
+		payload  map[string]any
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"valid token": {
+			options:  []Option{WithAuthenticator(authenticator)},
+			payload:  map[string]any{"Authorization": "Bearer valid"},
+			response: map[string]any{"mealPlanFeed": feeds.Link(entity.NewID("user-1"))},
+			err:      nil,
+		},
+		"lowercase key": {
+			options:  []Option{WithAuthenticator(authenticator)},
+			payload:  map[string]any{"authorization": "Bearer valid"},
+			response: map[string]any{"mealPlanFeed": feeds.Link(entity.NewID("user-1"))},
+			err:      nil,
+		},
+		"invalid token": {
+			options:  []Option{WithAuthenticator(authenticator)},
+			payload:  map[string]any{"Authorization": "Bearer forged"},
+			response: nil,
+			err:      errors.New("connection_error"),
+		},
+		"missing token": {
+			options:  []Option{WithAuthenticator(authenticator)},
+			payload:  nil,
+			response: nil,
+			err:      errors.New("connection_error"),
+		},
+		"anonymous": {
+			options:  nil,
+			payload:  nil,
+			response: map[string]any{"mealPlanFeed": feeds.Link(entity.NewID(""))},
+			err:      nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testClient := client.New(newTestServer(noopRecorder{}, append(test.options, WithFeedSigner(feeds))...))
+
+			subscription := testClient.WebsocketWithPayload(`query { mealPlanFeed }`, test.payload)
+			defer func() { _ = subscription.Close() }()
+
+			var response map[string]any
+
+			err := subscription.Next(&response)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err.Error())
+			}
+		})
+	}
+}
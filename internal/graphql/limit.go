@@ -0,0 +1,218 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/graphql/resolver"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// DefaultMaxDepth, et al. are the limits used when none are set.
+const (
+	DefaultMaxDepth      = 10
+	DefaultMaxComplexity = 2500
+	DefaultMaxPageSize   = 100
+)
+
+// Fields that do more work than reading a value cost more than the default of one.
+const (
+	countCost = 10
+	scanCost  = 100
+)
+
+// DepthRejection, et al. are the reasons an operation can be rejected for.
+const (
+	DepthRejection      = "depth"
+	ComplexityRejection = "complexity"
+	PageSizeRejection   = "page_size"
+)
+
+// fieldCosts sets the complexity of fields whose cost depends on their arguments or the work behind them.
+// Connections and cookable recipes cost their children once for every item asked for,
+// parsed ingredients once for every line and meal plans once for every slot of every day.
+func fieldCosts() resolver.ComplexityRoot {
+	var costs resolver.ComplexityRoot
+
+	costs.Query.FindRecipes = func(
+		childComplexity int,
+		_ *string,
+		_ *model.RecipeFilter,
+		page *model.Page,
+		_ *model.Order,
+	) int {
+		return pageCost(childComplexity, page)
+	}
+	costs.Query.WhatCanICook = func(childComplexity int, _ []string, _ *int, first *int) int {
+		limit, _ := model.NewResultLimit(first)
+
+		return scanCost + childComplexity*max(limit, 1)
+	}
+	costs.Query.ParseIngredients = func(childComplexity int, lines []string) int {
+		return 1 + childComplexity*max(len(lines), 1)
+	}
+	costs.Query.MealPlan = func(childComplexity int, _ time.Time, days int) int {
+		return 1 + childComplexity*max(days, 1)*len(model.AllMealSlot)
+	}
+	costs.Query.MealPlanIngredients = func(childComplexity int, _ time.Time, days int) int {
+		return 1 + childComplexity*max(days, 1)*len(model.AllMealSlot)
+	}
+	costs.RecipeConnection.TotalCount = func(childComplexity int) int {
+		return countCost + childComplexity
+	}
+	costs.User.Favorites = func(childComplexity int, page *model.Page, _ *model.Order) int {
+		return pageCost(childComplexity, page)
+	}
+
+	return costs
+}
+
+// pageCost is the cost of a connection, whose children are resolved once for every item on the page.
+// Negative page sizes are rejected before any cost is calculated, so their error is ignored here.
+func pageCost(childComplexity int, page *model.Page) int {
+	pagination, _ := model.NewQueryPagination(page)
+
+	return 1 + childComplexity*max(pagination.Size, 1)
+}
+
+// limits rejects operations that are nested too deeply, cost too much or ask for too large a page.
+// A limit of zero is not enforced.
+type limits struct {
+	maxDepth      int
+	maxComplexity int
+	maxPageSize   int
+	recorder      Recorder
+	schema        graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = (*limits)(nil)
+
+// ExtensionName returns the name of the extension.
+func (l *limits) ExtensionName() string {
+	return "Limits"
+}
+
+// Validate keeps the schema operations are measured against.
+func (l *limits) Validate(schema graphql.ExecutableSchema) error {
+	l.schema = schema
+
+	return nil
+}
+
+// MutateOperationContext rejects an operation that exceeds any of the limits or asks for a negative page size.
+func (l *limits) MutateOperationContext(ctx context.Context, operation *graphql.OperationContext) *gqlerror.Error {
+	definition := operation.Doc.Operations.ForName(operation.OperationName)
+	if definition == nil {
+		return nil
+	}
+
+	depth, pageSize, smallestPageSize := 0, 0, 0
+
+	walkFields(definition.SelectionSet, 1, func(field *ast.Field, fieldDepth int) {
+		first, last := requestedPageSize(field, operation.Variables)
+
+		depth = max(depth, fieldDepth)
+		pageSize = max(pageSize, first, last)
+		smallestPageSize = min(smallestPageSize, first, last)
+	})
+
+	if smallestPageSize < 0 {
+		err := gqlerror.Errorf("page size %d must not be negative", smallestPageSize)
+		err.Extensions = map[string]any{codeExtension: ValidationCode}
+
+		return err
+	}
+
+	if l.maxDepth > 0 && depth > l.maxDepth {
+		return l.reject(DepthRejection, "operation has depth %d, which exceeds the limit of %d", depth, l.maxDepth)
+	}
+
+	if l.maxPageSize > 0 && pageSize > l.maxPageSize {
+		return l.reject(PageSizeRejection, "page size %d exceeds the limit of %d", pageSize, l.maxPageSize)
+	}
+
+	if l.maxComplexity > 0 {
+		cost := complexity.Calculate(ctx, l.schema, definition, operation.Variables)
+		if cost > l.maxComplexity {
+			return l.reject(
+				ComplexityRejection,
+				"operation has complexity %d, which exceeds the limit of %d",
+				cost,
+				l.maxComplexity,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (l *limits) reject(reason string, format string, args ...any) *gqlerror.Error {
+	l.recorder.ObserveQueryRejected(reason)
+
+	err := gqlerror.Errorf(format, args...)
+	err.Extensions = map[string]any{codeExtension: LimitExceededCode, "reason": reason}
+
+	return err
+}
+
+// walkFields visits every field in a selection set, along with how deeply it is nested.
+// Introspection fields are skipped, as their depth is fixed by the GraphQL spec.
+func walkFields(selections ast.SelectionSet, depth int, visit func(field *ast.Field, depth int)) {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+
+			visit(selection, depth)
+			walkFields(selection.SelectionSet, depth+1, visit)
+		case *ast.InlineFragment:
+			walkFields(selection.SelectionSet, depth, visit)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				walkFields(selection.Definition.SelectionSet, depth, visit)
+			}
+		}
+	}
+}
+
+// requestedPageSize returns the first and last asked for by a field's page argument,
+// or the first asked for by a list field that takes one directly.
+// Either is zero when it is not given.
+func requestedPageSize(field *ast.Field, variables map[string]any) (int, int) {
+	arguments := field.ArgumentMap(variables)
+
+	page, ok := arguments["page"].(map[string]any)
+	if !ok {
+		return toInt(arguments["first"]), 0
+	}
+
+	return toInt(page["first"]), toInt(page["last"])
+}
+
+func toInt(value any) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case json.Number:
+		result, _ := value.Int64()
+
+		return int(result)
+	default:
+		return 0
+	}
+}
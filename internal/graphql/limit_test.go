@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type rejectionRecorder struct {
+	noopRecorder
+
+	rejected []string
+}
+
+func (r *rejectionRecorder) ObserveQueryRejected(reason string) {
+	r.rejected = append(r.rejected, reason)
+}
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		options  []client.Option
+		query    string
+		rejected []string
+		err      string
+	}
+
+	tests := map[string]testCase{
+		"within limits": {
+			options:  nil,
+			query:    `query { findRecipes(page: {first: 5}) { edges { node { name }}}}`,
+			rejected: nil,
+			err:      "",
+		},
+		"too deep": {
+			options:  nil,
+			query:    `query { findRecipes { edges { node { ingredients { unit { ...on Unit { name }}}}}}}`,
+			rejected: []string{DepthRejection},
+			err:      `"code":"LIMIT_EXCEEDED"`,
+		},
+		"fragments count towards depth": {
+			options:  nil,
+			query:    `query { findRecipes { ...edges }} fragment edges on RecipeConnection { edges { node { ingredients { unit { ...on Unit { name }}}}}}`,
+			rejected: []string{DepthRejection},
+			err:      "operation has depth 6",
+		},
+		"page too large": {
+			options:  []client.Option{client.Var("size", 500)},
+			query:    `query test($size: Int){ findRecipes(page: {last: $size}) { totalCount }}`,
+			rejected: []string{PageSizeRejection},
+			err:      "page size 500 exceeds the limit of 100",
+		},
+		"negative first": {
+			options:  nil,
+			query:    `query { findRecipes(page: {first: -10}) { edges { node { name }}}}`,
+			rejected: nil,
+			err:      `"code":"VALIDATION"`,
+		},
+		"negative last": {
+			options:  []client.Option{client.Var("size", -1)},
+			query:    `query test($size: Int){ findRecipes(page: {last: $size}) { totalCount }}`,
+			rejected: nil,
+			err:      "page size -1 must not be negative",
+		},
+		"too complex": {
+			options:  nil,
+			query:    `query { findRecipes(page: {first: 50}) { edges { node { name url steps tags }}}}`,
+			rejected: []string{ComplexityRejection},
+			err:      "operation has complexity 301",
+		},
+		"favorites cost every item on the page": {
+			options: []client.Option{client.Var("id", model.NewUserID(entity.NewID("1")).String())},
+			query: `query test($id: ID!){ node(id: $id) { ...on User { favorites(page: {first: 50}) { ` +
+				`edges { node { name url steps tags }}}}}}`,
+			rejected: []string{ComplexityRejection},
+			err:      "operation has complexity 302",
+		},
+		"meal plans cost every slot of every day": {
+			options:  nil,
+			query:    `query { mealPlan(from: "2025-03-03", days: 40) { date slot }}`,
+			rejected: []string{ComplexityRejection},
+			err:      "operation has complexity 321",
+		},
+		"cookable recipes cost every one asked for": {
+			options:  nil,
+			query:    `query { whatCanICook(ingredients: ["egg"], first: 50) { recipe { name url steps tags }}}`,
+			rejected: []string{ComplexityRejection},
+			err:      "operation has complexity 350",
+		},
+		"too many cookable recipes": {
+			options:  nil,
+			query:    `query { whatCanICook(ingredients: ["egg"], first: 500) { coverage }}`,
+			rejected: []string{PageSizeRejection},
+			err:      "page size 500 exceeds the limit of 100",
+		},
+		"negative cookable recipes": {
+			options:  nil,
+			query:    `query { whatCanICook(ingredients: ["egg"], first: -1) { coverage }}`,
+			rejected: nil,
+			err:      `"code":"VALIDATION"`,
+		},
+		"parsed ingredients cost every line": {
+			options:  []client.Option{client.Var("lines", slices.Repeat([]string{"1 egg"}, 150))},
+			query:    `query test($lines: [String!]!){ parseIngredients(lines: $lines) { name note }}`,
+			rejected: []string{ComplexityRejection},
+			err:      "operation has complexity 301",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &rejectionRecorder{noopRecorder: noopRecorder{}, rejected: nil}
+			server := newTestServer(recorder, WithMaxDepth(5), WithMaxComplexity(300), WithMaxPageSize(100))
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.rejected, recorder.rejected)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
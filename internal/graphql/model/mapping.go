@@ -2,8 +2,18 @@
 package model
 
 import (
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/command"
 	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/pantry"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/b-sea/supply-run-api/internal/unit"
 )
 
 const defaultPageSize = 50
@@ -18,24 +28,14 @@ func NewRecipeID(id entity.ID) ID {
 
 // NewRecipe creates a new graphql Recipe.
 func NewRecipe(recipe *query.Recipe) *Recipe {
-	ingredients := make([]*Ingredient, len(recipe.Ingredients))
-	for i := range recipe.Ingredients {
-		ingredients[i] = &Ingredient{
-			Name:     recipe.Ingredients[i].Name,
-			Quantity: recipe.Ingredients[i].Quantity,
-			UnitID:   recipe.Ingredients[i].UnitID,
-		}
-	}
-
 	return &Recipe{
 		ID:          NewRecipeID(recipe.ID),
 		Name:        recipe.Name,
 		URL:         recipe.URL,
 		NumServings: recipe.NumServings,
 		Steps:       recipe.Steps,
-		Ingredients: ingredients,
+		Ingredients: NewIngredients(recipe.Ingredients),
 		Tags:        recipe.Tags,
-		IsFavorite:  recipe.IsFavorite,
 		CreatedAt:   recipe.CreatedAt,
 		CreatedByID: recipe.CreatedBy,
 		UpdatedAt:   recipe.UpdatedAt,
@@ -43,9 +43,40 @@ func NewRecipe(recipe *query.Recipe) *Recipe {
 	}
 }
 
+// NewIngredients creates new graphql Ingredients.
+func NewIngredients(ingredients []query.Ingredient) []*Ingredient {
+	result := make([]*Ingredient, len(ingredients))
+	for i := range ingredients {
+		result[i] = &Ingredient{
+			Name:     ingredients[i].Name,
+			Quantity: ingredients[i].Quantity,
+			UnitID:   ingredients[i].UnitID,
+		}
+	}
+
+	return result
+}
+
+// NewQueryIngredients creates new query Ingredients.
+func NewQueryIngredients(ingredients []*Ingredient) []query.Ingredient {
+	result := make([]query.Ingredient, len(ingredients))
+	for i := range ingredients {
+		result[i] = query.Ingredient{
+			Name:     ingredients[i].Name,
+			Quantity: ingredients[i].Quantity,
+			UnitID:   ingredients[i].UnitID,
+		}
+	}
+
+	return result
+}
+
 // NewQueryRecipeFilter creates a new query RecipeFilter.
-func NewQueryRecipeFilter(filter *RecipeFilter) query.RecipeFilter {
-	result := query.RecipeFilter{}
+// The isFavorite filter applies to the favorites of the given viewer.
+func NewQueryRecipeFilter(filter *RecipeFilter, viewer entity.ID) query.RecipeFilter {
+	result := query.RecipeFilter{
+		FavoriteOf: viewer,
+	}
 
 	if filter == nil {
 		return result
@@ -77,35 +108,22 @@ func NewRecipeConnection(page *query.RecipePage) *RecipeConnection {
 	result.PageInfo.HasNextPage = page.Info.HasNextPage
 	result.PageInfo.HasPreviousPage = page.Info.HasPreviousPage
 
-	var sort Sort
-
 	if page.Info.StartCursor != nil {
-		sort = newSort(page.Info.StartCursor.Sort)
-
-		result.PageInfo.StartCursor = &Cursor{
-			ID:   page.Info.StartCursor.ID,
-			Sort: sort,
-		}
+		cursor := newCursor(*page.Info.StartCursor)
+		result.PageInfo.StartCursor = &cursor
 	}
 
 	if page.Info.EndCursor != nil {
-		sort = newSort(page.Info.EndCursor.Sort)
-
-		result.PageInfo.EndCursor = &Cursor{
-			ID:   page.Info.EndCursor.ID,
-			Sort: sort,
-		}
+		cursor := newCursor(*page.Info.EndCursor)
+		result.PageInfo.EndCursor = &cursor
 	}
 
-	for _, recipe := range page.Items {
+	for i, recipe := range page.Items {
 		result.Edges = append(
 			result.Edges,
 			&RecipeEdge{
-				Cursor: Cursor{
-					ID:   recipe.ID,
-					Sort: sort,
-				},
-				Node: NewRecipe(recipe),
+				Cursor: newCursor(page.Cursors[i]),
+				Node:   NewRecipe(recipe),
 			},
 		)
 	}
@@ -113,6 +131,217 @@ func NewRecipeConnection(page *query.RecipePage) *RecipeConnection {
 	return result
 }
 
+func newCursor(cursor query.Cursor) Cursor {
+	return Cursor{
+		ID:    cursor.ID,
+		Sort:  newSort(cursor.Sort),
+		Time:  cursor.Time,
+		Name:  cursor.Name,
+		Score: cursor.Score,
+	}
+}
+
+func newQueryCursor(cursor *Cursor) *query.Cursor {
+	if cursor == nil {
+		return nil
+	}
+
+	return &query.Cursor{
+		ID:    cursor.ID,
+		Sort:  newQuerySort(cursor.Sort),
+		Time:  cursor.Time,
+		Name:  cursor.Name,
+		Score: cursor.Score,
+	}
+}
+
+// NewCreateRecipeOptions creates recipe options from a graphql CreateRecipeInput.
+// Ingredient lines are not parsed here, see NewIngredientInputs.
+func NewCreateRecipeOptions(input CreateRecipeInput) []recipe.Option {
+	return newRecipeOptions(
+		UpdateRecipeInput{
+			Name:            nil,
+			URL:             input.URL,
+			NumServings:     input.NumServings,
+			Steps:           input.Steps,
+			Ingredients:     input.Ingredients,
+			IngredientLines: input.IngredientLines,
+			Tags:            input.Tags,
+		},
+	)
+}
+
+// NewUpdateRecipeOptions creates recipe options from a graphql UpdateRecipeInput.
+// Lists that are provided replace the existing values.
+// Ingredient lines are not parsed here, see NewIngredientInputs.
+func NewUpdateRecipeOptions(input UpdateRecipeInput) []recipe.Option {
+	return newRecipeOptions(input)
+}
+
+func newRecipeOptions(input UpdateRecipeInput) []recipe.Option {
+	options := make([]recipe.Option, 0)
+
+	if input.Name != nil {
+		options = append(options, recipe.SetName(*input.Name))
+	}
+
+	if input.URL != nil {
+		options = append(options, recipe.SetURL(*input.URL))
+	}
+
+	if input.NumServings != nil {
+		options = append(options, recipe.SetNumServings(*input.NumServings))
+	}
+
+	if input.Steps != nil {
+		options = append(options, recipe.ClearSteps())
+
+		for _, step := range input.Steps {
+			options = append(options, recipe.AddStep(step))
+		}
+	}
+
+	if input.Ingredients != nil {
+		options = append(options, recipe.ClearIngredients())
+
+		for _, ingredient := range input.Ingredients {
+			options = append(options, newIngredientOption(ingredient))
+		}
+	}
+
+	if input.Tags != nil {
+		options = append(options, recipe.ClearTags())
+
+		for _, tag := range input.Tags {
+			options = append(options, recipe.AddTag(tag))
+		}
+	}
+
+	return options
+}
+
+func newIngredientOption(input *IngredientInput) recipe.Option {
+	if input.Unit == nil {
+		return recipe.AddIngredient(input.Name, input.Quantity, entity.NewID(""))
+	}
+
+	if input.Unit.Kind != UnitKind {
+		return func(*recipe.Recipe) (bool, error) {
+			return false, entity.NewFieldError("ingredients", "ingredient unit must be a unit id")
+		}
+	}
+
+	return recipe.AddIngredient(input.Name, input.Quantity, input.Unit.Key)
+}
+
+// NewCookableRecipes creates new graphql CookableRecipes.
+func NewCookableRecipes(recipes []*query.CookableRecipe) []*CookableRecipe {
+	result := make([]*CookableRecipe, len(recipes))
+
+	for i, recipe := range recipes {
+		result[i] = &CookableRecipe{
+			Recipe:   NewRecipe(recipe.Recipe),
+			Coverage: recipe.Coverage,
+			Missing:  recipe.Missing,
+		}
+	}
+
+	return result
+}
+
+// NewParsedIngredients creates new graphql ParsedIngredients.
+// Lines without a quantity have none.
+func NewParsedIngredients(lines []ingredient.Line) []*ParsedIngredient {
+	result := make([]*ParsedIngredient, len(lines))
+
+	for i, line := range lines {
+		result[i] = &ParsedIngredient{
+			Text:        line.Text,
+			Name:        line.Name,
+			Quantity:    nil,
+			MaxQuantity: nil,
+			Note:        line.Note,
+			UnitID:      line.UnitID,
+		}
+
+		if line.Quantity > 0 {
+			result[i].Quantity = &lines[i].Quantity
+			result[i].MaxQuantity = &lines[i].MaxQuantity
+		}
+	}
+
+	return result
+}
+
+// NewIngredientInputs creates graphql IngredientInputs from parsed ingredient lines.
+// Notes are dropped, since recipe ingredients have nowhere to keep them.
+func NewIngredientInputs(lines []ingredient.Line) []*IngredientInput {
+	result := make([]*IngredientInput, len(lines))
+
+	for i, line := range lines {
+		result[i] = &IngredientInput{
+			Name:     line.Name,
+			Quantity: line.Amount(),
+			Unit:     nil,
+		}
+
+		if line.UnitID.String() != "" {
+			unitID := NewUnitID(line.UnitID)
+			result[i].Unit = &unitID
+		}
+	}
+
+	return result
+}
+
+// NewImportSource returns the url and uploaded document of a graphql ImportRecipeInput.
+// The document is nil when none was uploaded.
+func NewImportSource(input ImportRecipeInput) (string, []byte) {
+	source := ""
+	if input.URL != nil {
+		source = *input.URL
+	}
+
+	if input.HTML == nil {
+		return source, nil
+	}
+
+	return source, []byte(*input.HTML)
+}
+
+// NewRecipePreview creates a new graphql RecipePreview from an imported recipe and its parsed ingredients.
+func NewRecipePreview(imported *importer.Recipe, lines []ingredient.Line) *RecipePreview {
+	return &RecipePreview{
+		Name:        imported.Name,
+		URL:         imported.URL,
+		NumServings: imported.NumServings,
+		Steps:       imported.Steps,
+		Ingredients: NewParsedIngredients(lines),
+		Tags:        imported.Tags,
+	}
+}
+
+// NewValidationError creates a new graphql ValidationError.
+func NewValidationError(err *entity.ValidationError) *ValidationError {
+	result := &ValidationError{
+		Errors: make([]*FieldError, len(err.InnerErrors)),
+	}
+
+	for i, inner := range err.InnerErrors {
+		result.Errors[i] = &FieldError{
+			Field:   nil,
+			Message: inner.Error(),
+		}
+
+		var field *entity.FieldError
+		if errors.As(inner, &field) {
+			result.Errors[i].Field = &field.Field
+		}
+	}
+
+	return result
+}
+
 // NewUnitID creates a new graphql Unit ID.
 func NewUnitID(id entity.ID) ID {
 	return ID{
@@ -132,6 +361,44 @@ func NewUnit(unit *query.Unit) *Unit {
 	}
 }
 
+// NewConversion creates a new graphql Conversion.
+func NewConversion(conversion *query.Conversion) *Conversion {
+	return &Conversion{
+		Ratio:  conversion.Ratio,
+		FromID: conversion.FromID,
+		ToID:   conversion.ToID,
+	}
+}
+
+// NewUnitOptions creates unit options from a graphql CreateUnitInput.
+func NewUnitOptions(input CreateUnitInput) []unit.Option {
+	options := make([]unit.Option, 0)
+
+	if input.BaseType != nil {
+		options = append(options, unit.SetBaseType(*input.BaseType))
+	}
+
+	if input.System != nil {
+		options = append(options, unit.SetSystem(*input.System))
+	}
+
+	if input.Plural != nil {
+		options = append(options, unit.WithCustomPlural(*input.Plural))
+	}
+
+	return options
+}
+
+// NewUnitKey returns the unit key of a graphql ID.
+// IDs of any other kind return an empty key.
+func NewUnitKey(id ID) entity.ID {
+	if id.Kind != UnitKind {
+		return entity.NewID("")
+	}
+
+	return id.Key
+}
+
 // NewUserID creates a new graphql User ID.
 func NewUserID(id entity.ID) ID {
 	return ID{
@@ -148,29 +415,379 @@ func NewUser(user *query.User) *User {
 	}
 }
 
+// NewShoppingListID creates a new graphql ShoppingList ID.
+func NewShoppingListID(id entity.ID) ID {
+	return ID{
+		Key:  id,
+		Kind: ShoppingListKind,
+	}
+}
+
+// NewShoppingItemID creates a new graphql ShoppingItem ID.
+func NewShoppingItemID(id entity.ID) ID {
+	return ID{
+		Key:  id,
+		Kind: ShoppingItemKind,
+	}
+}
+
+// NewShoppingList creates a new graphql ShoppingList.
+func NewShoppingList(list *query.ShoppingList) *ShoppingList {
+	result := &ShoppingList{
+		ID:          NewShoppingListID(list.ID),
+		Name:        list.Name,
+		Items:       make([]*ShoppingItem, len(list.Items)),
+		CreatedAt:   list.CreatedAt,
+		CreatedByID: list.CreatedBy,
+		UpdatedAt:   list.UpdatedAt,
+		UpdatedByID: list.UpdatedBy,
+	}
+
+	for i, item := range list.Items {
+		result.Items[i] = &ShoppingItem{
+			ID:       NewShoppingItemID(item.ID),
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Checked:  item.Checked,
+			UnitID:   item.UnitID,
+		}
+	}
+
+	return result
+}
+
+// NewQueryRecipeServings creates new query RecipeServings.
+// IDs of any kind other than recipe will not be found.
+func NewQueryRecipeServings(inputs []*RecipeServingsInput) []query.RecipeServings {
+	result := make([]query.RecipeServings, len(inputs))
+	for i, input := range inputs {
+		result[i] = query.RecipeServings{
+			RecipeID: entity.NewID(""),
+			Servings: 0,
+		}
+
+		if input.Recipe.Kind == RecipeKind {
+			result[i].RecipeID = input.Recipe.Key
+		}
+
+		if input.Servings != nil {
+			result[i].Servings = *input.Servings
+		}
+	}
+
+	return result
+}
+
+// NewPlannedShoppingItems creates new shopping list items from planned ingredients.
+func NewPlannedShoppingItems(ingredients []query.Ingredient) []command.ShoppingItem {
+	result := make([]command.ShoppingItem, len(ingredients))
+	for i := range ingredients {
+		result[i] = command.ShoppingItem{
+			Name:     ingredients[i].Name,
+			Quantity: ingredients[i].Quantity,
+			UnitID:   ingredients[i].UnitID,
+		}
+	}
+
+	return result
+}
+
+// NewShoppingItems creates new shopping list items from graphql ShoppingItemInputs.
+// Units of any kind other than unit are ignored.
+func NewShoppingItems(inputs []*ShoppingItemInput) []command.ShoppingItem {
+	result := make([]command.ShoppingItem, len(inputs))
+	for i, input := range inputs {
+		result[i] = command.ShoppingItem{
+			Name:     input.Name,
+			Quantity: 0,
+			UnitID:   entity.NewID(""),
+		}
+
+		if input.Quantity != nil {
+			result[i].Quantity = *input.Quantity
+		}
+
+		if input.Unit != nil {
+			result[i].UnitID = NewUnitKey(*input.Unit)
+		}
+	}
+
+	return result
+}
+
+// NewShoppingListOptions creates shopping list options from a graphql UpdateShoppingListInput.
+func NewShoppingListOptions(input UpdateShoppingListInput) []shoppinglist.Option {
+	options := make([]shoppinglist.Option, 0)
+
+	if input.Name != nil {
+		options = append(options, shoppinglist.SetName(*input.Name))
+	}
+
+	return options
+}
+
+// NewShoppingItemKey returns the shopping item key of a graphql ID.
+// IDs of any other kind return an empty key.
+func NewShoppingItemKey(id ID) entity.ID {
+	if id.Kind != ShoppingItemKind {
+		return entity.NewID("")
+	}
+
+	return id.Key
+}
+
+// NewPantryItemID creates a new graphql PantryItem ID.
+func NewPantryItemID(id entity.ID) ID {
+	return ID{
+		Key:  id,
+		Kind: PantryItemKind,
+	}
+}
+
+// NewPantryItem creates a new graphql PantryItem.
+func NewPantryItem(item *query.PantryItem) *PantryItem {
+	return &PantryItem{
+		ID:          NewPantryItemID(item.ID),
+		Name:        item.Name,
+		Quantity:    item.Quantity,
+		ExpiresAt:   item.ExpiresAt,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+		UnitID:      item.UnitID,
+		CreatedByID: item.CreatedBy,
+		UpdatedByID: item.UpdatedBy,
+	}
+}
+
+// NewPantryItems creates new graphql PantryItems.
+func NewPantryItems(items []*query.PantryItem) []*PantryItem {
+	result := make([]*PantryItem, len(items))
+	for i := range items {
+		result[i] = NewPantryItem(items[i])
+	}
+
+	return result
+}
+
+// NewQueryPantryFilter creates a new query PantryFilter.
+func NewQueryPantryFilter(filter *PantryFilter) query.PantryFilter {
+	result := query.PantryFilter{
+		Name:          nil,
+		ExpiresBefore: nil,
+	}
+
+	if filter == nil {
+		return result
+	}
+
+	result.Name = filter.Name
+
+	return result
+}
+
+// NewCreatePantryItemOptions creates pantry item options from a graphql CreatePantryItemInput.
+// Units of any kind other than unit are ignored.
+func NewCreatePantryItemOptions(input CreatePantryItemInput) []pantry.Option {
+	options := make([]pantry.Option, 0)
+
+	if input.Quantity != nil {
+		options = append(options, pantry.SetQuantity(*input.Quantity))
+	}
+
+	if input.Unit != nil {
+		options = append(options, pantry.SetUnit(NewUnitKey(*input.Unit)))
+	}
+
+	if input.ExpiresAt != nil {
+		options = append(options, pantry.SetExpiry(*input.ExpiresAt))
+	}
+
+	return options
+}
+
+// NewUpdatePantryItemOptions creates pantry item options from a graphql UpdatePantryItemInput.
+// Explicitly setting the quantity, unit or expiry to null clears it.
+func NewUpdatePantryItemOptions(input UpdatePantryItemInput) []pantry.Option {
+	options := make([]pantry.Option, 0)
+
+	if input.Name != nil {
+		options = append(options, pantry.SetName(*input.Name))
+	}
+
+	if value, ok := input.Quantity.ValueOK(); ok {
+		if value == nil {
+			options = append(options, pantry.ClearQuantity())
+		} else {
+			options = append(options, pantry.SetQuantity(*value))
+		}
+	}
+
+	if value, ok := input.Unit.ValueOK(); ok {
+		unitID := entity.NewID("")
+		if value != nil {
+			unitID = NewUnitKey(*value)
+		}
+
+		options = append(options, pantry.SetUnit(unitID))
+	}
+
+	if value, ok := input.ExpiresAt.ValueOK(); ok {
+		if value == nil {
+			options = append(options, pantry.ClearExpiry())
+		} else {
+			options = append(options, pantry.SetExpiry(*value))
+		}
+	}
+
+	return options
+}
+
+// NewRecipeKey returns the recipe key of a graphql ID.
+// IDs of any other kind return an empty key.
+func NewRecipeKey(id ID) entity.ID {
+	if id.Kind != RecipeKind {
+		return entity.NewID("")
+	}
+
+	return id.Key
+}
+
+// NewMealID creates a new graphql Meal ID.
+func NewMealID(id entity.ID) ID {
+	return ID{
+		Key:  id,
+		Kind: MealKind,
+	}
+}
+
+// NewMeal creates a new graphql Meal.
+func NewMeal(meal *query.Meal) *Meal {
+	result := &Meal{
+		ID:          NewMealID(meal.ID),
+		Date:        meal.Date,
+		Slot:        newMealSlot(mealplan.Slot(meal.Slot)),
+		Servings:    nil,
+		CreatedAt:   meal.CreatedAt,
+		UpdatedAt:   meal.UpdatedAt,
+		RecipeID:    meal.RecipeID,
+		CreatedByID: meal.CreatedBy,
+		UpdatedByID: meal.UpdatedBy,
+	}
+
+	if meal.Servings > 0 {
+		result.Servings = &meal.Servings
+	}
+
+	return result
+}
+
+// NewMeals creates new graphql Meals.
+func NewMeals(meals []*query.Meal) []*Meal {
+	result := make([]*Meal, len(meals))
+	for i := range meals {
+		result[i] = NewMeal(meals[i])
+	}
+
+	return result
+}
+
+// NewCreateMealOptions creates meal options from a graphql CreateMealInput.
+func NewCreateMealOptions(input CreateMealInput) []mealplan.Option {
+	options := make([]mealplan.Option, 0)
+
+	if input.Slot != nil {
+		options = append(options, mealplan.SetSlot(newMealplanSlot(*input.Slot)))
+	}
+
+	if input.Servings != nil {
+		options = append(options, mealplan.SetServings(*input.Servings))
+	}
+
+	return options
+}
+
+// NewUpdateMealOptions creates meal options from a graphql UpdateMealInput.
+// Explicitly setting the servings to null falls back to the recipe's own servings.
+func NewUpdateMealOptions(input UpdateMealInput) []mealplan.Option {
+	options := make([]mealplan.Option, 0)
+
+	if input.Date != nil {
+		options = append(options, mealplan.SetDate(*input.Date))
+	}
+
+	if input.Slot != nil {
+		options = append(options, mealplan.SetSlot(newMealplanSlot(*input.Slot)))
+	}
+
+	if input.Recipe != nil {
+		options = append(options, mealplan.SetRecipe(NewRecipeKey(*input.Recipe)))
+	}
+
+	if value, ok := input.Servings.ValueOK(); ok {
+		servings := 0
+		if value != nil {
+			servings = *value
+		}
+
+		options = append(options, mealplan.SetServings(servings))
+	}
+
+	return options
+}
+
 // NewQueryPagination creates a new query Pagination.
-func NewQueryPagination(page *Page) query.Pagination {
+// First and after page forward, and last and before page backward when first is not given.
+// Negative page sizes are reported as a validation error.
+func NewQueryPagination(page *Page) (query.Pagination, error) {
 	result := query.Pagination{
-		Size:   defaultPageSize,
-		Cursor: nil,
+		Size:     defaultPageSize,
+		Cursor:   nil,
+		Backward: false,
 	}
 
 	if page == nil {
-		return result
+		return result, nil
 	}
 
-	if page.First != nil {
-		result.Size = *page.First
+	switch {
+	case page.First != nil:
+		if *page.First < 0 {
+			return result, negativePageSizeError("first")
+		}
 
-		if page.After != nil {
-			result.Cursor = &query.Cursor{
-				ID:   page.After.ID,
-				Sort: newQuerySort(page.After.Sort),
-			}
+		result.Size = *page.First
+		result.Cursor = newQueryCursor(page.After)
+	case page.Last != nil:
+		if *page.Last < 0 {
+			return result, negativePageSizeError("last")
 		}
+
+		result.Size = *page.Last
+		result.Backward = true
+		result.Cursor = newQueryCursor(page.Before)
 	}
 
-	return result
+	return result, nil
+}
+
+// NewResultLimit returns how many results a list field asks for with its first argument,
+// or the default page size when it does not.
+func NewResultLimit(first *int) (int, error) {
+	if first == nil {
+		return defaultPageSize, nil
+	}
+
+	if *first < 0 {
+		return 0, negativePageSizeError("first")
+	}
+
+	return *first, nil
+}
+
+func negativePageSizeError(field string) error {
+	return &entity.ValidationError{
+		InnerErrors: []error{entity.NewFieldError(field, field+" must not be negative")},
+	}
 }
 
 // NewQueryOrder creates a new query Order.
@@ -198,6 +815,8 @@ func newSort(sort query.Sort) Sort {
 		return SortName
 	case query.UpdatedSort:
 		return SortUpdated
+	case query.RelevanceSort:
+		return SortRelevance
 	case query.CreatedSort:
 		fallthrough
 	default:
@@ -211,6 +830,8 @@ func newQuerySort(sort Sort) query.Sort {
 		return query.NameSort
 	case SortUpdated:
 		return query.UpdatedSort
+	case SortRelevance:
+		return query.RelevanceSort
 	case SortCreated:
 		fallthrough
 	default:
@@ -228,3 +849,33 @@ func newQueryDirection(direciton Direction) query.Direction {
 		return query.DescDirection
 	}
 }
+
+func newMealSlot(slot mealplan.Slot) MealSlot {
+	switch slot {
+	case mealplan.Breakfast:
+		return MealSlotBreakfast
+	case mealplan.Lunch:
+		return MealSlotLunch
+	case mealplan.Snack:
+		return MealSlotSnack
+	case mealplan.Dinner:
+		fallthrough
+	default:
+		return MealSlotDinner
+	}
+}
+
+func newMealplanSlot(slot MealSlot) mealplan.Slot {
+	switch slot {
+	case MealSlotBreakfast:
+		return mealplan.Breakfast
+	case MealSlotLunch:
+		return mealplan.Lunch
+	case MealSlotSnack:
+		return mealplan.Snack
+	case MealSlotDinner:
+		fallthrough
+	default:
+		return mealplan.Dinner
+	}
+}
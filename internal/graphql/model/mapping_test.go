@@ -1,12 +1,19 @@
 package model_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/b-sea/supply-run-api/internal/command"
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/pantry"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,11 +51,10 @@ func TestNewRecipe(t *testing.T) {
 		Tags: []string{
 			"good", "not good",
 		},
-		IsFavorite: true,
-		CreatedAt:  created,
-		CreatedBy:  entity.NewID("creator-123"),
-		UpdatedAt:  updated,
-		UpdatedBy:  entity.NewID("updater-123"),
+		CreatedAt: created,
+		CreatedBy: entity.NewID("creator-123"),
+		UpdatedAt: updated,
+		UpdatedBy: entity.NewID("updater-123"),
 	}
 
 	result := &model.Recipe{
@@ -66,7 +72,6 @@ func TestNewRecipe(t *testing.T) {
 		Tags: []string{
 			"good", "not good",
 		},
-		IsFavorite:  true,
 		CreatedAt:   created,
 		CreatedByID: entity.NewID("creator-123"),
 		UpdatedAt:   updated,
@@ -101,21 +106,22 @@ func TestNewQueryRecipeFilter(t *testing.T) {
 				Ingredients: []string{"bread", "tomato"},
 				CreatedBy:   &user.Key,
 				IsFavorite:  &favorite,
+				FavoriteOf:  entity.NewID("viewer-123"),
 			},
 		},
 		"empty": {
 			filter: &model.RecipeFilter{},
-			result: query.RecipeFilter{},
+			result: query.RecipeFilter{FavoriteOf: entity.NewID("viewer-123")},
 		},
 		"nil": {
 			filter: nil,
-			result: query.RecipeFilter{},
+			result: query.RecipeFilter{FavoriteOf: entity.NewID("viewer-123")},
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, test.result, model.NewQueryRecipeFilter(test.filter))
+			assert.Equal(t, test.result, model.NewQueryRecipeFilter(test.filter, entity.NewID("viewer-123")))
 		})
 	}
 }
@@ -123,6 +129,8 @@ func TestNewQueryRecipeFilter(t *testing.T) {
 func TestNewRecipeConnection(t *testing.T) {
 	t.Parallel()
 
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	type testCase struct {
 		page   *query.RecipePage
 		result *model.RecipeConnection
@@ -134,48 +142,54 @@ func TestNewRecipeConnection(t *testing.T) {
 				Info: query.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &query.Cursor{ID: entity.NewID("1")},
-					EndCursor:       &query.Cursor{ID: entity.NewID("1")},
+					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Time: created},
+					EndCursor:       &query.Cursor{ID: entity.NewID("1"), Time: created},
 				},
 				Items: []*query.Recipe{
 					{ID: entity.NewID("1")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Time: created},
+				},
 			},
 			result: &model.RecipeConnection{
 				PageInfo: &model.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated},
-					EndCursor:       &model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated},
+					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated, Time: created},
+					EndCursor:       &model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated, Time: created},
 				},
 				Edges: []*model.RecipeEdge{
 					{
-						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated},
+						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated, Time: created},
 						Node:   model.NewRecipe(&query.Recipe{ID: entity.NewID("1")}),
 					},
 				},
 			},
 		},
-		"updated sort": {
+		"relevance sort": {
 			page: &query.RecipePage{
 				Info: query.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Sort: query.UpdatedSort},
+					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 1.5},
 				},
 				Items: []*query.Recipe{
 					{ID: entity.NewID("1")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 1.5},
+				},
 			},
 			result: &model.RecipeConnection{
 				PageInfo: &model.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortUpdated},
+					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortRelevance, Score: 1.5},
 				},
 				Edges: []*model.RecipeEdge{
 					{
-						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortUpdated},
+						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortRelevance, Score: 1.5},
 						Node:   model.NewRecipe(&query.Recipe{ID: entity.NewID("1")}),
 					},
 				},
@@ -186,33 +200,38 @@ func TestNewRecipeConnection(t *testing.T) {
 				Info: query.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Sort: query.NameSort},
-					EndCursor:       &query.Cursor{ID: entity.NewID("3"), Sort: query.NameSort},
+					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Sort: query.NameSort, Name: "a"},
+					EndCursor:       &query.Cursor{ID: entity.NewID("3"), Sort: query.NameSort, Name: "c"},
 				},
 				Items: []*query.Recipe{
 					{ID: entity.NewID("1")},
 					{ID: entity.NewID("2")},
 					{ID: entity.NewID("3")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Sort: query.NameSort, Name: "a"},
+					{ID: entity.NewID("2"), Sort: query.NameSort, Name: "b"},
+					{ID: entity.NewID("3"), Sort: query.NameSort, Name: "c"},
+				},
 			},
 			result: &model.RecipeConnection{
 				PageInfo: &model.PageInfo{
 					HasNextPage:     true,
 					HasPreviousPage: true,
-					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortName},
-					EndCursor:       &model.Cursor{ID: entity.NewID("3"), Sort: model.SortName},
+					StartCursor:     &model.Cursor{ID: entity.NewID("1"), Sort: model.SortName, Name: "a"},
+					EndCursor:       &model.Cursor{ID: entity.NewID("3"), Sort: model.SortName, Name: "c"},
 				},
 				Edges: []*model.RecipeEdge{
 					{
-						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortName},
+						Cursor: model.Cursor{ID: entity.NewID("1"), Sort: model.SortName, Name: "a"},
 						Node:   model.NewRecipe(&query.Recipe{ID: entity.NewID("1")}),
 					},
 					{
-						Cursor: model.Cursor{ID: entity.NewID("2"), Sort: model.SortName},
+						Cursor: model.Cursor{ID: entity.NewID("2"), Sort: model.SortName, Name: "b"},
 						Node:   model.NewRecipe(&query.Recipe{ID: entity.NewID("2")}),
 					},
 					{
-						Cursor: model.Cursor{ID: entity.NewID("3"), Sort: model.SortName},
+						Cursor: model.Cursor{ID: entity.NewID("3"), Sort: model.SortName, Name: "c"},
 						Node:   model.NewRecipe(&query.Recipe{ID: entity.NewID("3")}),
 					},
 				},
@@ -241,6 +260,69 @@ func TestNewRecipeConnection(t *testing.T) {
 	}
 }
 
+func TestNewUpdateRecipeOptions(t *testing.T) {
+	t.Parallel()
+
+	test, err := recipe.New(
+		entity.NewID("1"), "pancakes", time.Now(), entity.NewID("user"),
+		recipe.AddStep("mix"),
+		recipe.AddTag("breakfast"),
+	)
+	assert.NoError(t, err)
+
+	name := "crepes"
+	unitID := model.NewUnitID(entity.NewID("cup"))
+
+	err = test.Update(time.Now(), entity.NewID("user"), model.NewUpdateRecipeOptions(model.UpdateRecipeInput{
+		Name:        &name,
+		Ingredients: []*model.IngredientInput{{Name: "flour", Quantity: 2, Unit: &unitID}, {Name: "egg", Quantity: 1}},
+		Tags:        []string{},
+	})...)
+	assert.NoError(t, err)
+	assert.Equal(t, "crepes", test.Name())
+	assert.Equal(t, []string{"mix"}, test.Steps())
+	assert.Equal(t, 2, len(test.Ingredients()))
+	assert.Equal(t, entity.NewID("cup"), test.Ingredients()[0].UnitID())
+	assert.Equal(t, entity.NewID(""), test.Ingredients()[1].UnitID())
+	assert.Equal(t, []string{}, test.Tags())
+}
+
+func TestNewIngredientInputs(t *testing.T) {
+	t.Parallel()
+
+	unitID := model.NewUnitID(entity.NewID("cup"))
+
+	result := model.NewIngredientInputs([]ingredient.Line{
+		{Name: "flour", Quantity: 1, MaxQuantity: 2, UnitID: entity.NewID("cup"), Note: "sifted"},
+		{Name: "salt", UnitID: entity.NewID(""), Note: "to taste"},
+	})
+
+	assert.Equal(t, []*model.IngredientInput{
+		{Name: "flour", Quantity: 2, Unit: &unitID},
+		{Name: "salt", Quantity: 1, Unit: nil},
+	}, result)
+}
+
+func TestNewValidationError(t *testing.T) {
+	t.Parallel()
+
+	field := "name"
+
+	result := model.NewValidationError(&entity.ValidationError{
+		InnerErrors: []error{
+			entity.NewFieldError("name", "name cannot be empty"),
+			errors.New("something else"),
+		},
+	})
+
+	assert.Equal(t, &model.ValidationError{
+		Errors: []*model.FieldError{
+			{Field: &field, Message: "name cannot be empty"},
+			{Field: nil, Message: "something else"},
+		},
+	}, result)
+}
+
 func TestNewUserID(t *testing.T) {
 	t.Parallel()
 
@@ -276,9 +358,11 @@ func TestNewQueryPagination(t *testing.T) {
 	type testCase struct {
 		page   *model.Page
 		result query.Pagination
+		err    error
 	}
 
 	first := 100
+	negative := -10
 
 	tests := map[string]testCase{
 		"name sort": {
@@ -287,6 +371,7 @@ func TestNewQueryPagination(t *testing.T) {
 				After: &model.Cursor{
 					ID:   entity.NewID("1"),
 					Sort: model.SortName,
+					Name: "pancakes",
 				},
 			},
 			result: query.Pagination{
@@ -294,6 +379,23 @@ func TestNewQueryPagination(t *testing.T) {
 				Cursor: &query.Cursor{
 					ID:   entity.NewID("1"),
 					Sort: query.NameSort,
+					Name: "pancakes",
+				},
+			},
+		},
+		"relevance sort": {
+			page: &model.Page{
+				First: &first,
+				After: &model.Cursor{
+					ID:   entity.NewID("1"),
+					Sort: model.SortRelevance,
+				},
+			},
+			result: query.Pagination{
+				Size: 100,
+				Cursor: &query.Cursor{
+					ID:   entity.NewID("1"),
+					Sort: query.RelevanceSort,
 				},
 			},
 		},
@@ -329,6 +431,36 @@ func TestNewQueryPagination(t *testing.T) {
 				},
 			},
 		},
+		"backward": {
+			page: &model.Page{
+				Last: &first,
+				Before: &model.Cursor{
+					ID:   entity.NewID("1"),
+					Sort: model.SortName,
+				},
+			},
+			result: query.Pagination{
+				Size: 100,
+				Cursor: &query.Cursor{
+					ID:   entity.NewID("1"),
+					Sort: query.NameSort,
+				},
+				Backward: true,
+			},
+		},
+		"first before last": {
+			page: &model.Page{
+				First: &first,
+				Last:  &first,
+				Before: &model.Cursor{
+					ID:   entity.NewID("1"),
+					Sort: model.SortName,
+				},
+			},
+			result: query.Pagination{
+				Size: 100,
+			},
+		},
 		"empty": {
 			page: &model.Page{},
 			result: query.Pagination{
@@ -341,11 +473,32 @@ func TestNewQueryPagination(t *testing.T) {
 				Size: 50,
 			},
 		},
+		"negative first": {
+			page: &model.Page{First: &negative},
+			result: query.Pagination{
+				Size: 50,
+			},
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("first", "first must not be negative")},
+			},
+		},
+		"negative last": {
+			page: &model.Page{Last: &negative},
+			result: query.Pagination{
+				Size: 50,
+			},
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("last", "last must not be negative")},
+			},
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, test.result, model.NewQueryPagination(test.page))
+			result, err := model.NewQueryPagination(test.page)
+
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.err, err)
 		})
 	}
 }
@@ -388,3 +541,259 @@ func TestNewQueryOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestNewQueryIngredients(t *testing.T) {
+	t.Parallel()
+
+	ingredients := []query.Ingredient{
+		{Name: "bread", Quantity: 2, UnitID: entity.NewID("slice")},
+		{Name: "milk", Quantity: 0.5},
+	}
+
+	result := model.NewIngredients(ingredients)
+
+	assert.Equal(t, []*model.Ingredient{
+		{Name: "bread", Quantity: 2, UnitID: entity.NewID("slice")},
+		{Name: "milk", Quantity: 0.5},
+	}, result)
+	assert.Equal(t, ingredients, model.NewQueryIngredients(result))
+}
+
+func TestNewShoppingList(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := model.NewShoppingList(&query.ShoppingList{
+		ID:   entity.NewID("list-1"),
+		Name: "groceries",
+		Items: []query.ShoppingItem{
+			{ID: entity.NewID("item-1"), Name: "flour", Quantity: 2, UnitID: entity.NewID("cup"), Checked: true},
+		},
+		CreatedAt: timestamp,
+		CreatedBy: entity.NewID("user-1"),
+		UpdatedAt: timestamp,
+		UpdatedBy: entity.NewID("user-2"),
+	})
+
+	assert.Equal(t, &model.ShoppingList{
+		ID:   model.NewShoppingListID(entity.NewID("list-1")),
+		Name: "groceries",
+		Items: []*model.ShoppingItem{
+			{
+				ID:       model.NewShoppingItemID(entity.NewID("item-1")),
+				Name:     "flour",
+				Quantity: 2,
+				Checked:  true,
+				UnitID:   entity.NewID("cup"),
+			},
+		},
+		CreatedAt:   timestamp,
+		CreatedByID: entity.NewID("user-1"),
+		UpdatedAt:   timestamp,
+		UpdatedByID: entity.NewID("user-2"),
+	}, result)
+}
+
+func TestNewQueryRecipeServings(t *testing.T) {
+	t.Parallel()
+
+	servings := 4
+	result := model.NewQueryRecipeServings([]*model.RecipeServingsInput{
+		{Recipe: model.NewRecipeID(entity.NewID("recipe-1")), Servings: &servings},
+		{Recipe: model.NewRecipeID(entity.NewID("recipe-2"))},
+		{Recipe: model.NewUnitID(entity.NewID("recipe-3"))},
+	})
+
+	assert.Equal(t, []query.RecipeServings{
+		{RecipeID: entity.NewID("recipe-1"), Servings: 4},
+		{RecipeID: entity.NewID("recipe-2"), Servings: 0},
+		{RecipeID: entity.NewID(""), Servings: 0},
+	}, result)
+}
+
+func TestNewShoppingItems(t *testing.T) {
+	t.Parallel()
+
+	quantity := 2.0
+	unit := model.NewUnitID(entity.NewID("cup"))
+	recipe := model.NewRecipeID(entity.NewID("cup"))
+
+	result := model.NewShoppingItems([]*model.ShoppingItemInput{
+		{Name: "flour", Quantity: &quantity, Unit: &unit},
+		{Name: "paper towels"},
+		{Name: "milk", Unit: &recipe},
+	})
+
+	assert.Equal(t, []command.ShoppingItem{
+		{Name: "flour", Quantity: 2, UnitID: entity.NewID("cup")},
+		{Name: "paper towels", Quantity: 0, UnitID: entity.NewID("")},
+		{Name: "milk", Quantity: 0, UnitID: entity.NewID("")},
+	}, result)
+
+	assert.Equal(
+		t,
+		[]command.ShoppingItem{{Name: "flour", Quantity: 2, UnitID: entity.NewID("cup")}},
+		model.NewPlannedShoppingItems([]query.Ingredient{{Name: "flour", Quantity: 2, UnitID: entity.NewID("cup")}}),
+	)
+}
+
+func TestNewPantryItem(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	quantity := 1.0
+	result := model.NewPantryItem(&query.PantryItem{
+		ID:        entity.NewID("item-1"),
+		Name:      "milk",
+		Quantity:  &quantity,
+		UnitID:    entity.NewID("gallon"),
+		ExpiresAt: &timestamp,
+		CreatedAt: timestamp,
+		CreatedBy: entity.NewID("user-1"),
+		UpdatedAt: timestamp,
+		UpdatedBy: entity.NewID("user-2"),
+	})
+
+	assert.Equal(t, &model.PantryItem{
+		ID:          model.NewPantryItemID(entity.NewID("item-1")),
+		Name:        "milk",
+		Quantity:    &quantity,
+		ExpiresAt:   &timestamp,
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+		UnitID:      entity.NewID("gallon"),
+		CreatedByID: entity.NewID("user-1"),
+		UpdatedByID: entity.NewID("user-2"),
+	}, result)
+}
+
+func TestNewUpdatePantryItemOptions(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	unit := model.NewUnitID(entity.NewID("cup"))
+
+	item, err := pantry.New(
+		entity.NewID("item-1"), "flour", time.Now(), entity.NewID("user-1"),
+		model.NewCreatePantryItemOptions(model.CreatePantryItemInput{Name: "flour", Unit: &unit, ExpiresAt: &expiry})...,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID("cup"), item.UnitID())
+	assert.Equal(t, &expiry, item.ExpiresAt())
+
+	// Leaving fields out keeps them
+	quantity := 2.0
+	err = item.Update(time.Now(), entity.NewID("user-2"), model.NewUpdatePantryItemOptions(model.UpdatePantryItemInput{
+		Quantity: graphql.OmittableOf(&quantity),
+	})...)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), *item.Quantity())
+	assert.Equal(t, entity.NewID("cup"), item.UnitID())
+	assert.Equal(t, &expiry, item.ExpiresAt())
+
+	// Setting fields to null clears them
+	err = item.Update(time.Now(), entity.NewID("user-2"), model.NewUpdatePantryItemOptions(model.UpdatePantryItemInput{
+		Quantity:  graphql.OmittableOf[*float64](nil),
+		Unit:      graphql.OmittableOf[*model.ID](nil),
+		ExpiresAt: graphql.OmittableOf[*time.Time](nil),
+	})...)
+	assert.NoError(t, err)
+	assert.Nil(t, item.Quantity())
+	assert.Equal(t, entity.NewID(""), item.UnitID())
+	assert.Nil(t, item.ExpiresAt())
+}
+
+func TestNewMeal(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	servings := 4
+
+	result := model.NewMeal(&query.Meal{
+		ID:        entity.NewID("meal-1"),
+		Date:      timestamp,
+		Slot:      "breakfast",
+		RecipeID:  entity.NewID("recipe-1"),
+		Servings:  servings,
+		CreatedAt: timestamp,
+		CreatedBy: entity.NewID("user-1"),
+		UpdatedAt: timestamp,
+		UpdatedBy: entity.NewID("user-2"),
+	})
+
+	assert.Equal(t, &model.Meal{
+		ID:          model.NewMealID(entity.NewID("meal-1")),
+		Date:        timestamp,
+		Slot:        model.MealSlotBreakfast,
+		Servings:    &servings,
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+		RecipeID:    entity.NewID("recipe-1"),
+		CreatedByID: entity.NewID("user-1"),
+		UpdatedByID: entity.NewID("user-2"),
+	}, result)
+
+	// Meals without servings use the recipe's own
+	result = model.NewMeal(&query.Meal{ID: entity.NewID("meal-2"), Slot: "dinner"})
+	assert.Nil(t, result.Servings)
+	assert.Equal(t, model.MealSlotDinner, result.Slot)
+}
+
+func TestNewUpdateMealOptions(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	slot := model.MealSlotSnack
+	servings := 3
+
+	meal, err := mealplan.New(
+		entity.NewID("meal-1"), monday, entity.NewID("recipe-1"), time.Now(), entity.NewID("user-1"),
+		model.NewCreateMealOptions(model.CreateMealInput{Slot: &slot, Servings: &servings})...,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, mealplan.Snack, meal.Slot())
+	assert.Equal(t, 3, meal.Servings())
+
+	// Leaving fields out keeps them
+	recipe := model.NewRecipeID(entity.NewID("recipe-2"))
+	err = meal.Update(time.Now(), entity.NewID("user-2"), model.NewUpdateMealOptions(model.UpdateMealInput{
+		Recipe: &recipe,
+	})...)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID("recipe-2"), meal.RecipeID())
+	assert.Equal(t, 3, meal.Servings())
+
+	// Setting servings to null falls back to the recipe
+	err = meal.Update(time.Now(), entity.NewID("user-2"), model.NewUpdateMealOptions(model.UpdateMealInput{
+		Servings: graphql.OmittableOf[*int](nil),
+	})...)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, meal.Servings())
+
+	// Recipes of the wrong kind are rejected
+	wrong := model.NewUnitID(entity.NewID("recipe-3"))
+	err = meal.Update(time.Now(), entity.NewID("user-2"), model.NewUpdateMealOptions(model.UpdateMealInput{
+		Recipe: &wrong,
+	})...)
+	assert.Error(t, err)
+}
+
+func TestNewImportSource(t *testing.T) {
+	t.Parallel()
+
+	address := "https://example.com/pancakes"
+	document := "<html></html>"
+
+	source, result := model.NewImportSource(model.ImportRecipeInput{URL: &address})
+	assert.Equal(t, address, source)
+	assert.Nil(t, result)
+
+	// Uploaded documents keep the url as their source
+	source, result = model.NewImportSource(model.ImportRecipeInput{URL: &address, HTML: &document})
+	assert.Equal(t, address, source)
+	assert.Equal(t, []byte(document), result)
+
+	source, result = model.NewImportSource(model.ImportRecipeInput{})
+	assert.Empty(t, source)
+	assert.Nil(t, result)
+}
@@ -9,26 +9,227 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
 )
 
+type ConvertResult interface {
+	IsConvertResult()
+}
+
+type CreateConversionResult interface {
+	IsCreateConversionResult()
+}
+
+type CreateMealResult interface {
+	IsCreateMealResult()
+}
+
+type CreatePantryItemResult interface {
+	IsCreatePantryItemResult()
+}
+
+type CreateRecipeResult interface {
+	IsCreateRecipeResult()
+}
+
+type CreateShoppingListResult interface {
+	IsCreateShoppingListResult()
+}
+
+type CreateUnitResult interface {
+	IsCreateUnitResult()
+}
+
+type DeleteMealResult interface {
+	IsDeleteMealResult()
+}
+
+type DeletePantryItemResult interface {
+	IsDeletePantryItemResult()
+}
+
+type DeleteRecipeResult interface {
+	IsDeleteRecipeResult()
+}
+
+type DeleteShoppingListResult interface {
+	IsDeleteShoppingListResult()
+}
+
+type FavoriteRecipeResult interface {
+	IsFavoriteRecipeResult()
+}
+
+type ImportRecipeResult interface {
+	IsImportRecipeResult()
+}
+
+type MealResult interface {
+	IsMealResult()
+}
+
 type Node interface {
 	IsNode()
 	GetID() ID
 }
 
+type PantryItemResult interface {
+	IsPantryItemResult()
+}
+
+type RecipeChangedResult interface {
+	IsRecipeChangedResult()
+}
+
 type RecipeResult interface {
 	IsRecipeResult()
 }
 
+type ShoppingListChangedResult interface {
+	IsShoppingListChangedResult()
+}
+
+type ShoppingListResult interface {
+	IsShoppingListResult()
+}
+
+type StillNeededResult interface {
+	IsStillNeededResult()
+}
+
 type UnitResult interface {
 	IsUnitResult()
 }
 
+type UpdateMealResult interface {
+	IsUpdateMealResult()
+}
+
+type UpdatePantryItemResult interface {
+	IsUpdatePantryItemResult()
+}
+
+type UpdateRecipeResult interface {
+	IsUpdateRecipeResult()
+}
+
+type UpdateShoppingListResult interface {
+	IsUpdateShoppingListResult()
+}
+
 type UserResult interface {
 	IsUserResult()
 }
 
+type Conversion struct {
+	From   UnitResult `json:"from"`
+	To     UnitResult `json:"to"`
+	Ratio  float64    `json:"ratio"`
+	FromID entity.ID  `json:"-"`
+	ToID   entity.ID  `json:"-"`
+}
+
+func (Conversion) IsCreateConversionResult() {}
+
+type ConvertedQuantity struct {
+	Quantity float64    `json:"quantity"`
+	Unit     UnitResult `json:"unit"`
+	UnitID   entity.ID  `json:"-"`
+}
+
+func (ConvertedQuantity) IsConvertResult() {}
+
+type CookableRecipe struct {
+	Recipe   *Recipe  `json:"recipe"`
+	Coverage float64  `json:"coverage"`
+	Missing  []string `json:"missing"`
+}
+
+type CreateConversionInput struct {
+	From  ID      `json:"from"`
+	To    ID      `json:"to"`
+	Ratio float64 `json:"ratio"`
+}
+
+type CreateMealInput struct {
+	Date     time.Time `json:"date"`
+	Slot     *MealSlot `json:"slot,omitempty"`
+	Recipe   ID        `json:"recipe"`
+	Servings *int      `json:"servings,omitempty"`
+}
+
+type CreatePantryItemInput struct {
+	Name      string     `json:"name"`
+	Quantity  *float64   `json:"quantity,omitempty"`
+	Unit      *ID        `json:"unit,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreateRecipeInput struct {
+	Name            string             `json:"name"`
+	URL             *string            `json:"url,omitempty"`
+	NumServings     *int               `json:"numServings,omitempty"`
+	Steps           []string           `json:"steps,omitempty"`
+	Ingredients     []*IngredientInput `json:"ingredients,omitempty"`
+	IngredientLines []string           `json:"ingredientLines,omitempty"`
+	Tags            []string           `json:"tags,omitempty"`
+}
+
+type CreateShoppingListInput struct {
+	Name    string                 `json:"name"`
+	Recipes []*RecipeServingsInput `json:"recipes,omitempty"`
+	Items   []*ShoppingItemInput   `json:"items,omitempty"`
+}
+
+type CreateUnitInput struct {
+	Name     string  `json:"name"`
+	Symbol   string  `json:"symbol"`
+	Plural   *string `json:"plural,omitempty"`
+	BaseType *string `json:"baseType,omitempty"`
+	System   *string `json:"system,omitempty"`
+}
+
+type DeletedMeal struct {
+	ID ID `json:"id"`
+}
+
+func (DeletedMeal) IsDeleteMealResult() {}
+
+type DeletedPantryItem struct {
+	ID ID `json:"id"`
+}
+
+func (DeletedPantryItem) IsDeletePantryItemResult() {}
+
+type DeletedRecipe struct {
+	ID ID `json:"id"`
+}
+
+func (DeletedRecipe) IsDeleteRecipeResult() {}
+
+func (DeletedRecipe) IsRecipeChangedResult() {}
+
+type DeletedShoppingList struct {
+	ID ID `json:"id"`
+}
+
+func (DeletedShoppingList) IsDeleteShoppingListResult() {}
+
+func (DeletedShoppingList) IsShoppingListChangedResult() {}
+
+type FieldError struct {
+	Field   *string `json:"field,omitempty"`
+	Message string  `json:"message"`
+}
+
+type ImportRecipeInput struct {
+	URL     *string `json:"url,omitempty"`
+	HTML    *string `json:"html,omitempty"`
+	Preview bool    `json:"preview"`
+}
+
 type Ingredient struct {
 	Name     string     `json:"name"`
 	Quantity float64    `json:"quantity"`
@@ -36,6 +237,45 @@ type Ingredient struct {
 	UnitID   entity.ID  `json:"-"`
 }
 
+type IngredientInput struct {
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Unit     *ID     `json:"unit,omitempty"`
+}
+
+type Meal struct {
+	ID          ID           `json:"id"`
+	Date        time.Time    `json:"date"`
+	Slot        MealSlot     `json:"slot"`
+	Recipe      RecipeResult `json:"recipe"`
+	Servings    *int         `json:"servings,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	CreatedBy   UserResult   `json:"createdBy"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	UpdatedBy   UserResult   `json:"updatedBy"`
+	CreatedByID entity.ID    `json:"-"`
+	RecipeID    entity.ID    `json:"-"`
+	UpdatedByID entity.ID    `json:"-"`
+}
+
+func (Meal) IsNode()        {}
+func (this Meal) GetID() ID { return this.ID }
+
+func (Meal) IsMealResult() {}
+
+func (Meal) IsCreateMealResult() {}
+
+func (Meal) IsUpdateMealResult() {}
+
+type Mutation struct {
+}
+
+type NeededIngredients struct {
+	Ingredients []*Ingredient `json:"ingredients"`
+}
+
+func (NeededIngredients) IsStillNeededResult() {}
+
 type NotFoundError struct {
 	ID ID `json:"id"`
 }
@@ -43,8 +283,32 @@ type NotFoundError struct {
 func (NotFoundError) IsNode()        {}
 func (this NotFoundError) GetID() ID { return this.ID }
 
+func (NotFoundError) IsMealResult() {}
+
+func (NotFoundError) IsUpdateMealResult() {}
+
+func (NotFoundError) IsDeleteMealResult() {}
+
+func (NotFoundError) IsPantryItemResult() {}
+
+func (NotFoundError) IsUpdatePantryItemResult() {}
+
+func (NotFoundError) IsDeletePantryItemResult() {}
+
 func (NotFoundError) IsRecipeResult() {}
 
+func (NotFoundError) IsUpdateRecipeResult() {}
+
+func (NotFoundError) IsDeleteRecipeResult() {}
+
+func (NotFoundError) IsFavoriteRecipeResult() {}
+
+func (NotFoundError) IsShoppingListResult() {}
+
+func (NotFoundError) IsUpdateShoppingListResult() {}
+
+func (NotFoundError) IsDeleteShoppingListResult() {}
+
 func (NotFoundError) IsUnitResult() {}
 
 func (NotFoundError) IsUserResult() {}
@@ -55,8 +319,10 @@ type Order struct {
 }
 
 type Page struct {
-	First *int    `json:"first,omitempty"`
-	After *Cursor `json:"after,omitempty"`
+	First  *int    `json:"first,omitempty"`
+	After  *Cursor `json:"after,omitempty"`
+	Last   *int    `json:"last,omitempty"`
+	Before *Cursor `json:"before,omitempty"`
 }
 
 type PageInfo struct {
@@ -66,6 +332,44 @@ type PageInfo struct {
 	EndCursor       *Cursor `json:"endCursor,omitempty"`
 }
 
+type PantryFilter struct {
+	Name *string `json:"name,omitempty"`
+}
+
+type PantryItem struct {
+	ID          ID         `json:"id"`
+	Name        string     `json:"name"`
+	Quantity    *float64   `json:"quantity,omitempty"`
+	Unit        UnitResult `json:"unit"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CreatedBy   UserResult `json:"createdBy"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UpdatedBy   UserResult `json:"updatedBy"`
+	CreatedByID entity.ID  `json:"-"`
+	UnitID      entity.ID  `json:"-"`
+	UpdatedByID entity.ID  `json:"-"`
+}
+
+func (PantryItem) IsNode()        {}
+func (this PantryItem) GetID() ID { return this.ID }
+
+func (PantryItem) IsPantryItemResult() {}
+
+func (PantryItem) IsCreatePantryItemResult() {}
+
+func (PantryItem) IsUpdatePantryItemResult() {}
+
+type ParsedIngredient struct {
+	Text        string     `json:"text"`
+	Name        string     `json:"name"`
+	Quantity    *float64   `json:"quantity,omitempty"`
+	MaxQuantity *float64   `json:"maxQuantity,omitempty"`
+	Unit        UnitResult `json:"unit"`
+	Note        string     `json:"note"`
+	UnitID      entity.ID  `json:"-"`
+}
+
 type Query struct {
 }
 
@@ -91,9 +395,21 @@ func (this Recipe) GetID() ID { return this.ID }
 
 func (Recipe) IsRecipeResult() {}
 
+func (Recipe) IsCreateRecipeResult() {}
+
+func (Recipe) IsUpdateRecipeResult() {}
+
+func (Recipe) IsFavoriteRecipeResult() {}
+
+func (Recipe) IsImportRecipeResult() {}
+
+func (Recipe) IsRecipeChangedResult() {}
+
 type RecipeConnection struct {
-	PageInfo *PageInfo     `json:"pageInfo"`
-	Edges    []*RecipeEdge `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	Edges      []*RecipeEdge      `json:"edges"`
+	TotalCount int                `json:"totalCount"`
+	Filter     query.RecipeFilter `json:"-"`
 }
 
 type RecipeEdge struct {
@@ -108,12 +424,70 @@ type RecipeFilter struct {
 	IsFavorite  *bool    `json:"isFavorite,omitempty"`
 }
 
+type RecipePreview struct {
+	Name        string              `json:"name"`
+	URL         string              `json:"url"`
+	NumServings int                 `json:"numServings"`
+	Steps       []string            `json:"steps"`
+	Ingredients []*ParsedIngredient `json:"ingredients"`
+	Tags        []string            `json:"tags"`
+}
+
+func (RecipePreview) IsImportRecipeResult() {}
+
+type RecipeServingsInput struct {
+	Recipe   ID   `json:"recipe"`
+	Servings *int `json:"servings,omitempty"`
+}
+
+type ShoppingItem struct {
+	ID       ID         `json:"id"`
+	Name     string     `json:"name"`
+	Quantity float64    `json:"quantity"`
+	Unit     UnitResult `json:"unit"`
+	Checked  bool       `json:"checked"`
+	UnitID   entity.ID  `json:"-"`
+}
+
+type ShoppingItemInput struct {
+	Name     string   `json:"name"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     *ID      `json:"unit,omitempty"`
+}
+
+type ShoppingList struct {
+	ID          ID              `json:"id"`
+	Name        string          `json:"name"`
+	Items       []*ShoppingItem `json:"items"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	CreatedBy   UserResult      `json:"createdBy"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	UpdatedBy   UserResult      `json:"updatedBy"`
+	CreatedByID entity.ID       `json:"-"`
+	UpdatedByID entity.ID       `json:"-"`
+}
+
+func (ShoppingList) IsNode()        {}
+func (this ShoppingList) GetID() ID { return this.ID }
+
+func (ShoppingList) IsShoppingListResult() {}
+
+func (ShoppingList) IsCreateShoppingListResult() {}
+
+func (ShoppingList) IsUpdateShoppingListResult() {}
+
+func (ShoppingList) IsShoppingListChangedResult() {}
+
+type Subscription struct {
+}
+
 type Unit struct {
-	ID       ID     `json:"id"`
-	Name     string `json:"name"`
-	Symbol   string `json:"symbol"`
-	BaseType string `json:"baseType"`
-	System   string `json:"system"`
+	ID          ID            `json:"id"`
+	Name        string        `json:"name"`
+	Symbol      string        `json:"symbol"`
+	BaseType    string        `json:"baseType"`
+	System      string        `json:"system"`
+	Conversions []*Conversion `json:"conversions"`
 }
 
 func (Unit) IsNode()        {}
@@ -121,9 +495,40 @@ func (this Unit) GetID() ID { return this.ID }
 
 func (Unit) IsUnitResult() {}
 
+func (Unit) IsCreateUnitResult() {}
+
+type UpdateMealInput struct {
+	Date     *time.Time              `json:"date,omitempty"`
+	Slot     *MealSlot               `json:"slot,omitempty"`
+	Recipe   *ID                     `json:"recipe,omitempty"`
+	Servings graphql.Omittable[*int] `json:"servings,omitempty"`
+}
+
+type UpdatePantryItemInput struct {
+	Name      *string                       `json:"name,omitempty"`
+	Quantity  graphql.Omittable[*float64]   `json:"quantity,omitempty"`
+	Unit      graphql.Omittable[*ID]        `json:"unit,omitempty"`
+	ExpiresAt graphql.Omittable[*time.Time] `json:"expiresAt,omitempty"`
+}
+
+type UpdateRecipeInput struct {
+	Name            *string            `json:"name,omitempty"`
+	URL             *string            `json:"url,omitempty"`
+	NumServings     *int               `json:"numServings,omitempty"`
+	Steps           []string           `json:"steps,omitempty"`
+	Ingredients     []*IngredientInput `json:"ingredients,omitempty"`
+	IngredientLines []string           `json:"ingredientLines,omitempty"`
+	Tags            []string           `json:"tags,omitempty"`
+}
+
+type UpdateShoppingListInput struct {
+	Name *string `json:"name,omitempty"`
+}
+
 type User struct {
-	ID       ID     `json:"id"`
-	Username string `json:"username"`
+	ID        ID                `json:"id"`
+	Username  string            `json:"username"`
+	Favorites *RecipeConnection `json:"favorites"`
 }
 
 func (User) IsNode()        {}
@@ -131,6 +536,36 @@ func (this User) GetID() ID { return this.ID }
 
 func (User) IsUserResult() {}
 
+type ValidationError struct {
+	Errors []*FieldError `json:"errors"`
+}
+
+func (ValidationError) IsCreateMealResult() {}
+
+func (ValidationError) IsUpdateMealResult() {}
+
+func (ValidationError) IsCreatePantryItemResult() {}
+
+func (ValidationError) IsUpdatePantryItemResult() {}
+
+func (ValidationError) IsStillNeededResult() {}
+
+func (ValidationError) IsCreateRecipeResult() {}
+
+func (ValidationError) IsUpdateRecipeResult() {}
+
+func (ValidationError) IsImportRecipeResult() {}
+
+func (ValidationError) IsCreateShoppingListResult() {}
+
+func (ValidationError) IsUpdateShoppingListResult() {}
+
+func (ValidationError) IsConvertResult() {}
+
+func (ValidationError) IsCreateUnitResult() {}
+
+func (ValidationError) IsCreateConversionResult() {}
+
 type Direction string
 
 const (
@@ -186,23 +621,84 @@ func (e Direction) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type MealSlot string
+
+const (
+	MealSlotBreakfast MealSlot = "BREAKFAST"
+	MealSlotLunch     MealSlot = "LUNCH"
+	MealSlotDinner    MealSlot = "DINNER"
+	MealSlotSnack     MealSlot = "SNACK"
+)
+
+var AllMealSlot = []MealSlot{
+	MealSlotBreakfast,
+	MealSlotLunch,
+	MealSlotDinner,
+	MealSlotSnack,
+}
+
+func (e MealSlot) IsValid() bool {
+	switch e {
+	case MealSlotBreakfast, MealSlotLunch, MealSlotDinner, MealSlotSnack:
+		return true
+	}
+	return false
+}
+
+func (e MealSlot) String() string {
+	return string(e)
+}
+
+func (e *MealSlot) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MealSlot(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MealSlot", str)
+	}
+	return nil
+}
+
+func (e MealSlot) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MealSlot) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MealSlot) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type Sort string
 
 const (
-	SortCreated Sort = "CREATED"
-	SortUpdated Sort = "UPDATED"
-	SortName    Sort = "NAME"
+	SortCreated   Sort = "CREATED"
+	SortUpdated   Sort = "UPDATED"
+	SortName      Sort = "NAME"
+	SortRelevance Sort = "RELEVANCE"
 )
 
 var AllSort = []Sort{
 	SortCreated,
 	SortUpdated,
 	SortName,
+	SortRelevance,
 }
 
 func (e Sort) IsValid() bool {
 	switch e {
-	case SortCreated, SortUpdated, SortName:
+	case SortCreated, SortUpdated, SortName, SortRelevance:
 		return true
 	}
 	return false
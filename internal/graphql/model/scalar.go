@@ -1,10 +1,18 @@
 package model
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/b-sea/supply-run-api/internal/entity"
@@ -15,23 +23,45 @@ type Kind string
 
 // RecipeKind, et al. are custom GraphQL relay types.
 const (
-	RecipeKind = Kind("recipe")
-	UnitKind   = Kind("unit")
-	UserKind   = Kind("user")
+	RecipeKind       = Kind("recipe")
+	UnitKind         = Kind("unit")
+	UserKind         = Kind("user")
+	ShoppingListKind = Kind("shoppinglist")
+	ShoppingItemKind = Kind("shoppingitem")
+	PantryItemKind   = Kind("pantryitem")
+	MealKind         = Kind("meal")
 
 	delim            = ":"
 	idSplitCount     = 2
-	cursorSplitCount = 2
+	cursorDelim      = "."
+	cursorVersion    = "v1"
+	cursorSplitCount = 3
+	dateLayout       = time.DateOnly
+	randomSecretSize = 32
 )
 
+type ctxKey string
+
+const cursorSignerKey = ctxKey("cursors")
+
+// ErrInvalidID is returned when an ID cannot be decoded.
+var ErrInvalidID = errors.New("invalid id")
+
+// ErrInvalidDate is returned when a Date cannot be parsed.
+var ErrInvalidDate = errors.New("invalid date")
+
+// ErrInvalidCursor is returned when a Cursor cannot be decoded or has been corrupted.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 // ID is a global identifier.
 type ID struct {
 	Key  entity.ID
 	Kind Kind
 }
 
+// String encodes the ID as URL-safe base64, so it can be used as a path segment.
 func (s ID) String() string {
-	return base64.StdEncoding.EncodeToString([]byte(string(s.Kind) + delim + s.Key.String()))
+	return base64.URLEncoding.EncodeToString([]byte(string(s.Kind) + delim + s.Key.String()))
 }
 
 // MarshalID marshals an ID to a GraphQL format.
@@ -44,20 +74,31 @@ func MarshalID(value ID) graphql.Marshaler { //nolint: ireturn
 }
 
 // UnmarshalID unmarshals a value into an ID.
+// IDs must be base64 encoded and have both a kind and a key.
 func UnmarshalID(value any) (ID, error) {
 	str, ok := value.(string)
 	if !ok {
-		return ID{}, nil
+		return ID{}, fmt.Errorf("%w: %v", ErrInvalidID, value)
 	}
 
-	decoded, err := base64.StdEncoding.DecodeString(str)
+	return ParseID(str)
+}
+
+// ParseID decodes an ID from its string form.
+// Standard base64 is accepted alongside URL-safe base64 for IDs handed out before the switch.
+func ParseID(str string) (ID, error) {
+	decoded, err := base64.URLEncoding.DecodeString(str)
 	if err != nil {
-		return ID{}, nil //nolint: nilerr
+		decoded, err = base64.StdEncoding.DecodeString(str)
+	}
+
+	if err != nil {
+		return ID{}, fmt.Errorf("%w: %q", ErrInvalidID, str)
 	}
 
 	split := strings.Split(string(decoded), delim)
-	if len(split) != idSplitCount {
-		return ID{}, nil
+	if len(split) != idSplitCount || split[0] == "" || split[1] == "" {
+		return ID{}, fmt.Errorf("%w: %q", ErrInvalidID, str)
 	}
 
 	return ID{
@@ -67,44 +108,164 @@ func UnmarshalID(value any) (ID, error) {
 }
 
 // Cursor is placement of an item on a page.
+// Like a query Cursor, it keeps the sort value of the item next to its ID.
+// Cursors are encoded as a version, a base64 JSON payload and an HMAC-SHA256 of the two, separated by dots.
 type Cursor struct {
-	ID   entity.ID
-	Sort Sort
+	ID    entity.ID
+	Sort  Sort
+	Time  time.Time
+	Name  string
+	Score float64
 }
 
-func (s Cursor) String() string {
-	return base64.StdEncoding.EncodeToString([]byte(s.ID.String() + delim + s.Sort.String()))
+type cursorPayload struct {
+	ID    string    `json:"id"`
+	Sort  Sort      `json:"sort"`
+	Time  time.Time `json:"time"`
+	Name  string    `json:"name,omitempty"`
+	Score float64   `json:"score,omitempty"`
 }
 
-// MarshalCursor marshals a Cursor to a GraphQL format.
-func MarshalCursor(value Cursor) graphql.Marshaler { //nolint: ireturn
-	return graphql.WriterFunc(
-		func(writer io.Writer) {
-			_, _ = io.WriteString(writer, strconv.Quote(value.String()))
+// CursorSigner signs cursors, so clients can only page with cursors the server handed out.
+// Cursors stay valid until the secret changes.
+type CursorSigner struct {
+	secret []byte
+}
+
+// fallbackCursors signs cursors when no CursorSigner is in the Context.
+var fallbackCursors = NewCursorSigner(nil) //nolint: gochecknoglobals
+
+// NewCursorSigner creates a new CursorSigner from a server-side secret.
+// An empty secret is replaced by a random one, so cursors only last until restart.
+func NewCursorSigner(secret []byte) *CursorSigner {
+	if len(secret) == 0 {
+		secret = make([]byte, randomSecretSize)
+		_, _ = rand.Read(secret)
+	}
+
+	return &CursorSigner{
+		secret: secret,
+	}
+}
+
+// CursorSignerToContext adds a CursorSigner to a Context.
+func CursorSignerToContext(ctx context.Context, signer *CursorSigner) context.Context {
+	return context.WithValue(ctx, cursorSignerKey, signer)
+}
+
+// CursorSignerFromContext returns the CursorSigner in a Context.
+// A signer with a random secret is used when there is none.
+func CursorSignerFromContext(ctx context.Context) *CursorSigner {
+	signer, ok := ctx.Value(cursorSignerKey).(*CursorSigner)
+	if !ok {
+		return fallbackCursors
+	}
+
+	return signer
+}
+
+// Encode returns the signed string form of a Cursor.
+func (s *CursorSigner) Encode(cursor Cursor) string {
+	payload, _ := json.Marshal(cursorPayload{
+		ID:    cursor.ID.String(),
+		Sort:  cursor.Sort,
+		Time:  cursor.Time,
+		Name:  cursor.Name,
+		Score: cursor.Score,
+	})
+
+	body := cursorVersion + cursorDelim + base64.RawURLEncoding.EncodeToString(payload)
+
+	return body + cursorDelim + base64.RawURLEncoding.EncodeToString(s.sign(body))
+}
+
+// Decode reads a Cursor from its signed string form.
+// Cursors of another version, or whose signature does not match, are rejected.
+func (s *CursorSigner) Decode(str string) (Cursor, error) {
+	split := strings.Split(str, cursorDelim)
+	if len(split) != cursorSplitCount {
+		return Cursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, str)
+	}
+
+	if split[0] != cursorVersion {
+		return Cursor{}, fmt.Errorf("%w: unsupported version %q", ErrInvalidCursor, split[0])
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(split[2])
+	if err != nil || !hmac.Equal(signature, s.sign(split[0]+cursorDelim+split[1])) {
+		return Cursor{}, fmt.Errorf("%w: bad signature", ErrInvalidCursor)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(split[1])
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(decoded, &payload); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if payload.ID == "" || !payload.Sort.IsValid() {
+		return Cursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, str)
+	}
+
+	return Cursor{
+		ID:    entity.NewID(payload.ID),
+		Sort:  payload.Sort,
+		Time:  payload.Time,
+		Name:  payload.Name,
+		Score: payload.Score,
+	}, nil
+}
+
+func (s *CursorSigner) sign(body string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = mac.Write([]byte(body))
+
+	return mac.Sum(nil)
+}
+
+// MarshalCursor marshals a Cursor to a GraphQL format, signed by the CursorSigner in the Context.
+func MarshalCursor(value Cursor) graphql.ContextMarshaler { //nolint: ireturn
+	return graphql.ContextWriterFunc(
+		func(ctx context.Context, writer io.Writer) error {
+			_, err := io.WriteString(writer, strconv.Quote(CursorSignerFromContext(ctx).Encode(value)))
+
+			return err //nolint: wrapcheck
 		},
 	)
 }
 
-// UnmarshalCursor unmarshals a value into a Cursor.
-func UnmarshalCursor(value any) (Cursor, error) {
+// UnmarshalCursor unmarshals a value into a Cursor, checking it against the CursorSigner in the Context.
+func UnmarshalCursor(ctx context.Context, value any) (Cursor, error) {
 	str, ok := value.(string)
 	if !ok {
-		return Cursor{}, nil
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, value)
 	}
 
-	decoded, err := base64.StdEncoding.DecodeString(str)
-	if err != nil {
-		return Cursor{}, nil //nolint: nilerr
-	}
+	return CursorSignerFromContext(ctx).Decode(str)
+}
 
-	split := strings.Split(string(decoded), delim)
-	if len(split) != cursorSplitCount {
-		return Cursor{}, nil
+// MarshalDate marshals a calendar date to a GraphQL format.
+func MarshalDate(value time.Time) graphql.Marshaler { //nolint: ireturn
+	return graphql.WriterFunc(
+		func(writer io.Writer) {
+			_, _ = io.WriteString(writer, strconv.Quote(value.Format(dateLayout)))
+		},
+	)
+}
+
+// UnmarshalDate unmarshals a YYYY-MM-DD value into a calendar date at midnight UTC.
+func UnmarshalDate(value any) (time.Time, error) {
+	str, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidDate, value)
 	}
 
-	result := Cursor{
-		ID:   entity.NewID(split[0]),
-		Sort: Sort(split[1]),
+	result, err := time.Parse(dateLayout, str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, str)
 	}
 
 	return result, nil
@@ -2,7 +2,15 @@ package model_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql/model"
@@ -25,24 +33,44 @@ func TestUnmarshalID(t *testing.T) {
 	type testCase struct {
 		value  any
 		result model.ID
+		err    error
 	}
 
 	tests := map[string]testCase{
 		"success": {
 			value:  `cmVjaXBlOnJlY2lwZS0xMjM0`,
 			result: model.NewRecipeID(entity.NewID("recipe-1234")),
+			err:    nil,
+		},
+		"url-safe": {
+			value:  `cmVjaXBlOlI_`,
+			result: model.NewRecipeID(entity.NewID("R?")),
+			err:    nil,
+		},
+		"standard encoding": {
+			value:  `cmVjaXBlOlI/`,
+			result: model.NewRecipeID(entity.NewID("R?")),
+			err:    nil,
 		},
 		"bad type": {
 			value:  43,
 			result: model.ID{},
+			err:    model.ErrInvalidID,
 		},
 		"bad encoding": {
 			value:  `i am not base 64`,
 			result: model.ID{},
+			err:    model.ErrInvalidID,
 		},
 		"bad format": {
 			value:  `YWJjZGVmZ2hpag==`,
 			result: model.ID{},
+			err:    model.ErrInvalidID,
+		},
+		"missing key": {
+			value:  base64.StdEncoding.EncodeToString([]byte("recipe:")),
+			result: model.ID{},
+			err:    model.ErrInvalidID,
 		},
 	}
 
@@ -51,7 +79,7 @@ func TestUnmarshalID(t *testing.T) {
 			result, err := model.UnmarshalID(test.value)
 
 			assert.Equal(t, test.result, result)
-			assert.NoError(t, err)
+			assert.ErrorIs(t, err, test.err)
 		})
 	}
 }
@@ -59,54 +87,165 @@ func TestUnmarshalID(t *testing.T) {
 func TestMarshalCursor(t *testing.T) {
 	t.Parallel()
 
+	ctx := model.CursorSignerToContext(context.Background(), model.NewCursorSigner([]byte("secret")))
 	result := new(bytes.Buffer)
 
 	marshaler := model.MarshalCursor(
 		model.Cursor{
 			ID:   entity.NewID("recipe-1234"),
 			Sort: model.SortName,
+			Name: "pancakes",
 		},
 	)
-	marshaler.MarshalGQL(result)
-	assert.Equal(t, `"cmVjaXBlLTEyMzQ6TkFNRQ=="`, result.String())
+	assert.NoError(t, marshaler.MarshalGQLContext(ctx, result))
+	assert.Equal(
+		t,
+		`"v1.eyJpZCI6InJlY2lwZS0xMjM0Iiwic29ydCI6Ik5BTUUiLCJ0aW1lIjoiMDAwMS0wMS0wMVQwMDowMDowMFoiLCJuYW1lIjoicGFuY2FrZXMifQ.`+
+			signature(`v1.eyJpZCI6InJlY2lwZS0xMjM0Iiwic29ydCI6Ik5BTUUiLCJ0aW1lIjoiMDAwMS0wMS0wMVQwMDowMDowMFoiLCJuYW1lIjoicGFuY2FrZXMifQ`)+`"`,
+		result.String(),
+	)
+}
+
+// signature signs a cursor body the way a CursorSigner with the secret "secret" does.
+func signature(body string) string {
+	mac := hmac.New(sha256.New, []byte("secret"))
+	_, _ = mac.Write([]byte(body))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
 }
 
 func TestUnmarshalCursor(t *testing.T) {
 	t.Parallel()
 
+	signer := model.NewCursorSigner([]byte("secret"))
+	ctx := model.CursorSignerToContext(context.Background(), signer)
+	cursor := model.Cursor{
+		ID:    entity.NewID("recipe-1234"),
+		Sort:  model.SortRelevance,
+		Time:  time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+		Name:  "",
+		Score: 1.25,
+	}
+	split := strings.Split(signer.Encode(cursor), ".")
+	modified := "v1." + base64.RawURLEncoding.EncodeToString([]byte(`{"id":"recipe-9999","sort":"RELEVANCE"}`))
+
 	type testCase struct {
 		value  any
 		result model.Cursor
+		err    error
 	}
 
 	tests := map[string]testCase{
 		"success": {
-			value: `cmVjaXBlLTEyMzQ6TkFNRQ==`,
-			result: model.Cursor{
-				ID:   entity.NewID("recipe-1234"),
-				Sort: model.SortName,
-			},
+			value:  signer.Encode(cursor),
+			result: cursor,
+			err:    nil,
+		},
+		"modified": {
+			value:  modified + "." + split[2],
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"modified and re-checksummed": {
+			value:  modified + "." + fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(modified))),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"another secret": {
+			value:  model.NewCursorSigner([]byte("another secret")).Encode(cursor),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"unsupported version": {
+			value:  "v0." + split[1] + "." + signature("v0."+split[1]),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
 		},
 		"bad type": {
 			value:  43,
 			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
 		},
 		"bad encoding": {
-			value:  `i am not base 64`,
+			value:  "v1.i am not base 64." + signature("v1.i am not base 64"),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"bad signature encoding": {
+			value:  "v1." + split[1] + ".not base 64",
 			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"bad payload": {
+			value:  "v1.bm9wZQ." + signature("v1.bm9wZQ"),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+		"unknown sort": {
+			value:  "v1.eyJpZCI6IjEiLCJzb3J0IjoiU0laRSJ9." + signature("v1.eyJpZCI6IjEiLCJzb3J0IjoiU0laRSJ9"),
+			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
 		},
 		"bad format": {
-			value:  `YWJjZGVmZ2hpag==`,
+			value:  `cmVjaXBlLTEyMzQ6TkFNRQ==`,
 			result: model.Cursor{},
+			err:    model.ErrInvalidCursor,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := model.UnmarshalCursor(ctx, test.value)
+
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestMarshalDate(t *testing.T) {
+	t.Parallel()
+
+	result := new(bytes.Buffer)
+
+	marshaler := model.MarshalDate(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	marshaler.MarshalGQL(result)
+	assert.Equal(t, `"2025-03-03"`, result.String())
+}
+
+func TestUnmarshalDate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		value  any
+		result time.Time
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			value:  "2025-03-03",
+			result: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+			err:    nil,
+		},
+		"bad type": {
+			value:  43,
+			result: time.Time{},
+			err:    model.ErrInvalidDate,
+		},
+		"bad format": {
+			value:  "03/03/2025",
+			result: time.Time{},
+			err:    model.ErrInvalidDate,
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			result, err := model.UnmarshalCursor(test.value)
+			result, err := model.UnmarshalDate(test.value)
 
 			assert.Equal(t, test.result, result)
-			assert.NoError(t, err)
+			assert.ErrorIs(t, err, test.err)
 		})
 	}
 }
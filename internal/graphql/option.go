@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+)
+
+// Option is a GraphQL API handler creation option.
+type Option func(g *GraphQL)
+
+// WithFetcher overrides how recipe imports fetch documents.
+func WithFetcher(fetcher importer.Fetcher) Option {
+	return func(g *GraphQL) {
+		g.fetcher = fetcher
+	}
+}
+
+// WithBroker sets where subscriptions listen for changes.
+// It should be the same Broker the command Service publishes to.
+func WithBroker(broker pubsub.Broker) Option {
+	return func(g *GraphQL) {
+		g.broker = broker
+	}
+}
+
+// WithFeedSigner sets how meal plan feed links are signed.
+// It should be the same Signer the calendar Feed verifies with.
+func WithFeedSigner(signer *calendar.Signer) Option {
+	return func(g *GraphQL) {
+		g.feeds = signer
+	}
+}
+
+// WithCursorSecret sets the secret page cursors are signed with.
+// Cursors handed out before the secret changes are rejected.
+func WithCursorSecret(secret []byte) Option {
+	return func(g *GraphQL) {
+		g.cursors = model.NewCursorSigner(secret)
+	}
+}
+
+// WithAuthenticator sets how WebSocket connections log in.
+// Plain HTTP requests are expected to be authenticated before they reach the handler.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(g *GraphQL) {
+		g.authenticator = authenticator
+	}
+}
+
+// WithMaxDepth limits how deeply fields can be nested in an operation.
+// Zero removes the limit.
+func WithMaxDepth(depth int) Option {
+	return func(g *GraphQL) {
+		g.limits.maxDepth = depth
+	}
+}
+
+// WithMaxComplexity limits the total cost of the fields in an operation.
+// Zero removes the limit.
+func WithMaxComplexity(complexity int) Option {
+	return func(g *GraphQL) {
+		g.limits.maxComplexity = complexity
+	}
+}
+
+// WithMaxPageSize limits how many items can be asked for in a single page.
+// Zero removes the limit.
+func WithMaxPageSize(size int) Option {
+	return func(g *GraphQL) {
+		g.limits.maxPageSize = size
+	}
+}
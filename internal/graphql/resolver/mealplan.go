@@ -0,0 +1,203 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.83
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+)
+
+// Recipe is the resolver for the recipe field.
+func (r *mealResolver) Recipe(ctx context.Context, obj *model.Meal) (model.RecipeResult, error) {
+	result, err := dataloader.GetRecipe(ctx, obj.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreatedBy is the resolver for the createdBy field.
+func (r *mealResolver) CreatedBy(ctx context.Context, obj *model.Meal) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.CreatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdatedBy is the resolver for the updatedBy field.
+func (r *mealResolver) UpdatedBy(ctx context.Context, obj *model.Meal) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.UpdatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateMeal is the resolver for the createMeal field.
+func (r *mutationResolver) CreateMeal(ctx context.Context, input model.CreateMealInput) (model.CreateMealResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.commands.CreateMeal(
+		ctx, userID, input.Date, model.NewRecipeKey(input.Recipe), model.NewCreateMealOptions(input)...,
+	)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetMeal(ctx, userID, created.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMeal(result), nil
+}
+
+// UpdateMeal is the resolver for the updateMeal field.
+func (r *mutationResolver) UpdateMeal(ctx context.Context, id model.ID, input model.UpdateMealInput) (model.UpdateMealResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.MealKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	_, err = r.commands.UpdateMeal(ctx, userID, id.Key, model.NewUpdateMealOptions(input)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetMeal(ctx, userID, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMeal(result), nil
+}
+
+// DeleteMeal is the resolver for the deleteMeal field.
+func (r *mutationResolver) DeleteMeal(ctx context.Context, id model.ID) (model.DeleteMealResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.MealKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.DeleteMeal(ctx, userID, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.DeletedMeal{ID: id}, nil
+}
+
+// Meal is the resolver for the meal field.
+func (r *queryResolver) Meal(ctx context.Context, id model.ID) (model.MealResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.MealKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	result, err := r.queries.GetMeal(ctx, userID, id.Key)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.NewMeal(result), nil
+}
+
+// MealPlan is the resolver for the mealPlan field.
+func (r *queryResolver) MealPlan(ctx context.Context, from time.Time, days int) ([]*model.Meal, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.queries.FindMeals(ctx, userID, from, from.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMeals(found), nil
+}
+
+// MealPlanIngredients is the resolver for the mealPlanIngredients field.
+func (r *queryResolver) MealPlanIngredients(ctx context.Context, from time.Time, days int) ([]*model.Ingredient, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.PlanMealIngredients(ctx, userID, from, from.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewIngredients(result), nil
+}
+
+// MealPlanFeed is the resolver for the mealPlanFeed field.
+func (r *queryResolver) MealPlanFeed(ctx context.Context) (string, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return r.feeds.Link(userID), nil
+}
+
+// Meal returns MealResolver implementation.
+func (r *Resolver) Meal() MealResolver { return &mealResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mealResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,386 @@
+package resolver_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/metrics"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryMeal(t *testing.T) {
+	t.Parallel()
+
+	mealID := model.NewMealID(entity.NewID("M1")).String()
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	feeds := calendar.NewSigner([]byte("secret"))
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("R1"),
+				Name:        "pancakes",
+				NumServings: 2,
+				Ingredients: []query.Ingredient{{Name: "egg", Quantity: 1}},
+			},
+		},
+	}
+
+	type testCase struct {
+		meals    query.MealRepository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			meals: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{
+					{ID: entity.NewID("M1"), Date: monday, Slot: "lunch", RecipeID: entity.NewID("R1"), Servings: 4},
+				},
+			},
+			options: []client.Option{client.Var("id", mealID)},
+			query:   `query test($id: ID!){ meal(id: $id){ __typename ...on Meal { date slot servings recipe { ...on Recipe { name }}}}}`,
+			response: map[string]any{
+				"meal": map[string]any{
+					"__typename": "Meal",
+					"date":       "2025-03-03",
+					"slot":       "LUNCH",
+					"servings":   float64(4),
+					"recipe":     map[string]any{"name": "pancakes"},
+				},
+			},
+			err: nil,
+		},
+		"node": {
+			meals: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{{ID: entity.NewID("M1")}},
+			},
+			options: []client.Option{client.Var("id", mealID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "Meal",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			meals:   &mock.QueryMealRepository{},
+			options: []client.Option{client.Var("id", mealID)},
+			query:   `query test($id: ID!){ meal(id: $id){ __typename }}`,
+			response: map[string]any{
+				"meal": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"another user": {
+			meals: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{{ID: entity.NewID("M1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			options: []client.Option{client.Var("id", mealID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			meals: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{{ID: entity.NewID("M1")}},
+			},
+			options: []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("M1")).String())},
+			query:   `query test($id: ID!){ meal(id: $id){ __typename }}`,
+			response: map[string]any{
+				"meal": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"meal plan": {
+			meals: &mock.QueryMealRepository{
+				FindMealsResult: []*query.Meal{
+					{ID: entity.NewID("M1"), Date: monday, Slot: "dinner", RecipeID: entity.NewID("R1")},
+				},
+			},
+			query: `query { mealPlan(from: "2025-03-03"){ date slot servings }}`,
+			response: map[string]any{
+				"mealPlan": []any{
+					map[string]any{"date": "2025-03-03", "slot": "DINNER", "servings": nil},
+				},
+			},
+			err: nil,
+		},
+		"meal plan ingredients": {
+			meals: &mock.QueryMealRepository{
+				FindMealsResult: []*query.Meal{
+					{ID: entity.NewID("M1"), Date: monday, RecipeID: entity.NewID("R1"), Servings: 4},
+					{ID: entity.NewID("M2"), Date: monday.AddDate(0, 0, 1), RecipeID: entity.NewID("R1")},
+				},
+			},
+			query: `query { mealPlanIngredients(from: "2025-03-03", days: 7){ name quantity }}`,
+			response: map[string]any{
+				"mealPlanIngredients": []any{
+					map[string]any{"name": "egg", "quantity": float64(3)},
+				},
+			},
+			err: nil,
+		},
+		"invalid date": {
+			meals:    &mock.QueryMealRepository{},
+			query:    `query { mealPlan(from: "next week"){ date }}`,
+			response: nil,
+			err:      errors.New("invalid date"),
+		},
+		"meal plan feed": {
+			meals: &mock.QueryMealRepository{},
+			query: `query { mealPlanFeed }`,
+			response: map[string]any{
+				"mealPlanFeed": feeds.Link(entity.NewID("")),
+			},
+			err: nil,
+		},
+		"repo error": {
+			meals:    &mock.QueryMealRepository{GetMealsErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("id", mealID)},
+			query:    `query test($id: ID!){ meal(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					recipes,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					test.meals,
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+				graphql.WithFeedSigner(feeds),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationMeal(t *testing.T) {
+	t.Parallel()
+
+	mealID := model.NewMealID(entity.NewID("M1")).String()
+	recipeID := model.NewRecipeID(entity.NewID("R1")).String()
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	existing, err := mealplan.New(entity.NewID("M1"), monday, entity.NewID("R1"), time.Now(), entity.NewID("user-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	another, err := mealplan.New(entity.NewID("M1"), monday, entity.NewID("R1"), time.Now(), entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		queries  query.MealRepository
+		commands mealplan.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"create": {
+			queries: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{
+					{ID: entity.NewID("M1"), Date: monday, Slot: "breakfast", CreatedBy: entity.NewID("user-1")},
+				},
+			},
+			commands: &mock.MealRepository{},
+			options: []client.Option{
+				client.Var("input", map[string]any{"date": "2025-03-03", "slot": "BREAKFAST", "recipe": recipeID}),
+			},
+			query: `mutation test($input: CreateMealInput!){ createMeal(input: $input) { __typename ...on Meal { id date slot }}}`,
+			response: map[string]any{
+				"createMeal": map[string]any{
+					"__typename": "Meal",
+					"id":         mealID,
+					"date":       "2025-03-03",
+					"slot":       "BREAKFAST",
+				},
+			},
+			err: nil,
+		},
+		"create validation error": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{},
+			options: []client.Option{
+				client.Var("input", map[string]any{"date": "2025-03-03", "recipe": mealID, "servings": -1}),
+			},
+			query: `mutation test($input: CreateMealInput!){ createMeal(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createMeal": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "recipe", "message": "meal recipe cannot be empty"},
+						map[string]any{"field": "servings", "message": "meal servings cannot be negative"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"update": {
+			queries: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{
+					{ID: entity.NewID("M1"), Date: monday, Slot: "dinner", Servings: 6, CreatedBy: entity.NewID("user-1")},
+				},
+			},
+			commands: &mock.MealRepository{GetMealResult: existing},
+			options: []client.Option{
+				client.Var("id", mealID),
+				client.Var("input", map[string]any{"servings": 6}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateMealInput!){ updateMeal(id: $id, input: $input) { __typename ...on Meal { servings }}}`,
+			response: map[string]any{
+				"updateMeal": map[string]any{
+					"__typename": "Meal",
+					"servings":   float64(6),
+				},
+			},
+			err: nil,
+		},
+		"update not found": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{GetMealErr: entity.ErrNotFound},
+			options: []client.Option{
+				client.Var("id", mealID),
+				client.Var("input", map[string]any{"servings": 2}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateMealInput!){ updateMeal(id: $id, input: $input) { __typename }}`,
+			response: map[string]any{
+				"updateMeal": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"delete": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{GetMealResult: existing},
+			options:  []client.Option{client.Var("id", mealID)},
+			query:    `mutation test($id: ID!){ deleteMeal(id: $id) { __typename ...on DeletedMeal { id }}}`,
+			response: map[string]any{
+				"deleteMeal": map[string]any{
+					"__typename": "DeletedMeal",
+					"id":         mealID,
+				},
+			},
+			err: nil,
+		},
+		"delete another user": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{GetMealResult: another},
+			options:  []client.Option{client.Var("id", mealID)},
+			query:    `mutation test($id: ID!){ deleteMeal(id: $id) { __typename }}`,
+			response: nil,
+			err:      entity.ErrForbidden,
+		},
+		"delete wrong kind": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{},
+			options:  []client.Option{client.Var("id", recipeID)},
+			query:    `mutation test($id: ID!){ deleteMeal(id: $id) { __typename }}`,
+			response: map[string]any{
+				"deleteMeal": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			queries:  &mock.QueryMealRepository{},
+			commands: &mock.MealRepository{GetMealResult: existing, DeleteMealErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("id", mealID)},
+			query:    `mutation test($id: ID!){ deleteMeal(id: $id) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					test.queries,
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					test.commands,
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser("user-1"))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
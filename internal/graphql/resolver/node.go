@@ -51,12 +51,58 @@ func (r *queryResolver) Node(ctx context.Context, id model.ID) (model.Node, erro
 
 		return model.NewUser(found[0]), nil
 
+	case model.ShoppingListKind:
+		userID, err := currentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := r.queries.GetShoppingList(ctx, userID, id.Key)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotFound) {
+				return model.NotFoundError{ID: id}, nil
+			}
+
+			return nil, err
+		}
+
+		return model.NewShoppingList(found), nil
+
+	case model.PantryItemKind:
+		userID, err := currentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := r.queries.GetPantryItem(ctx, userID, id.Key)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotFound) {
+				return model.NotFoundError{ID: id}, nil
+			}
+
+			return nil, err
+		}
+
+		return model.NewPantryItem(found), nil
+
+	case model.MealKind:
+		userID, err := currentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := r.queries.GetMeal(ctx, userID, id.Key)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotFound) {
+				return model.NotFoundError{ID: id}, nil
+			}
+
+			return nil, err
+		}
+
+		return model.NewMeal(found), nil
+
 	default:
 		return model.NotFoundError{ID: id}, nil
 	}
 }
-
-// Query returns QueryResolver implementation.
-func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
-
-type queryResolver struct{ *Resolver }
@@ -3,13 +3,16 @@ package resolver_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql"
 	"github.com/b-sea/supply-run-api/internal/graphql/model"
 	"github.com/b-sea/supply-run-api/internal/metrics"
 	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pantry"
 	"github.com/b-sea/supply-run-api/internal/query"
 	"github.com/stretchr/testify/assert"
 )
@@ -169,7 +172,22 @@ func TestQueryNode(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			server := graphql.New(
-				query.NewService(test.recipe, test.unit, test.user),
+				query.NewService(
+					test.recipe,
+					test.unit,
+					test.user,
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
 				metrics.NewNoOp(),
 			)
 			testClient := client.New(server)
@@ -187,3 +205,125 @@ func TestQueryNode(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipe  query.RecipeRepository
+		pantry  pantry.Repository
+		options []client.Option
+		query   string
+		code    string
+	}
+
+	another, err := pantry.New(entity.NewID("P1"), "milk", time.Now(), entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursors := model.NewCursorSigner([]byte("secret"))
+
+	tests := map[string]testCase{
+		"invalid id": {
+			recipe:  &mock.QueryRecipeRepository{},
+			pantry:  &mock.PantryRepository{},
+			options: []client.Option{client.Var("id", "i am not an id")},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			code:    "INVALID_ID",
+		},
+		"invalid cursor": {
+			recipe:  &mock.QueryRecipeRepository{},
+			pantry:  &mock.PantryRepository{},
+			options: []client.Option{client.Var("after", "v1.e30.00000000")},
+			query:   `query test($after: Cursor){ findRecipes(page: {first: 10, after: $after}) { edges { cursor }}}`,
+			code:    "INVALID_CURSOR",
+		},
+		"cursor for another sort": {
+			recipe: &mock.QueryRecipeRepository{},
+			pantry: &mock.PantryRepository{},
+			options: []client.Option{
+				client.Var("after", cursors.Encode(model.Cursor{ID: entity.NewID("1"), Sort: model.SortName, Name: "pie"})),
+			},
+			query: `query test($after: Cursor){ findRecipes(page: {first: 10, after: $after}) { edges { cursor }}}`,
+			code:  "INVALID_CURSOR",
+		},
+		"forged cursor": {
+			recipe: &mock.QueryRecipeRepository{},
+			pantry: &mock.PantryRepository{},
+			options: []client.Option{
+				client.Var(
+					"after",
+					model.NewCursorSigner([]byte("forged")).Encode(model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated}),
+				),
+			},
+			query: `query test($after: Cursor){ findRecipes(page: {first: 10, after: $after}) { edges { cursor }}}`,
+			code:  "INVALID_CURSOR",
+		},
+		"internal": {
+			recipe: &mock.QueryRecipeRepository{
+				GetRecipesErr: errors.New("some random error"),
+			},
+			pantry:  &mock.PantryRepository{},
+			options: []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("1")).String())},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			code:    "INTERNAL",
+		},
+		"unauthenticated": {
+			recipe:  &mock.QueryRecipeRepository{},
+			pantry:  &mock.PantryRepository{},
+			options: nil,
+			query:   `query { shoppingLists { name }}`,
+			code:    "UNAUTHENTICATED",
+		},
+		"unauthenticated mutation": {
+			recipe:  &mock.QueryRecipeRepository{},
+			pantry:  &mock.PantryRepository{},
+			options: []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("1")).String())},
+			query:   `mutation test($id: ID!){ deleteRecipe(id: $id){ __typename }}`,
+			code:    "UNAUTHENTICATED",
+		},
+		"forbidden": {
+			recipe: &mock.QueryRecipeRepository{},
+			pantry: &mock.PantryRepository{GetPantryItemResult: another},
+			options: []client.Option{
+				asUser("user-1"),
+				client.Var("id", model.NewPantryItemID(entity.NewID("P1")).String()),
+			},
+			query: `mutation test($id: ID!){ deletePantryItem(id: $id){ __typename }}`,
+			code:  "FORBIDDEN",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.recipe,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					test.pantry,
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+				graphql.WithCursorSecret([]byte("secret")),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.ErrorContains(t, err, `"code":"`+test.code+`"`)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.83
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+)
+
+// CreatePantryItem is the resolver for the createPantryItem field.
+func (r *mutationResolver) CreatePantryItem(ctx context.Context, input model.CreatePantryItemInput) (model.CreatePantryItemResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.commands.CreatePantryItem(
+		ctx, userID, input.Name, model.NewCreatePantryItemOptions(input)...,
+	)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetPantryItem(ctx, userID, created.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPantryItem(result), nil
+}
+
+// UpdatePantryItem is the resolver for the updatePantryItem field.
+func (r *mutationResolver) UpdatePantryItem(ctx context.Context, id model.ID, input model.UpdatePantryItemInput) (model.UpdatePantryItemResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.PantryItemKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	_, err = r.commands.UpdatePantryItem(ctx, userID, id.Key, model.NewUpdatePantryItemOptions(input)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetPantryItem(ctx, userID, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPantryItem(result), nil
+}
+
+// DeletePantryItem is the resolver for the deletePantryItem field.
+func (r *mutationResolver) DeletePantryItem(ctx context.Context, id model.ID) (model.DeletePantryItemResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.PantryItemKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.DeletePantryItem(ctx, userID, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.DeletedPantryItem{ID: id}, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *pantryItemResolver) Unit(ctx context.Context, obj *model.PantryItem) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreatedBy is the resolver for the createdBy field.
+func (r *pantryItemResolver) CreatedBy(ctx context.Context, obj *model.PantryItem) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.CreatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdatedBy is the resolver for the updatedBy field.
+func (r *pantryItemResolver) UpdatedBy(ctx context.Context, obj *model.PantryItem) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.UpdatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PantryItem is the resolver for the pantryItem field.
+func (r *queryResolver) PantryItem(ctx context.Context, id model.ID) (model.PantryItemResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.PantryItemKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	result, err := r.queries.GetPantryItem(ctx, userID, id.Key)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.NewPantryItem(result), nil
+}
+
+// Pantry is the resolver for the pantry field.
+func (r *queryResolver) Pantry(ctx context.Context, filter *model.PantryFilter) ([]*model.PantryItem, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.queries.FindPantryItems(ctx, userID, model.NewQueryPantryFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPantryItems(found), nil
+}
+
+// ExpiringSoon is the resolver for the expiringSoon field.
+func (r *queryResolver) ExpiringSoon(ctx context.Context, days int) ([]*model.PantryItem, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.queries.ExpiringPantryItems(ctx, userID, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPantryItems(found), nil
+}
+
+// StillNeeded is the resolver for the stillNeeded field.
+func (r *queryResolver) StillNeeded(ctx context.Context, recipes []*model.RecipeServingsInput) (model.StillNeededResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.StillNeeded(ctx, userID, model.NewQueryRecipeServings(recipes))
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	return model.NeededIngredients{Ingredients: model.NewIngredients(result)}, nil
+}
+
+// PantryItem returns PantryItemResolver implementation.
+func (r *Resolver) PantryItem() PantryItemResolver { return &pantryItemResolver{r} }
+
+type pantryItemResolver struct{ *Resolver }
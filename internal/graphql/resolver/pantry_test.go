@@ -0,0 +1,455 @@
+package resolver_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/metrics"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryPantryItem(t *testing.T) {
+	t.Parallel()
+
+	itemID := model.NewPantryItemID(entity.NewID("P1")).String()
+	expiry := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		pantry   query.PantryRepository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			pantry: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{
+					{ID: entity.NewID("P1"), Name: "milk", Quantity: amount(1), ExpiresAt: &expiry},
+				},
+			},
+			options: []client.Option{client.Var("id", itemID)},
+			query:   `query test($id: ID!){ pantryItem(id: $id){ __typename ...on PantryItem { name quantity expiresAt }}}`,
+			response: map[string]any{
+				"pantryItem": map[string]any{
+					"__typename": "PantryItem",
+					"name":       "milk",
+					"quantity":   float64(1),
+					"expiresAt":  "2025-01-01T00:00:00Z",
+				},
+			},
+			err: nil,
+		},
+		"node": {
+			pantry: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1")}},
+			},
+			options: []client.Option{client.Var("id", itemID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "PantryItem",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			pantry:  &mock.QueryPantryRepository{},
+			options: []client.Option{client.Var("id", itemID)},
+			query:   `query test($id: ID!){ pantryItem(id: $id){ __typename }}`,
+			response: map[string]any{
+				"pantryItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"another user": {
+			pantry: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			options: []client.Option{client.Var("id", itemID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			pantry: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1")}},
+			},
+			options: []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("P1")).String())},
+			query:   `query test($id: ID!){ pantryItem(id: $id){ __typename }}`,
+			response: map[string]any{
+				"pantryItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"expiring soon": {
+			pantry: &mock.QueryPantryRepository{
+				FindPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1"), Name: "milk", ExpiresAt: &expiry}},
+			},
+			query: `query { expiringSoon(days: 3){ name expiresAt }}`,
+			response: map[string]any{
+				"expiringSoon": []any{
+					map[string]any{"name": "milk", "expiresAt": "2025-01-01T00:00:00Z"},
+				},
+			},
+			err: nil,
+		},
+		"pantry": {
+			pantry: &mock.QueryPantryRepository{
+				FindPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1"), Name: "milk"}},
+			},
+			query: `query { pantry(filter: {name: "mi"}){ name expiresAt }}`,
+			response: map[string]any{
+				"pantry": []any{
+					map[string]any{"name": "milk", "expiresAt": nil},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			pantry:   &mock.QueryPantryRepository{GetPantryItemsErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("id", itemID)},
+			query:    `query test($id: ID!){ pantryItem(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					test.pantry,
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryStillNeeded(t *testing.T) {
+	t.Parallel()
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("R1"),
+				NumServings: 2,
+				Ingredients: []query.Ingredient{
+					{Name: "egg", Quantity: 3},
+					{Name: "milk", Quantity: 1},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		recipes  query.RecipeRepository
+		pantry   query.PantryRepository
+		options  []client.Option
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"needed": {
+			recipes: recipes,
+			pantry: &mock.QueryPantryRepository{
+				FindPantryItemsResult: []*query.PantryItem{
+					{Name: "egg", Quantity: amount(2)},
+					{Name: "milk", Quantity: amount(4)},
+				},
+			},
+			options: []client.Option{client.Var("recipes", []any{
+				map[string]any{"recipe": model.NewRecipeID(entity.NewID("R1")).String(), "servings": 4},
+			})},
+			response: map[string]any{
+				"stillNeeded": map[string]any{
+					"__typename": "NeededIngredients",
+					"ingredients": []any{
+						map[string]any{"name": "egg", "quantity": float64(4)},
+					},
+				},
+			},
+			err: nil,
+		},
+		"missing recipe": {
+			recipes: &mock.QueryRecipeRepository{},
+			pantry:  &mock.QueryPantryRepository{},
+			options: []client.Option{client.Var("recipes", []any{
+				map[string]any{"recipe": model.NewRecipeID(entity.NewID("R1")).String()},
+			})},
+			response: map[string]any{
+				"stillNeeded": map[string]any{
+					"__typename": "ValidationError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			recipes: recipes,
+			pantry:  &mock.QueryPantryRepository{FindPantryItemsErr: errors.New("some random error")},
+			options: []client.Option{client.Var("recipes", []any{
+				map[string]any{"recipe": model.NewRecipeID(entity.NewID("R1")).String()},
+			})},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.recipes,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					test.pantry,
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($recipes: [RecipeServingsInput!]!){ stillNeeded(recipes: $recipes){ __typename ...on NeededIngredients { ingredients { name quantity }}}}`,
+				&response,
+				test.options...,
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationPantryItem(t *testing.T) {
+	t.Parallel()
+
+	itemID := model.NewPantryItemID(entity.NewID("P1")).String()
+
+	existing, err := pantry.New(entity.NewID("P1"), "milk", time.Now(), entity.NewID("user-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	another, err := pantry.New(entity.NewID("P1"), "milk", time.Now(), entity.NewID("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		queries  query.PantryRepository
+		commands pantry.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"create": {
+			queries: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1"), Name: "milk", Quantity: amount(1), CreatedBy: entity.NewID("user-1")}},
+			},
+			commands: &mock.PantryRepository{},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "milk", "quantity": 1})},
+			query:    `mutation test($input: CreatePantryItemInput!){ createPantryItem(input: $input) { __typename ...on PantryItem { id name }}}`,
+			response: map[string]any{
+				"createPantryItem": map[string]any{
+					"__typename": "PantryItem",
+					"id":         itemID,
+					"name":       "milk",
+				},
+			},
+			err: nil,
+		},
+		"create validation error": {
+			queries:  &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "", "quantity": -1})},
+			query:    `mutation test($input: CreatePantryItemInput!){ createPantryItem(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createPantryItem": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "name", "message": "pantry item name cannot be empty"},
+						map[string]any{"field": "quantity", "message": "pantry item quantity cannot be negative"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"update": {
+			queries: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("P1"), Name: "milk", Quantity: amount(2), CreatedBy: entity.NewID("user-1")}},
+			},
+			commands: &mock.PantryRepository{GetPantryItemResult: existing},
+			options: []client.Option{
+				client.Var("id", itemID),
+				client.Var("input", map[string]any{"quantity": 2, "expiresAt": nil}),
+			},
+			query: `mutation test($id: ID!, $input: UpdatePantryItemInput!){ updatePantryItem(id: $id, input: $input) { __typename ...on PantryItem { quantity }}}`,
+			response: map[string]any{
+				"updatePantryItem": map[string]any{
+					"__typename": "PantryItem",
+					"quantity":   float64(2),
+				},
+			},
+			err: nil,
+		},
+		"update not found": {
+			queries:  &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{GetPantryItemErr: entity.ErrNotFound},
+			options: []client.Option{
+				client.Var("id", itemID),
+				client.Var("input", map[string]any{"quantity": 2}),
+			},
+			query: `mutation test($id: ID!, $input: UpdatePantryItemInput!){ updatePantryItem(id: $id, input: $input) { __typename }}`,
+			response: map[string]any{
+				"updatePantryItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"delete": {
+			queries:  &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{GetPantryItemResult: existing},
+			options:  []client.Option{client.Var("id", itemID)},
+			query:    `mutation test($id: ID!){ deletePantryItem(id: $id) { __typename ...on DeletedPantryItem { id }}}`,
+			response: map[string]any{
+				"deletePantryItem": map[string]any{
+					"__typename": "DeletedPantryItem",
+					"id":         itemID,
+				},
+			},
+			err: nil,
+		},
+		"delete another user": {
+			queries:  &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{GetPantryItemResult: another},
+			options:  []client.Option{client.Var("id", itemID)},
+			query:    `mutation test($id: ID!){ deletePantryItem(id: $id) { __typename }}`,
+			response: nil,
+			err:      entity.ErrForbidden,
+		},
+		"delete wrong kind": {
+			queries:  &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{},
+			options:  []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("P1")).String())},
+			query:    `mutation test($id: ID!){ deletePantryItem(id: $id) { __typename }}`,
+			response: map[string]any{
+				"deletePantryItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			queries: &mock.QueryPantryRepository{},
+			commands: &mock.PantryRepository{
+				GetPantryItemResult: existing,
+				DeletePantryItemErr: errors.New("some random error"),
+			},
+			options:  []client.Option{client.Var("id", itemID)},
+			query:    `mutation test($id: ID!){ deletePantryItem(id: $id) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					test.queries,
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					test.commands,
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser("user-1"))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
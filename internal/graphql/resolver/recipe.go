@@ -11,6 +11,7 @@ import (
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
 	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
 )
 
 // Unit is the resolver for the unit field.
@@ -23,19 +24,233 @@ func (r *ingredientResolver) Unit(ctx context.Context, obj *model.Ingredient) (m
 	return result, nil
 }
 
+// CreateRecipe is the resolver for the createRecipe field.
+func (r *mutationResolver) CreateRecipe(ctx context.Context, input model.CreateRecipeInput) (model.CreateRecipeResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err := r.ingredientInputs(ctx, input.Ingredients, input.IngredientLines)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Ingredients = ingredients
+
+	created, err := r.commands.CreateRecipe(ctx, userID, input.Name, model.NewCreateRecipeOptions(input)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetRecipe(ctx, created.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipe(result), nil
+}
+
+// UpdateRecipe is the resolver for the updateRecipe field.
+func (r *mutationResolver) UpdateRecipe(ctx context.Context, id model.ID, input model.UpdateRecipeInput) (model.UpdateRecipeResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.RecipeKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	ingredients, err := r.ingredientInputs(ctx, input.Ingredients, input.IngredientLines)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Ingredients = ingredients
+
+	_, err = r.commands.UpdateRecipe(ctx, userID, id.Key, model.NewUpdateRecipeOptions(input)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetRecipe(ctx, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipe(result), nil
+}
+
+// DeleteRecipe is the resolver for the deleteRecipe field.
+func (r *mutationResolver) DeleteRecipe(ctx context.Context, id model.ID) (model.DeleteRecipeResult, error) {
+	if _, err := currentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.RecipeKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.DeleteRecipe(ctx, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.DeletedRecipe{ID: id}, nil
+}
+
+// FavoriteRecipe is the resolver for the favoriteRecipe field.
+func (r *mutationResolver) FavoriteRecipe(ctx context.Context, id model.ID) (model.FavoriteRecipeResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.RecipeKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.FavoriteRecipe(ctx, userID, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetRecipe(ctx, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipe(result), nil
+}
+
+// UnfavoriteRecipe is the resolver for the unfavoriteRecipe field.
+func (r *mutationResolver) UnfavoriteRecipe(ctx context.Context, id model.ID) (model.FavoriteRecipeResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.RecipeKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.UnfavoriteRecipe(ctx, userID, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetRecipe(ctx, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipe(result), nil
+}
+
+// ImportRecipe is the resolver for the importRecipe field.
+func (r *mutationResolver) ImportRecipe(ctx context.Context, input model.ImportRecipeInput) (model.ImportRecipeResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	source, document := model.NewImportSource(input)
+
+	imported, err := r.imports.Import(ctx, source, document)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	lines, err := r.queries.ParseIngredients(ctx, imported.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Preview {
+		return model.NewRecipePreview(imported, lines), nil
+	}
+
+	created, err := r.commands.CreateRecipe(ctx, userID, imported.Name, imported.Options(lines)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetRecipe(ctx, created.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipe(result), nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *parsedIngredientResolver) Unit(ctx context.Context, obj *model.ParsedIngredient) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // FindRecipes is the resolver for the findRecipes field.
-func (r *queryResolver) FindRecipes(ctx context.Context, filter *model.RecipeFilter, page *model.Page, order *model.Order) (*model.RecipeConnection, error) {
+func (r *queryResolver) FindRecipes(ctx context.Context, search *string, filter *model.RecipeFilter, page *model.Page, order *model.Order) (*model.RecipeConnection, error) {
+	queryFilter := model.NewQueryRecipeFilter(filter, viewer(ctx))
+	queryFilter.Search = search
+
+	pagination, err := model.NewQueryPagination(page)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.queries.FindRecipes(
 		ctx,
-		model.NewQueryRecipeFilter(filter),
-		model.NewQueryPagination(page),
+		queryFilter,
+		pagination,
 		model.NewQueryOrder(order),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return model.NewRecipeConnection(result), nil
+	connection := model.NewRecipeConnection(result)
+	connection.Filter = queryFilter
+
+	return connection, nil
 }
 
 // Recipe is the resolver for the recipe field.
@@ -66,6 +281,55 @@ func (r *queryResolver) FindTags(ctx context.Context, filter *string) ([]string,
 	return result, nil
 }
 
+// ParseIngredients is the resolver for the parseIngredients field.
+func (r *queryResolver) ParseIngredients(ctx context.Context, lines []string) ([]*model.ParsedIngredient, error) {
+	result, err := r.queries.ParseIngredients(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewParsedIngredients(result), nil
+}
+
+// WhatCanICook is the resolver for the whatCanICook field.
+func (r *queryResolver) WhatCanICook(ctx context.Context, ingredients []string, maxMissing *int, first *int) ([]*model.CookableRecipe, error) {
+	limit, err := model.NewResultLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CookableRecipes(ctx, ingredients, maxMissing, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCookableRecipes(result), nil
+}
+
+// Ingredients is the resolver for the ingredients field.
+func (r *recipeResolver) Ingredients(ctx context.Context, obj *model.Recipe, servings *int) ([]*model.Ingredient, error) {
+	if servings == nil {
+		return obj.Ingredients, nil
+	}
+
+	result, err := r.queries.ScaleIngredients(ctx, model.NewQueryIngredients(obj.Ingredients), obj.NumServings, *servings)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewIngredients(result), nil
+}
+
+// IsFavorite is the resolver for the isFavorite field.
+func (r *recipeResolver) IsFavorite(ctx context.Context, obj *model.Recipe) (bool, error) {
+	result, err := dataloader.IsFavorite(ctx, viewer(ctx), obj.ID.Key)
+	if err != nil {
+		return false, err
+	}
+
+	return result, nil
+}
+
 // CreatedBy is the resolver for the createdBy field.
 func (r *recipeResolver) CreatedBy(ctx context.Context, obj *model.Recipe) (model.UserResult, error) {
 	result, err := dataloader.GetUser(ctx, obj.CreatedByID)
@@ -86,11 +350,55 @@ func (r *recipeResolver) UpdatedBy(ctx context.Context, obj *model.Recipe) (mode
 	return result, nil
 }
 
+// TotalCount is the resolver for the totalCount field.
+func (r *recipeConnectionResolver) TotalCount(ctx context.Context, obj *model.RecipeConnection) (int, error) {
+	return r.queries.CountRecipes(ctx, obj.Filter)
+}
+
+// RecipeChanged is the resolver for the recipeChanged field.
+func (r *subscriptionResolver) RecipeChanged(ctx context.Context, id model.ID) (<-chan model.RecipeChangedResult, error) {
+	if id.Kind != model.RecipeKind {
+		return nil, entity.ErrNotFound
+	}
+
+	if _, err := r.queries.GetRecipe(ctx, id.Key); err != nil {
+		return nil, err
+	}
+
+	filter := model.NewQueryRecipeFilter(nil, viewer(ctx))
+	filter.IDs = []entity.ID{id.Key}
+
+	return subscribe(ctx, r.broker, pubsub.RecipeTopic, func(event pubsub.Event) (model.RecipeChangedResult, bool) {
+		return r.recipeChange(ctx, filter, event)
+	}), nil
+}
+
+// RecipesChanged is the resolver for the recipesChanged field.
+func (r *subscriptionResolver) RecipesChanged(ctx context.Context, filter *model.RecipeFilter) (<-chan model.RecipeChangedResult, error) {
+	queryFilter := model.NewQueryRecipeFilter(filter, viewer(ctx))
+
+	return subscribe(ctx, r.broker, pubsub.RecipeTopic, func(event pubsub.Event) (model.RecipeChangedResult, bool) {
+		return r.recipeChange(ctx, queryFilter, event)
+	}), nil
+}
+
 // Ingredient returns IngredientResolver implementation.
 func (r *Resolver) Ingredient() IngredientResolver { return &ingredientResolver{r} }
 
+// ParsedIngredient returns ParsedIngredientResolver implementation.
+func (r *Resolver) ParsedIngredient() ParsedIngredientResolver { return &parsedIngredientResolver{r} }
+
 // Recipe returns RecipeResolver implementation.
 func (r *Resolver) Recipe() RecipeResolver { return &recipeResolver{r} }
 
+// RecipeConnection returns RecipeConnectionResolver implementation.
+func (r *Resolver) RecipeConnection() RecipeConnectionResolver { return &recipeConnectionResolver{r} }
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
 type ingredientResolver struct{ *Resolver }
+type parsedIngredientResolver struct{ *Resolver }
 type recipeResolver struct{ *Resolver }
+type recipeConnectionResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
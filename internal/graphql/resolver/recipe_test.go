@@ -1,24 +1,37 @@
 package resolver_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/graphql"
 	"github.com/b-sea/supply-run-api/internal/graphql/model"
 	"github.com/b-sea/supply-run-api/internal/metrics"
 	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/b-sea/supply-run-api/internal/user"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestQueryFindRecipes(t *testing.T) {
 	t.Parallel()
 
+	cursors := model.NewCursorSigner([]byte("secret"))
+	cursor := cursors.Encode(
+		model.Cursor{ID: entity.NewID("1"), Sort: model.SortCreated, Time: time.Time{}, Name: "", Score: 0},
+	)
+
 	type testCase struct {
 		repo     query.RecipeRepository
+		search   query.RecipeSearch
 		options  []client.Option
 		query    string
 		response map[string]any
@@ -36,18 +49,74 @@ func TestQueryFindRecipes(t *testing.T) {
 			response: map[string]any{
 				"findRecipes": map[string]any{
 					"edges": []any{
-						map[string]any{"cursor": "MTpDUkVBVEVE", "node": map[string]any{"id": "cmVjaXBlOjE="}},
+						map[string]any{"cursor": cursor, "node": map[string]any{"id": "cmVjaXBlOjE="}},
 					},
 					"pageInfo": map[string]any{
-						"endCursor":       "MTpDUkVBVEVE",
+						"endCursor":       cursor,
 						"hasNextPage":     false,
 						"hasPreviousPage": false,
-						"startCursor":     "MTpDUkVBVEVE",
+						"startCursor":     cursor,
+					},
+				},
+			},
+			err: nil,
+		},
+		"search by relevance": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{
+					{ID: entity.NewID("1")},
+					{ID: entity.NewID("2")},
+				},
+			},
+			search: &mock.RecipeSearch{
+				SearchRecipesResult: []query.Match{
+					{ID: entity.NewID("2"), Score: 2},
+					{ID: entity.NewID("1"), Score: 1},
+				},
+			},
+			query: `query { findRecipes(search: "pasta", order: {Sort: RELEVANCE}) { edges { node { id }}}}`,
+			response: map[string]any{
+				"findRecipes": map[string]any{
+					"edges": []any{
+						map[string]any{"node": map[string]any{"id": "cmVjaXBlOjI="}},
+						map[string]any{"node": map[string]any{"id": "cmVjaXBlOjE="}},
+					},
+				},
+			},
+			err: nil,
+		},
+		"backward with total count": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{
+					{ID: entity.NewID("2")},
+					{ID: entity.NewID("1")},
+				},
+				CountRecipesResult: 2,
+			},
+			query: `query { findRecipes(page: {last: 1}) { totalCount pageInfo { hasNextPage hasPreviousPage } edges { node { id }}}}`,
+			response: map[string]any{
+				"findRecipes": map[string]any{
+					"totalCount": float64(2),
+					"edges": []any{
+						map[string]any{"node": map[string]any{"id": "cmVjaXBlOjI="}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": true,
 					},
 				},
 			},
 			err: nil,
 		},
+		"count error": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{{ID: entity.NewID("1")}},
+				CountRecipesErr:   errors.New("some random error"),
+			},
+			query:    `query { findRecipes { totalCount }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
 		"repo error": {
 			repo: &mock.QueryRecipeRepository{
 				FindRecipesErr: errors.New("some random error"),
@@ -60,13 +129,31 @@ func TestQueryFindRecipes(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
+			options := make([]query.Option, 0)
+			if test.search != nil {
+				options = append(options, query.WithRecipeSearch(test.search))
+			}
+
 			server := graphql.New(
 				query.NewService(
 					test.repo,
 					&mock.QueryUnitRepository{},
 					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+					options...,
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
+				graphql.WithCursorSecret([]byte("secret")),
 			)
 			testClient := client.New(server)
 
@@ -158,6 +245,17 @@ func TestQueryRecipe(t *testing.T) {
 					test.repo,
 					&mock.QueryUnitRepository{},
 					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
 			)
@@ -230,6 +328,17 @@ func TestQueryRecipeCreatedBy(t *testing.T) {
 					test.recipes,
 					&mock.QueryUnitRepository{},
 					test.users,
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
 			)
@@ -302,6 +411,17 @@ func TestQueryRecipeUpdatedBy(t *testing.T) {
 					test.recipes,
 					&mock.QueryUnitRepository{},
 					test.users,
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
 			)
@@ -378,6 +498,17 @@ func TestQueryIngredientUnit(t *testing.T) {
 					test.recipes,
 					test.units,
 					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
 			)
@@ -438,6 +569,17 @@ func TestQueryFindTags(t *testing.T) {
 					test.recipes,
 					&mock.QueryUnitRepository{},
 					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
 				),
 				metrics.NewNoOp(),
 			)
@@ -456,3 +598,1104 @@ func TestQueryFindTags(t *testing.T) {
 		})
 	}
 }
+
+func TestMutationCreateRecipe(t *testing.T) {
+	t.Parallel()
+
+	units := &mock.QueryUnitRepository{
+		AllUnitsResult: []*query.Unit{{ID: entity.NewID("cup"), Name: "cup", Symbol: "c"}},
+	}
+
+	type testCase struct {
+		queries  query.RecipeRepository
+		units    query.UnitRepository
+		commands recipe.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			queries: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{{ID: entity.NewID("R1"), Name: "pancakes"}},
+			},
+			units:    units,
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":        "pancakes",
+				"url":         "http://test.org/pancakes",
+				"steps":       []string{"mix", "cook"},
+				"ingredients": []any{map[string]any{"name": "flour", "quantity": 2, "unit": "dW5pdDpjdXA="}},
+				"tags":        []string{"breakfast"},
+			})},
+			query: `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename ...on Recipe { id name }}}`,
+			response: map[string]any{
+				"createRecipe": map[string]any{
+					"__typename": "Recipe",
+					"id":         "cmVjaXBlOlIx",
+					"name":       "pancakes",
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			queries:  &mock.QueryRecipeRepository{},
+			units:    units,
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":        "",
+				"ingredients": []any{map[string]any{"name": "flour", "quantity": 2, "unit": "cmVjaXBlOlIx"}},
+			})},
+			query: `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createRecipe": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "name", "message": "recipe name cannot be empty"},
+						map[string]any{"field": "ingredients", "message": "ingredient unit must be a unit id"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"ingredient lines": {
+			queries: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{{ID: entity.NewID("R1"), Name: "pancakes"}},
+			},
+			units:    units,
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":            "pancakes",
+				"ingredients":     []any{map[string]any{"name": "egg", "quantity": 1}},
+				"ingredientLines": []string{"1 1/2 cups flour, sifted", "salt, to taste"},
+			})},
+			query: `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename }}`,
+			response: map[string]any{
+				"createRecipe": map[string]any{
+					"__typename": "Recipe",
+				},
+			},
+			err: nil,
+		},
+		"ingredient line validation error": {
+			queries:  &mock.QueryRecipeRepository{},
+			units:    units,
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":            "pancakes",
+				"ingredientLines": []string{"2 cups"},
+			})},
+			query: `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createRecipe": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "ingredients", "message": "ingredient name cannot be empty"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"ingredient line error": {
+			queries:  &mock.QueryRecipeRepository{},
+			units:    &mock.QueryUnitRepository{AllUnitsErr: errors.New("some random error")},
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":            "pancakes",
+				"ingredientLines": []string{"1 cup flour"},
+			})},
+			query:    `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+		"repo error": {
+			queries: &mock.QueryRecipeRepository{},
+			units:   units,
+			commands: &mock.RecipeRepository{
+				CreateRecipeErr: errors.New("some random error"),
+			},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "pancakes"})},
+			query:    `mutation test($input: CreateRecipeInput!){ createRecipe(input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.queries,
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					test.commands,
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryWhatCanICook(t *testing.T) {
+	t.Parallel()
+
+	recipes := &mock.QueryRecipeRepository{
+		FindRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("1"),
+				Ingredients: []query.Ingredient{{Name: "eggs"}, {Name: "milk"}},
+			},
+			{
+				ID:          entity.NewID("2"),
+				Ingredients: []query.Ingredient{{Name: "egg"}},
+			},
+		},
+	}
+	one, negative := 1, -1
+
+	type testCase struct {
+		repo     query.RecipeRepository
+		first    *int
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:  recipes,
+			first: nil,
+			response: map[string]any{
+				"whatCanICook": []any{
+					map[string]any{"recipe": map[string]any{"id": "cmVjaXBlOjI="}, "coverage": 1.0, "missing": []any{}},
+					map[string]any{"recipe": map[string]any{"id": "cmVjaXBlOjE="}, "coverage": 0.5, "missing": []any{"milk"}},
+				},
+			},
+			err: nil,
+		},
+		"first": {
+			repo:  recipes,
+			first: &one,
+			response: map[string]any{
+				"whatCanICook": []any{
+					map[string]any{"recipe": map[string]any{"id": "cmVjaXBlOjI="}, "coverage": 1.0, "missing": []any{}},
+				},
+			},
+			err: nil,
+		},
+		"negative first": {
+			repo:     recipes,
+			first:    &negative,
+			response: nil,
+			err:      errors.New(`"code":"VALIDATION"`),
+		},
+		"repo error": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesErr: errors.New("some random error"),
+			},
+			first:    nil,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.repo,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($first: Int){ whatCanICook(ingredients: ["Eggs"], maxMissing: 1, first: $first) { `+
+					`recipe { id } coverage missing }}`,
+				&response,
+				client.Var("first", test.first),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryParseIngredients(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		units    query.UnitRepository
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				AllUnitsResult: []*query.Unit{{ID: entity.NewID("cup"), Name: "cup", Symbol: "c"}},
+				GetUnitsResult: []*query.Unit{{ID: entity.NewID("cup"), Name: "cup", Symbol: "c"}},
+			},
+			response: map[string]any{
+				"parseIngredients": []any{
+					map[string]any{
+						"name":        "flour",
+						"quantity":    1.5,
+						"maxQuantity": 1.5,
+						"note":        "sifted",
+						"unit":        map[string]any{"__typename": "Unit", "name": "cup"},
+					},
+					map[string]any{
+						"name":        "salt",
+						"quantity":    nil,
+						"maxQuantity": nil,
+						"note":        "to taste",
+						"unit":        map[string]any{"__typename": "NotFoundError"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			units:    &mock.QueryUnitRepository{AllUnitsErr: errors.New("some random error")},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query { parseIngredients(lines: ["1 1/2 cups flour, sifted", "salt, to taste"]){ name quantity maxQuantity note unit { __typename ...on Unit { name }}}}`,
+				&response,
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationImportRecipe(t *testing.T) {
+	t.Parallel()
+
+	document := `<script type="application/ld+json">{
+		"@type": "Recipe",
+		"name": "Pancakes",
+		"recipeYield": "4 servings",
+		"recipeIngredient": ["1 1/2 cups flour, sifted"],
+		"recipeInstructions": ["Mix.", "Cook."],
+		"keywords": "breakfast"
+	}</script>`
+
+	units := &mock.QueryUnitRepository{
+		AllUnitsResult: []*query.Unit{{ID: entity.NewID("cup"), Name: "cup", Symbol: "c"}},
+	}
+
+	type testCase struct {
+		fetcher  *mock.Fetcher
+		queries  query.RecipeRepository
+		commands recipe.Repository
+		input    map[string]any
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"preview": {
+			fetcher:  &mock.Fetcher{FetchResult: []byte(document)},
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{CreateRecipeErr: errors.New("should not create")},
+			input:    map[string]any{"url": "https://example.com/pancakes", "preview": true},
+			response: map[string]any{
+				"importRecipe": map[string]any{
+					"__typename":  "RecipePreview",
+					"name":        "Pancakes",
+					"url":         "https://example.com/pancakes",
+					"numServings": float64(4),
+					"steps":       []any{"Mix.", "Cook."},
+					"ingredients": []any{
+						map[string]any{"name": "flour", "quantity": 1.5, "note": "sifted"},
+					},
+					"tags": []any{"breakfast"},
+				},
+			},
+			err: nil,
+		},
+		"create": {
+			fetcher: &mock.Fetcher{},
+			queries: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{{ID: entity.NewID("R1"), Name: "Pancakes"}},
+			},
+			commands: &mock.RecipeRepository{},
+			input:    map[string]any{"html": document},
+			response: map[string]any{
+				"importRecipe": map[string]any{
+					"__typename": "Recipe",
+					"name":       "Pancakes",
+				},
+			},
+			err: nil,
+		},
+		"no recipe": {
+			fetcher:  &mock.Fetcher{FetchResult: []byte("<p>hello</p>")},
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{},
+			input:    map[string]any{"url": "https://example.com/pancakes"},
+			response: map[string]any{
+				"importRecipe": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "url", "message": "document does not contain a recipe"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			fetcher:  &mock.Fetcher{},
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{},
+			input:    map[string]any{"html": `<script type="application/ld+json">{"@type": "Recipe"}</script>`},
+			response: map[string]any{
+				"importRecipe": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "name", "message": "recipe name cannot be empty"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			fetcher:  &mock.Fetcher{},
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{CreateRecipeErr: errors.New("some random error")},
+			input:    map[string]any{"html": document},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.queries,
+					units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					test.commands,
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+				graphql.WithFetcher(test.fetcher),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`mutation test($input: ImportRecipeInput!){ importRecipe(input: $input) { __typename
+					...on Recipe { name }
+					...on RecipePreview { name url numServings steps ingredients { name quantity note } tags }
+					...on ValidationError { errors { field message }}
+				}}`,
+				&response,
+				client.Var("input", test.input),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationUpdateRecipe(t *testing.T) {
+	t.Parallel()
+
+	existing := func() *recipe.Recipe {
+		result, _ := recipe.New(entity.NewID("R1"), "pancakes", time.Now(), entity.NewID("U1"))
+
+		return result
+	}
+
+	type testCase struct {
+		queries  query.RecipeRepository
+		commands recipe.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			queries: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{{ID: entity.NewID("R1"), Name: "crepes"}},
+			},
+			commands: &mock.RecipeRepository{GetRecipeResult: existing()},
+			options: []client.Option{
+				client.Var("id", model.NewRecipeID(entity.NewID("R1")).String()),
+				client.Var("input", map[string]any{"name": "crepes", "tags": []string{}}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateRecipeInput!){ updateRecipe(id: $id, input: $input) { __typename ...on Recipe { name }}}`,
+			response: map[string]any{
+				"updateRecipe": map[string]any{
+					"__typename": "Recipe",
+					"name":       "crepes",
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{GetRecipeResult: existing()},
+			options: []client.Option{
+				client.Var("id", model.NewRecipeID(entity.NewID("R1")).String()),
+				client.Var("input", map[string]any{"url": "not a url", "tags": []string{""}}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateRecipeInput!){ updateRecipe(id: $id, input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"updateRecipe": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "url", "message": "recipe url must be a valid url"},
+						map[string]any{"field": "tags", "message": "tag name cannot be empty"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			options: []client.Option{
+				client.Var("id", model.NewRecipeID(entity.NewID("R1")).String()),
+				client.Var("input", map[string]any{"name": "crepes"}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateRecipeInput!){ updateRecipe(id: $id, input: $input) { __typename }}`,
+			response: map[string]any{
+				"updateRecipe": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			queries:  &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{},
+			options: []client.Option{
+				client.Var("id", model.NewUnitID(entity.NewID("R1")).String()),
+				client.Var("input", map[string]any{"name": "crepes"}),
+			},
+			query: `mutation test($id: ID!, $input: UpdateRecipeInput!){ updateRecipe(id: $id, input: $input) { __typename }}`,
+			response: map[string]any{
+				"updateRecipe": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"repo error": {
+			queries: &mock.QueryRecipeRepository{},
+			commands: &mock.RecipeRepository{
+				GetRecipeResult: existing(),
+				UpdateRecipeErr: errors.New("some random error"),
+			},
+			options: []client.Option{
+				client.Var("id", model.NewRecipeID(entity.NewID("R1")).String()),
+				client.Var("input", map[string]any{"name": "crepes"}),
+			},
+			query:    `mutation test($id: ID!, $input: UpdateRecipeInput!){ updateRecipe(id: $id, input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.queries,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					test.commands,
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationDeleteRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		commands recipe.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			commands: &mock.RecipeRepository{},
+			options:  []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("R1")).String())},
+			query:    `mutation test($id: ID!){ deleteRecipe(id: $id) { __typename ...on DeletedRecipe { id }}}`,
+			response: map[string]any{
+				"deleteRecipe": map[string]any{
+					"__typename": "DeletedRecipe",
+					"id":         "cmVjaXBlOlIx",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			commands: &mock.RecipeRepository{DeleteRecipeErr: entity.ErrNotFound},
+			options:  []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("R1")).String())},
+			query:    `mutation test($id: ID!){ deleteRecipe(id: $id) { __typename }}`,
+			response: map[string]any{
+				"deleteRecipe": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			commands: &mock.RecipeRepository{},
+			options:  []client.Option{client.Var("id", model.NewUserID(entity.NewID("R1")).String())},
+			query:    `mutation test($id: ID!){ deleteRecipe(id: $id) { __typename }}`,
+			response: map[string]any{
+				"deleteRecipe": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"repo error": {
+			commands: &mock.RecipeRepository{DeleteRecipeErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("R1")).String())},
+			query:    `mutation test($id: ID!){ deleteRecipe(id: $id) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					test.commands,
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryRecipeIsFavorite(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		users    query.UserRepository
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"favorite": {
+			users: &mock.QueryUserRepository{GetFavoritesResult: []entity.ID{entity.NewID("1")}},
+			response: map[string]any{
+				"recipe": map[string]any{"isFavorite": true},
+			},
+			err: nil,
+		},
+		"not favorite": {
+			users: &mock.QueryUserRepository{GetFavoritesResult: []entity.ID{}},
+			response: map[string]any{
+				"recipe": map[string]any{"isFavorite": false},
+			},
+			err: nil,
+		},
+		"repo error": {
+			users:    &mock.QueryUserRepository{GetFavoritesErr: errors.New("some random error")},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{GetRecipesResult: []*query.Recipe{{ID: entity.NewID("1")}}},
+					&mock.QueryUnitRepository{},
+					test.users,
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($id: ID!){ recipe(id: $id) { ...on Recipe { isFavorite }}}`,
+				&response,
+				client.Var("id", model.NewRecipeID(entity.NewID("1")).String()),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationFavoriteRecipe(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes  recipe.Repository
+		users    user.Repository
+		id       model.ID
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes: &mock.RecipeRepository{},
+			users:   &mock.UserRepository{},
+			id:      model.NewRecipeID(entity.NewID("R1")),
+			response: map[string]any{
+				"result": map[string]any{
+					"__typename": "Recipe",
+					"id":         "cmVjaXBlOlIx",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			recipes: &mock.RecipeRepository{GetRecipeErr: entity.ErrNotFound},
+			users:   &mock.UserRepository{},
+			id:      model.NewRecipeID(entity.NewID("R1")),
+			response: map[string]any{
+				"result": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			recipes: &mock.RecipeRepository{},
+			users:   &mock.UserRepository{},
+			id:      model.NewUserID(entity.NewID("R1")),
+			response: map[string]any{
+				"result": map[string]any{"__typename": "NotFoundError"},
+			},
+			err: nil,
+		},
+		"repo error": {
+			recipes: &mock.RecipeRepository{},
+			users: &mock.UserRepository{
+				AddFavoriteErr:    errors.New("some random error"),
+				RemoveFavoriteErr: errors.New("some random error"),
+			},
+			id:       model.NewRecipeID(entity.NewID("R1")),
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		for _, mutation := range []string{"favoriteRecipe", "unfavoriteRecipe"} {
+			t.Run(name+" "+mutation, func(t *testing.T) {
+				server := graphql.New(
+					query.NewService(
+						&mock.QueryRecipeRepository{GetRecipesResult: []*query.Recipe{{ID: entity.NewID("R1")}}},
+						&mock.QueryUnitRepository{},
+						&mock.QueryUserRepository{},
+						&mock.QueryShoppingListRepository{},
+						&mock.QueryPantryRepository{},
+						&mock.QueryMealRepository{},
+					),
+					command.NewService(
+						test.recipes,
+						&mock.UnitRepository{},
+						test.users,
+						&mock.ShoppingListRepository{},
+						&mock.PantryRepository{},
+						&mock.MealRepository{},
+					),
+					metrics.NewNoOp(),
+				)
+				testClient := client.New(server, asUser(""))
+
+				var response map[string]any
+
+				err := testClient.Post(
+					`mutation test($id: ID!){ result: `+mutation+`(id: $id) { __typename ...on Recipe { id }}}`,
+					&response,
+					client.Var("id", test.id.String()),
+				)
+
+				assert.Equal(t, test.response, response)
+				if test.err == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorAs(t, err, &test.err)
+				}
+			})
+		}
+	}
+}
+
+func TestQueryRecipeScaledIngredients(t *testing.T) {
+	t.Parallel()
+
+	teaspoon := unit.New("teaspoon", "tsp", unit.US, unit.Volume)
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+
+	type testCase struct {
+		units    query.UnitRepository
+		servings int
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				AllUnitsResult: []*query.Unit{
+					{ID: teaspoon.ID(), Name: "teaspoon", BaseType: "volume", System: "us"},
+					{ID: cup.ID(), Name: "cup", BaseType: "volume", System: "us"},
+				},
+				GetConversionsResult: []*query.Conversion{{FromID: teaspoon.ID(), ToID: cup.ID(), Ratio: 48}},
+			},
+			servings: 4,
+			response: map[string]any{
+				"recipe": map[string]any{
+					"ingredients": []any{
+						map[string]any{"name": "sugar", "quantity": float64(1)},
+					},
+				},
+			},
+			err: nil,
+		},
+		"invalid servings": {
+			units:    &mock.QueryUnitRepository{},
+			servings: 0,
+			response: nil,
+			err:      errors.New("validation errors: servings must be greater than 0"),
+		},
+		"repo error": {
+			units:    &mock.QueryUnitRepository{AllUnitsErr: errors.New("some random error")},
+			servings: 4,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{
+						GetRecipesResult: []*query.Recipe{{
+							ID:          entity.NewID("R1"),
+							NumServings: 2,
+							Ingredients: []query.Ingredient{{Name: "sugar", Quantity: 24, UnitID: teaspoon.ID()}},
+						}},
+					},
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($id: ID!, $servings: Int){ recipe(id: $id) { ...on Recipe { ingredients(servings: $servings) { name quantity }}}}`,
+				&response,
+				client.Var("id", model.NewRecipeID(entity.NewID("R1")).String()),
+				client.Var("servings", test.servings),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+// publish keeps publishing an Event until the Context is done, since subscribers are registered asynchronously.
+func publish(ctx context.Context, broker pubsub.Broker, event pubsub.Event) {
+	go func() {
+		for ctx.Err() == nil {
+			broker.Publish(ctx, event)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+}
+
+func TestSubscriptionRecipeChanged(t *testing.T) {
+	t.Parallel()
+
+	recipeID := model.NewRecipeID(entity.NewID("1")).String()
+
+	type testCase struct {
+		repo     query.RecipeRepository
+		event    pubsub.Event
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"updated": {
+			repo: &mock.QueryRecipeRepository{
+				GetRecipesResult:  []*query.Recipe{{ID: entity.NewID("1"), Name: "pancakes"}},
+				FindRecipesResult: []*query.Recipe{{ID: entity.NewID("1"), Name: "crepes"}},
+			},
+			event:   pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("1")},
+			options: []client.Option{client.Var("id", recipeID)},
+			query:   `subscription test($id: ID!){ recipeChanged(id: $id){ __typename ...on Recipe { name }}}`,
+			response: map[string]any{
+				"recipeChanged": map[string]any{"__typename": "Recipe", "name": "crepes"},
+			},
+			err: nil,
+		},
+		"deleted": {
+			repo: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{{ID: entity.NewID("1"), Name: "pancakes"}},
+			},
+			event:   pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.DeletedAction, ID: entity.NewID("1")},
+			options: []client.Option{client.Var("id", recipeID)},
+			query:   `subscription test($id: ID!){ recipeChanged(id: $id){ __typename ...on DeletedRecipe { id }}}`,
+			response: map[string]any{
+				"recipeChanged": map[string]any{"__typename": "DeletedRecipe", "id": recipeID},
+			},
+			err: nil,
+		},
+		"not found": {
+			repo:     &mock.QueryRecipeRepository{GetRecipesResult: []*query.Recipe{}},
+			event:    pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("1")},
+			options:  []client.Option{client.Var("id", recipeID)},
+			query:    `subscription test($id: ID!){ recipeChanged(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("not found"),
+		},
+		"any recipe": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{{ID: entity.NewID("2"), Name: "waffles"}},
+			},
+			event:   pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.CreatedAction, ID: entity.NewID("2")},
+			options: nil,
+			query:   `subscription { recipesChanged(filter: {name: "waffle"}){ __typename ...on Recipe { name }}}`,
+			response: map[string]any{
+				"recipesChanged": map[string]any{"__typename": "Recipe", "name": "waffles"},
+			},
+			err: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			broker := pubsub.NewMemory()
+			server := graphql.New(
+				query.NewService(
+					test.repo,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+				graphql.WithBroker(broker),
+			)
+			testClient := client.New(server)
+
+			subscription := testClient.Websocket(test.query, test.options...)
+			defer func() { _ = subscription.Close() }()
+
+			publish(ctx, broker, test.event)
+
+			var response map[string]any
+
+			err := subscription.Next(&response)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
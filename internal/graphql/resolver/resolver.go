@@ -1,16 +1,215 @@
 // Package resolver implements all GraphQL resolvers.
 package resolver
 
-import "github.com/b-sea/supply-run-api/internal/query"
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/calendar"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/rs/zerolog"
+)
 
 // Resolver defines all data available to the resolvers.
 type Resolver struct {
-	queries *query.Service
+	queries  *query.Service
+	commands *command.Service
+	imports  *importer.Importer
+	broker   pubsub.Broker
+	feeds    *calendar.Signer
 }
 
 // NewResolver creates a new Resolver.
-func NewResolver(queries *query.Service) *Resolver {
+func NewResolver(
+	queries *query.Service,
+	commands *command.Service,
+	imports *importer.Importer,
+	broker pubsub.Broker,
+	feeds *calendar.Signer,
+) *Resolver {
 	return &Resolver{
-		queries: queries,
+		queries:  queries,
+		commands: commands,
+		imports:  imports,
+		broker:   broker,
+		feeds:    feeds,
+	}
+}
+
+// currentUser returns the id of the user making the request.
+// It fails with auth.ErrUnauthorized when the request carries no user.
+func currentUser(ctx context.Context) (entity.ID, error) {
+	return auth.FromContext(ctx) //nolint: wrapcheck
+}
+
+// viewer returns the id of the user making the request, or an anonymous id when there is none.
+// It is meant for fields that only personalize their result.
+func viewer(ctx context.Context) entity.ID {
+	id, err := currentUser(ctx)
+	if err != nil {
+		return entity.NewID("")
+	}
+
+	return id
+}
+
+// ingredientInputs parses raw ingredient lines and appends them to the structured ingredient inputs.
+// Inputs are returned untouched when no lines are given.
+func (r *Resolver) ingredientInputs(
+	ctx context.Context,
+	inputs []*model.IngredientInput,
+	lines []string,
+) ([]*model.IngredientInput, error) {
+	if lines == nil {
+		return inputs, nil
 	}
+
+	parsed, err := r.queries.ParseIngredients(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(inputs, model.NewIngredientInputs(parsed)...), nil
+}
+
+// updateShoppingList applies changes to a shopping list and returns the result of the update.
+func (r *Resolver) updateShoppingList(
+	ctx context.Context,
+	id model.ID,
+	update func(userID entity.ID, listID entity.ID) (*shoppinglist.List, error),
+) (model.UpdateShoppingListResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.ShoppingListKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if _, err := update(userID, id.Key); err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetShoppingList(ctx, userID, id.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewShoppingList(result), nil
+}
+
+// subscribe streams the changes made by every Event published to a topic until the subscription ends.
+// Events the change function does not accept are skipped.
+func subscribe[T any](
+	ctx context.Context,
+	broker pubsub.Broker,
+	topic pubsub.Topic,
+	change func(event pubsub.Event) (T, bool),
+) <-chan T {
+	events := broker.Subscribe(ctx, topic)
+	result := make(chan T)
+
+	go func() {
+		defer close(result)
+
+		for event := range events {
+			changed, ok := change(event)
+			if !ok {
+				continue
+			}
+
+			// Subscriptions outlive a single result, so anything loaded for the last one may be stale.
+			dataloader.ClearCache(ctx)
+
+			select {
+			case result <- changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+// recipeChange returns the recipe changed by an Event, if it matches the filter.
+// Deleted recipes can no longer be matched against the filter, so only their ids are checked.
+func (r *Resolver) recipeChange(
+	ctx context.Context,
+	filter query.RecipeFilter,
+	event pubsub.Event,
+) (model.RecipeChangedResult, bool) {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, event.ID) {
+		return nil, false
+	}
+
+	if event.Action == pubsub.DeletedAction {
+		return model.DeletedRecipe{ID: model.NewRecipeID(event.ID)}, true
+	}
+
+	filter.IDs = []entity.ID{event.ID}
+
+	found, err := r.queries.FindRecipes(
+		ctx,
+		filter,
+		query.Pagination{Cursor: nil, Size: 1, Backward: false},
+		query.Order{Sort: query.CreatedSort, Direction: query.DescDirection},
+	)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to load changed recipe")
+
+		return nil, false
+	}
+
+	if len(found.Items) == 0 {
+		return nil, false
+	}
+
+	return model.NewRecipe(found.Items[0]), true
+}
+
+// shoppingListChange returns the shopping list changed by an Event, if it is the user's list with the given id.
+func (r *Resolver) shoppingListChange(
+	ctx context.Context,
+	userID entity.ID,
+	id entity.ID,
+	event pubsub.Event,
+) (model.ShoppingListChangedResult, bool) {
+	if event.ID != id {
+		return nil, false
+	}
+
+	if event.Action == pubsub.DeletedAction {
+		return model.DeletedShoppingList{ID: model.NewShoppingListID(event.ID)}, true
+	}
+
+	result, err := r.queries.GetShoppingList(ctx, userID, id)
+	if err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to load changed shopping list")
+		}
+
+		return nil, false
+	}
+
+	return model.NewShoppingList(result), true
 }
@@ -0,0 +1,18 @@
+package resolver_test
+
+import (
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/auth"
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// asUser sends every request of a test client as the given authenticated user.
+func asUser(id string) client.Option {
+	return func(bd *client.Request) {
+		bd.HTTP = bd.HTTP.WithContext(auth.ToContext(bd.HTTP.Context(), entity.NewID(id)))
+	}
+}
+
+func amount(value float64) *float64 {
+	return &value
+}
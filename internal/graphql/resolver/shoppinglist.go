@@ -0,0 +1,213 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.83
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+)
+
+// CreateShoppingList is the resolver for the createShoppingList field.
+func (r *mutationResolver) CreateShoppingList(ctx context.Context, input model.CreateShoppingListInput) (model.CreateShoppingListResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := model.NewShoppingItems(input.Items)
+
+	if len(input.Recipes) > 0 {
+		planned, err := r.queries.PlanShoppingList(ctx, model.NewQueryRecipeServings(input.Recipes))
+		if err != nil {
+			var validation *entity.ValidationError
+			if errors.As(err, &validation) {
+				return model.NewValidationError(validation), nil
+			}
+
+			return nil, err
+		}
+
+		items = append(model.NewPlannedShoppingItems(planned), items...)
+	}
+
+	created, err := r.commands.CreateShoppingList(ctx, userID, input.Name, items...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	result, err := r.queries.GetShoppingList(ctx, userID, created.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewShoppingList(result), nil
+}
+
+// UpdateShoppingList is the resolver for the updateShoppingList field.
+func (r *mutationResolver) UpdateShoppingList(ctx context.Context, id model.ID, input model.UpdateShoppingListInput) (model.UpdateShoppingListResult, error) {
+	return r.updateShoppingList(ctx, id, func(userID entity.ID, listID entity.ID) (*shoppinglist.List, error) {
+		return r.commands.UpdateShoppingList(ctx, userID, listID, model.NewShoppingListOptions(input)...)
+	})
+}
+
+// DeleteShoppingList is the resolver for the deleteShoppingList field.
+func (r *mutationResolver) DeleteShoppingList(ctx context.Context, id model.ID) (model.DeleteShoppingListResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.ShoppingListKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	if err := r.commands.DeleteShoppingList(ctx, userID, id.Key); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.DeletedShoppingList{ID: id}, nil
+}
+
+// AddShoppingItems is the resolver for the addShoppingItems field.
+func (r *mutationResolver) AddShoppingItems(ctx context.Context, list model.ID, items []*model.ShoppingItemInput) (model.UpdateShoppingListResult, error) {
+	return r.updateShoppingList(ctx, list, func(userID entity.ID, listID entity.ID) (*shoppinglist.List, error) {
+		return r.commands.AddShoppingItems(ctx, userID, listID, model.NewShoppingItems(items)...)
+	})
+}
+
+// CheckShoppingItem is the resolver for the checkShoppingItem field.
+func (r *mutationResolver) CheckShoppingItem(ctx context.Context, list model.ID, item model.ID, checked bool) (model.UpdateShoppingListResult, error) {
+	return r.updateShoppingList(ctx, list, func(userID entity.ID, listID entity.ID) (*shoppinglist.List, error) {
+		return r.commands.UpdateShoppingList(
+			ctx, userID, listID, shoppinglist.SetChecked(model.NewShoppingItemKey(item), checked),
+		)
+	})
+}
+
+// RemoveShoppingItem is the resolver for the removeShoppingItem field.
+func (r *mutationResolver) RemoveShoppingItem(ctx context.Context, list model.ID, item model.ID) (model.UpdateShoppingListResult, error) {
+	return r.updateShoppingList(ctx, list, func(userID entity.ID, listID entity.ID) (*shoppinglist.List, error) {
+		return r.commands.UpdateShoppingList(ctx, userID, listID, shoppinglist.RemoveItem(model.NewShoppingItemKey(item)))
+	})
+}
+
+// ShoppingList is the resolver for the shoppingList field.
+func (r *queryResolver) ShoppingList(ctx context.Context, id model.ID) (model.ShoppingListResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.ShoppingListKind {
+		return model.NotFoundError{ID: id}, nil
+	}
+
+	result, err := r.queries.GetShoppingList(ctx, userID, id.Key)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return model.NotFoundError{ID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return model.NewShoppingList(result), nil
+}
+
+// ShoppingLists is the resolver for the shoppingLists field.
+func (r *queryResolver) ShoppingLists(ctx context.Context) ([]*model.ShoppingList, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.queries.FindShoppingLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ShoppingList, len(found))
+	for i := range found {
+		result[i] = model.NewShoppingList(found[i])
+	}
+
+	return result, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *shoppingItemResolver) Unit(ctx context.Context, obj *model.ShoppingItem) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreatedBy is the resolver for the createdBy field.
+func (r *shoppingListResolver) CreatedBy(ctx context.Context, obj *model.ShoppingList) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.CreatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdatedBy is the resolver for the updatedBy field.
+func (r *shoppingListResolver) UpdatedBy(ctx context.Context, obj *model.ShoppingList) (model.UserResult, error) {
+	result, err := dataloader.GetUser(ctx, obj.UpdatedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ShoppingListChanged is the resolver for the shoppingListChanged field.
+func (r *subscriptionResolver) ShoppingListChanged(ctx context.Context, id model.ID) (<-chan model.ShoppingListChangedResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.Kind != model.ShoppingListKind {
+		return nil, entity.ErrNotFound
+	}
+
+	if _, err := r.queries.GetShoppingList(ctx, userID, id.Key); err != nil {
+		return nil, err
+	}
+
+	change := func(event pubsub.Event) (model.ShoppingListChangedResult, bool) {
+		return r.shoppingListChange(ctx, userID, id.Key, event)
+	}
+
+	return subscribe(ctx, r.broker, pubsub.ShoppingListTopic, change), nil
+}
+
+// ShoppingItem returns ShoppingItemResolver implementation.
+func (r *Resolver) ShoppingItem() ShoppingItemResolver { return &shoppingItemResolver{r} }
+
+// ShoppingList returns ShoppingListResolver implementation.
+func (r *Resolver) ShoppingList() ShoppingListResolver { return &shoppingListResolver{r} }
+
+type shoppingItemResolver struct{ *Resolver }
+type shoppingListResolver struct{ *Resolver }
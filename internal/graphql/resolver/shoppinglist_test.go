@@ -0,0 +1,711 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/metrics"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryShoppingList(t *testing.T) {
+	t.Parallel()
+
+	listID := model.NewShoppingListID(entity.NewID("L1")).String()
+
+	type testCase struct {
+		lists    query.ShoppingListRepository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{
+					{
+						ID:        entity.NewID("L1"),
+						Name:      "groceries",
+						CreatedBy: entity.NewID("user-1"),
+						Items: []query.ShoppingItem{
+							{ID: entity.NewID("I1"), Name: "flour", Quantity: 2, Checked: true},
+						},
+					},
+				},
+			},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `query test($id: ID!){ shoppingList(id: $id){ __typename ...on ShoppingList { name items { name quantity checked }}}}`,
+			response: map[string]any{
+				"shoppingList": map[string]any{
+					"__typename": "ShoppingList",
+					"name":       "groceries",
+					"items": []any{
+						map[string]any{"name": "flour", "quantity": float64(2), "checked": true},
+					},
+				},
+			},
+			err: nil,
+		},
+		"node": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), CreatedBy: entity.NewID("user-1")}},
+			},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "ShoppingList",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			lists:   &mock.QueryShoppingListRepository{},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `query test($id: ID!){ shoppingList(id: $id){ __typename }}`,
+			response: map[string]any{
+				"shoppingList": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"another user": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `query test($id: ID!){ shoppingList(id: $id){ __typename }}`,
+			response: map[string]any{
+				"shoppingList": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"another user node": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `query test($id: ID!){ node(id: $id){ __typename }}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1")}},
+			},
+			options: []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("L1")).String())},
+			query:   `query test($id: ID!){ shoppingList(id: $id){ __typename }}`,
+			response: map[string]any{
+				"shoppingList": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			lists:    &mock.QueryShoppingListRepository{GetShoppingListsErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("id", listID)},
+			query:    `query test($id: ID!){ shoppingList(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					test.lists,
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser("user-1"))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryShoppingLists(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		lists    query.ShoppingListRepository
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			lists: &mock.QueryShoppingListRepository{
+				FindShoppingListsResult: []*query.ShoppingList{
+					{ID: entity.NewID("L1"), Name: "groceries"},
+					{ID: entity.NewID("L2"), Name: "party"},
+				},
+			},
+			response: map[string]any{
+				"shoppingLists": []any{
+					map[string]any{"name": "groceries"},
+					map[string]any{"name": "party"},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			lists:    &mock.QueryShoppingListRepository{FindShoppingListsErr: errors.New("some random error")},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					test.lists,
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(`query { shoppingLists { name }}`, &response)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationCreateShoppingList(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes  query.RecipeRepository
+		lists    query.ShoppingListRepository
+		commands shoppinglist.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes: &mock.QueryRecipeRepository{
+				GetRecipesResult: []*query.Recipe{
+					{
+						ID:          entity.NewID("R1"),
+						NumServings: 2,
+						Ingredients: []query.Ingredient{{Name: "flour", Quantity: 2}},
+					},
+				},
+			},
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), Name: "groceries"}},
+			},
+			commands: &mock.ShoppingListRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":    "groceries",
+				"recipes": []any{map[string]any{"recipe": model.NewRecipeID(entity.NewID("R1")).String(), "servings": 4}},
+				"items":   []any{map[string]any{"name": "paper towels"}},
+			})},
+			query: `mutation test($input: CreateShoppingListInput!){ createShoppingList(input: $input) { __typename ...on ShoppingList { id name }}}`,
+			response: map[string]any{
+				"createShoppingList": map[string]any{
+					"__typename": "ShoppingList",
+					"id":         model.NewShoppingListID(entity.NewID("L1")).String(),
+					"name":       "groceries",
+				},
+			},
+			err: nil,
+		},
+		"missing recipe": {
+			recipes:  &mock.QueryRecipeRepository{},
+			lists:    &mock.QueryShoppingListRepository{},
+			commands: &mock.ShoppingListRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":    "groceries",
+				"recipes": []any{map[string]any{"recipe": model.NewUnitID(entity.NewID("R1")).String()}},
+			})},
+			query: `mutation test($input: CreateShoppingListInput!){ createShoppingList(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createShoppingList": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "recipes", "message": "recipe does not exist"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			recipes:  &mock.QueryRecipeRepository{},
+			lists:    &mock.QueryShoppingListRepository{},
+			commands: &mock.ShoppingListRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":  "",
+				"items": []any{map[string]any{"name": "milk", "quantity": -1}},
+			})},
+			query: `mutation test($input: CreateShoppingListInput!){ createShoppingList(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createShoppingList": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "name", "message": "shopping list name cannot be empty"},
+						map[string]any{"field": "items", "message": "item quantity cannot be negative"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			recipes:  &mock.QueryRecipeRepository{},
+			lists:    &mock.QueryShoppingListRepository{},
+			commands: &mock.ShoppingListRepository{CreateShoppingListErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "groceries"})},
+			query:    `mutation test($input: CreateShoppingListInput!){ createShoppingList(input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.recipes,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					test.lists,
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					test.commands,
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser(""))
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationCheckShoppingItem(t *testing.T) {
+	t.Parallel()
+
+	newList := func(userID string) *shoppinglist.List {
+		result, _ := shoppinglist.New(
+			entity.NewID("L1"), "groceries", time.Now(), entity.NewID(userID),
+			shoppinglist.AddItem(entity.NewID("I1"), "flour", 2, entity.NewID("")),
+		)
+
+		return result
+	}
+
+	listID := model.NewShoppingListID(entity.NewID("L1")).String()
+	itemID := model.NewShoppingItemID(entity.NewID("I1")).String()
+
+	type testCase struct {
+		commands shoppinglist.Repository
+		options  []client.Option
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			commands: &mock.ShoppingListRepository{GetShoppingListResult: newList("user-1")},
+			options:  []client.Option{client.Var("list", listID), client.Var("item", itemID)},
+			response: map[string]any{
+				"checkShoppingItem": map[string]any{
+					"__typename": "ShoppingList",
+				},
+			},
+			err: nil,
+		},
+		"missing item": {
+			commands: &mock.ShoppingListRepository{GetShoppingListResult: newList("user-1")},
+			options: []client.Option{
+				client.Var("list", listID),
+				client.Var("item", model.NewShoppingItemID(entity.NewID("I2")).String()),
+			},
+			response: map[string]any{
+				"checkShoppingItem": map[string]any{
+					"__typename": "ValidationError",
+				},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			commands: &mock.ShoppingListRepository{GetShoppingListResult: newList("user-1")},
+			options: []client.Option{
+				client.Var("list", model.NewRecipeID(entity.NewID("L1")).String()),
+				client.Var("item", itemID),
+			},
+			response: map[string]any{
+				"checkShoppingItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"another user": {
+			commands: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList("user-2"),
+				UpdateShoppingListErr: errors.New("should not be called"),
+			},
+			options: []client.Option{client.Var("list", listID), client.Var("item", itemID)},
+			response: map[string]any{
+				"checkShoppingItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			commands: &mock.ShoppingListRepository{GetShoppingListErr: entity.ErrNotFound},
+			options:  []client.Option{client.Var("list", listID), client.Var("item", itemID)},
+			response: map[string]any{
+				"checkShoppingItem": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			commands: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList("user-1"),
+				UpdateShoppingListErr: errors.New("some random error"),
+			},
+			options:  []client.Option{client.Var("list", listID), client.Var("item", itemID)},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{
+						GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), CreatedBy: entity.NewID("user-1")}},
+					},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					test.commands,
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser("user-1"))
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`mutation test($list: ID!, $item: ID!){ checkShoppingItem(list: $list, item: $item) { __typename }}`,
+				&response,
+				test.options...,
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationDeleteShoppingList(t *testing.T) {
+	t.Parallel()
+
+	listID := model.NewShoppingListID(entity.NewID("L1")).String()
+
+	newList := func(userID string) *shoppinglist.List {
+		result, _ := shoppinglist.New(entity.NewID("L1"), "groceries", time.Now(), entity.NewID(userID))
+
+		return result
+	}
+
+	type testCase struct {
+		commands shoppinglist.Repository
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			commands: &mock.ShoppingListRepository{GetShoppingListResult: newList("user-1")},
+			response: map[string]any{
+				"deleteShoppingList": map[string]any{
+					"__typename": "DeletedShoppingList",
+				},
+			},
+			err: nil,
+		},
+		"another user": {
+			commands: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList("user-2"),
+				DeleteShoppingListErr: errors.New("should not be called"),
+			},
+			response: map[string]any{
+				"deleteShoppingList": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"not found": {
+			commands: &mock.ShoppingListRepository{GetShoppingListErr: entity.ErrNotFound},
+			response: map[string]any{
+				"deleteShoppingList": map[string]any{
+					"__typename": "NotFoundError",
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			commands: &mock.ShoppingListRepository{
+				GetShoppingListResult: newList("user-1"),
+				DeleteShoppingListErr: errors.New("some random error"),
+			},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					test.commands,
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server, asUser("user-1"))
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`mutation test($id: ID!){ deleteShoppingList(id: $id) { __typename }}`,
+				&response,
+				client.Var("id", listID),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestSubscriptionShoppingListChanged(t *testing.T) {
+	t.Parallel()
+
+	listID := model.NewShoppingListID(entity.NewID("L1")).String()
+
+	type testCase struct {
+		lists    query.ShoppingListRepository
+		event    pubsub.Event
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"updated": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), Name: "groceries"}},
+			},
+			event:   pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("L1")},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `subscription test($id: ID!){ shoppingListChanged(id: $id){ __typename ...on ShoppingList { name }}}`,
+			response: map[string]any{
+				"shoppingListChanged": map[string]any{"__typename": "ShoppingList", "name": "groceries"},
+			},
+			err: nil,
+		},
+		"deleted": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), Name: "groceries"}},
+			},
+			event:   pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.DeletedAction, ID: entity.NewID("L1")},
+			options: []client.Option{client.Var("id", listID)},
+			query:   `subscription test($id: ID!){ shoppingListChanged(id: $id){ __typename ...on DeletedShoppingList { id }}}`,
+			response: map[string]any{
+				"shoppingListChanged": map[string]any{"__typename": "DeletedShoppingList", "id": listID},
+			},
+			err: nil,
+		},
+		"another user": {
+			lists: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("L1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			event:    pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("L1")},
+			options:  []client.Option{client.Var("id", listID)},
+			query:    `subscription test($id: ID!){ shoppingListChanged(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("not found"),
+		},
+		"wrong kind": {
+			lists:    &mock.QueryShoppingListRepository{},
+			event:    pubsub.Event{Topic: pubsub.ShoppingListTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("L1")},
+			options:  []client.Option{client.Var("id", model.NewRecipeID(entity.NewID("L1")).String())},
+			query:    `subscription test($id: ID!){ shoppingListChanged(id: $id){ __typename }}`,
+			response: nil,
+			err:      errors.New("not found"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			broker := pubsub.NewMemory()
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					test.lists,
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+				graphql.WithBroker(broker),
+			)
+			testClient := client.New(server)
+
+			subscription := testClient.Websocket(test.query, test.options...)
+			defer func() { _ = subscription.Close() }()
+
+			publish(ctx, broker, test.event)
+
+			var response map[string]any
+
+			err := subscription.Next(&response)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.83
+
+import (
+	"context"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/dataloader"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// From is the resolver for the from field.
+func (r *conversionResolver) From(ctx context.Context, obj *model.Conversion) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.FromID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// To is the resolver for the to field.
+func (r *conversionResolver) To(ctx context.Context, obj *model.Conversion) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.ToID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *convertedQuantityResolver) Unit(ctx context.Context, obj *model.ConvertedQuantity) (model.UnitResult, error) {
+	result, err := dataloader.GetUnit(ctx, obj.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateUnit is the resolver for the createUnit field.
+func (r *mutationResolver) CreateUnit(ctx context.Context, input model.CreateUnitInput) (model.CreateUnitResult, error) {
+	created, err := r.commands.CreateUnit(ctx, input.Name, input.Symbol, model.NewUnitOptions(input)...)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	found, err := r.queries.GetUnits(ctx, []entity.ID{created.ID()})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	return model.NewUnit(found[0]), nil
+}
+
+// CreateConversion is the resolver for the createConversion field.
+func (r *mutationResolver) CreateConversion(ctx context.Context, input model.CreateConversionInput) (model.CreateConversionResult, error) {
+	created, err := r.commands.CreateConversion(
+		ctx,
+		model.NewUnitKey(input.From),
+		model.NewUnitKey(input.To),
+		input.Ratio,
+	)
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	return model.NewConversion(
+		&query.Conversion{
+			FromID: created.From().ID(),
+			ToID:   created.To().ID(),
+			Ratio:  created.Ratio(),
+		},
+	), nil
+}
+
+// Units is the resolver for the units field.
+func (r *queryResolver) Units(ctx context.Context, baseType *string, system *string) ([]*model.Unit, error) {
+	found, err := r.queries.FindUnits(ctx, query.UnitFilter{BaseType: baseType, System: system})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Unit, len(found))
+	for i := range found {
+		result[i] = model.NewUnit(found[i])
+	}
+
+	return result, nil
+}
+
+// Convert is the resolver for the convert field.
+func (r *queryResolver) Convert(ctx context.Context, quantity float64, from model.ID, to model.ID) (model.ConvertResult, error) {
+	result, err := r.queries.Convert(ctx, quantity, model.NewUnitKey(from), model.NewUnitKey(to))
+	if err != nil {
+		var validation *entity.ValidationError
+		if errors.As(err, &validation) {
+			return model.NewValidationError(validation), nil
+		}
+
+		return nil, err
+	}
+
+	return model.ConvertedQuantity{Quantity: result, UnitID: model.NewUnitKey(to)}, nil
+}
+
+// Conversions is the resolver for the conversions field.
+func (r *unitResolver) Conversions(ctx context.Context, obj *model.Unit) ([]*model.Conversion, error) {
+	result, err := dataloader.GetConversions(ctx, obj.ID.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Conversion returns ConversionResolver implementation.
+func (r *Resolver) Conversion() ConversionResolver { return &conversionResolver{r} }
+
+// ConvertedQuantity returns ConvertedQuantityResolver implementation.
+func (r *Resolver) ConvertedQuantity() ConvertedQuantityResolver {
+	return &convertedQuantityResolver{r}
+}
+
+// Unit returns UnitResolver implementation.
+func (r *Resolver) Unit() UnitResolver { return &unitResolver{r} }
+
+type conversionResolver struct{ *Resolver }
+type convertedQuantityResolver struct{ *Resolver }
+type unitResolver struct{ *Resolver }
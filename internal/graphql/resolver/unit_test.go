@@ -0,0 +1,501 @@
+package resolver_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/metrics"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryUnitConversions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		units    query.UnitRepository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				GetUnitsResult: []*query.Unit{{ID: entity.NewID("U1")}},
+				GetConversionsResult: []*query.Conversion{
+					{FromID: entity.NewID("U1"), ToID: entity.NewID("U2"), Ratio: 2},
+				},
+			},
+			options: []client.Option{client.Var("id", model.NewUnitID(entity.NewID("U1")).String())},
+			query:   `query test($id: ID!){ node(id: $id) { ...on Unit { conversions { ratio from { ...on Unit { id }} to { __typename }}}}}`,
+			response: map[string]any{
+				"node": map[string]any{
+					"conversions": []any{
+						map[string]any{
+							"ratio": float64(2),
+							"from":  map[string]any{"id": "dW5pdDpVMQ=="},
+							"to":    map[string]any{"__typename": "NotFoundError"},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			units: &mock.QueryUnitRepository{
+				GetUnitsResult:    []*query.Unit{{ID: entity.NewID("U1")}},
+				GetConversionsErr: errors.New("some random error"),
+			},
+			options:  []client.Option{client.Var("id", model.NewUnitID(entity.NewID("U1")).String())},
+			query:    `query test($id: ID!){ node(id: $id) { ...on Unit { conversions { ratio }}}}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationCreateUnit(t *testing.T) {
+	t.Parallel()
+
+	stick := unit.New("stick", "stk", unit.US, unit.Mass)
+
+	type testCase struct {
+		queries  query.UnitRepository
+		commands unit.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			queries: &mock.QueryUnitRepository{
+				GetUnitsResult: []*query.Unit{{ID: stick.ID(), Name: "stick"}},
+			},
+			commands: &mock.UnitRepository{},
+			options: []client.Option{client.Var("input", map[string]any{
+				"name":     "stick",
+				"symbol":   "stk",
+				"plural":   "sticks",
+				"baseType": "mass",
+				"system":   "us",
+			})},
+			query: `mutation test($input: CreateUnitInput!){ createUnit(input: $input) { __typename ...on Unit { name }}}`,
+			response: map[string]any{
+				"createUnit": map[string]any{
+					"__typename": "Unit",
+					"name":       "stick",
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			queries:  &mock.QueryUnitRepository{},
+			commands: &mock.UnitRepository{},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "", "symbol": "stk"})},
+			query:    `mutation test($input: CreateUnitInput!){ createUnit(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createUnit": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "name", "message": "unit name cannot be empty"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"missing after create": {
+			queries:  &mock.QueryUnitRepository{},
+			commands: &mock.UnitRepository{},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "stick", "symbol": "stk"})},
+			query:    `mutation test($input: CreateUnitInput!){ createUnit(input: $input) { __typename }}`,
+			response: nil,
+			err:      entity.ErrNotFound,
+		},
+		"repo error": {
+			queries:  &mock.QueryUnitRepository{},
+			commands: &mock.UnitRepository{CreateUnitErr: errors.New("some random error")},
+			options:  []client.Option{client.Var("input", map[string]any{"name": "stick", "symbol": "stk"})},
+			query:    `mutation test($input: CreateUnitInput!){ createUnit(input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					test.queries,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					test.commands,
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestMutationCreateConversion(t *testing.T) {
+	t.Parallel()
+
+	stick := unit.New("stick", "stk", unit.US, unit.Mass)
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+	liter := unit.New("liter", "l", unit.Metric, unit.Volume)
+	units := map[entity.ID]*unit.Unit{stick.ID(): stick, gram.ID(): gram, liter.ID(): liter}
+
+	type testCase struct {
+		commands unit.Repository
+		options  []client.Option
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			commands: &mock.UnitRepository{GetUnitResult: units},
+			options: []client.Option{client.Var("input", map[string]any{
+				"from":  model.NewUnitID(stick.ID()).String(),
+				"to":    model.NewUnitID(gram.ID()).String(),
+				"ratio": 113,
+			})},
+			query: `mutation test($input: CreateConversionInput!){ createConversion(input: $input) { __typename ...on Conversion { ratio }}}`,
+			response: map[string]any{
+				"createConversion": map[string]any{
+					"__typename": "Conversion",
+					"ratio":      float64(113),
+				},
+			},
+			err: nil,
+		},
+		"validation error": {
+			commands: &mock.UnitRepository{GetUnitResult: units},
+			options: []client.Option{client.Var("input", map[string]any{
+				"from":  model.NewRecipeID(stick.ID()).String(),
+				"to":    model.NewUnitID(liter.ID()).String(),
+				"ratio": 0,
+			})},
+			query: `mutation test($input: CreateConversionInput!){ createConversion(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createConversion": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "from", "message": "unit does not exist"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"conversion error": {
+			commands: &mock.UnitRepository{GetUnitResult: units},
+			options: []client.Option{client.Var("input", map[string]any{
+				"from":  model.NewUnitID(stick.ID()).String(),
+				"to":    model.NewUnitID(liter.ID()).String(),
+				"ratio": 0,
+			})},
+			query: `mutation test($input: CreateConversionInput!){ createConversion(input: $input) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"createConversion": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "to", "message": `cannot convert "mass" to "volume"`},
+						map[string]any{"field": "ratio", "message": "ratio must be greater than 0"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			commands: &mock.UnitRepository{
+				GetUnitResult:       units,
+				CreateConversionErr: errors.New("some random error"),
+			},
+			options: []client.Option{client.Var("input", map[string]any{
+				"from":  model.NewUnitID(stick.ID()).String(),
+				"to":    model.NewUnitID(gram.ID()).String(),
+				"ratio": 113,
+			})},
+			query:    `mutation test($input: CreateConversionInput!){ createConversion(input: $input) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					test.commands,
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(test.query, &response, test.options...)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryUnits(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		units    query.UnitRepository
+		options  []client.Option
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				AllUnitsResult: []*query.Unit{
+					{ID: entity.NewID("U1"), Name: "gram", BaseType: "mass", System: "metric"},
+					{ID: entity.NewID("U2"), Name: "ounce", BaseType: "mass", System: "us"},
+					{ID: entity.NewID("U3"), Name: "liter", BaseType: "volume", System: "metric"},
+				},
+			},
+			options: []client.Option{client.Var("baseType", "mass")},
+			response: map[string]any{
+				"units": []any{
+					map[string]any{"name": "gram"},
+					map[string]any{"name": "ounce"},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			units:    &mock.QueryUnitRepository{AllUnitsErr: errors.New("some random error")},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($baseType: String, $system: String){ units(baseType: $baseType, system: $system) { name }}`,
+				&response,
+				test.options...,
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestQueryConvert(t *testing.T) {
+	t.Parallel()
+
+	gram := &query.Unit{ID: entity.NewID("U1"), Name: "gram", BaseType: "mass"}
+	kilogram := &query.Unit{ID: entity.NewID("U2"), Name: "kilogram", BaseType: "mass"}
+
+	type testCase struct {
+		units    query.UnitRepository
+		from     model.ID
+		query    string
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				GetUnitsResult:          []*query.Unit{gram, kilogram},
+				GetConversionPathResult: []*query.Conversion{{FromID: gram.ID, ToID: kilogram.ID, Ratio: 1000}},
+			},
+			from:  model.NewUnitID(gram.ID),
+			query: `query test($from: ID!, $to: ID!){ convert(quantity: 250, from: $from, to: $to) { __typename ...on ConvertedQuantity { quantity unit { ...on Unit { name }}}}}`,
+			response: map[string]any{
+				"convert": map[string]any{
+					"__typename": "ConvertedQuantity",
+					"quantity":   0.25,
+					"unit":       map[string]any{"name": "kilogram"},
+				},
+			},
+			err: nil,
+		},
+		"wrong kind": {
+			units: &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{gram, kilogram}},
+			from:  model.NewRecipeID(gram.ID),
+			query: `query test($from: ID!, $to: ID!){ convert(quantity: 250, from: $from, to: $to) { __typename ...on ValidationError { errors { field message }}}}`,
+			response: map[string]any{
+				"convert": map[string]any{
+					"__typename": "ValidationError",
+					"errors": []any{
+						map[string]any{"field": "from", "message": "unit does not exist"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			units:    &mock.QueryUnitRepository{GetUnitsErr: errors.New("some random error")},
+			from:     model.NewUnitID(gram.ID),
+			query:    `query test($from: ID!, $to: ID!){ convert(quantity: 250, from: $from, to: $to) { __typename }}`,
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					&mock.QueryRecipeRepository{},
+					test.units,
+					&mock.QueryUserRepository{},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				test.query,
+				&response,
+				client.Var("from", test.from.String()),
+				client.Var("to", model.NewUnitID(kilogram.ID).String()),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.83
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// Favorites is the resolver for the favorites field.
+func (r *userResolver) Favorites(ctx context.Context, obj *model.User, page *model.Page, order *model.Order) (*model.RecipeConnection, error) {
+	isFavorite := true
+
+	pagination, err := model.NewQueryPagination(page)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.FindRecipes(
+		ctx,
+		query.RecipeFilter{IsFavorite: &isFavorite, FavoriteOf: obj.ID.Key},
+		pagination,
+		model.NewQueryOrder(order),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRecipeConnection(result), nil
+}
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+type userResolver struct{ *Resolver }
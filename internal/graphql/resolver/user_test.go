@@ -0,0 +1,89 @@
+package resolver_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/b-sea/supply-run-api/internal/command"
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/metrics"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryUserFavorites(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		recipes  query.RecipeRepository
+		response map[string]any
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			recipes: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{{ID: entity.NewID("R1")}},
+			},
+			response: map[string]any{
+				"node": map[string]any{
+					"favorites": map[string]any{
+						"edges": []any{
+							map[string]any{"node": map[string]any{"id": "cmVjaXBlOlIx"}},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		"repo error": {
+			recipes:  &mock.QueryRecipeRepository{FindRecipesErr: errors.New("some random error")},
+			response: nil,
+			err:      errors.New("some random error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := graphql.New(
+				query.NewService(
+					test.recipes,
+					&mock.QueryUnitRepository{},
+					&mock.QueryUserRepository{GetUsersResult: []*query.User{{ID: entity.NewID("U1")}}},
+					&mock.QueryShoppingListRepository{},
+					&mock.QueryPantryRepository{},
+					&mock.QueryMealRepository{},
+				),
+				command.NewService(
+					&mock.RecipeRepository{},
+					&mock.UnitRepository{},
+					&mock.UserRepository{},
+					&mock.ShoppingListRepository{},
+					&mock.PantryRepository{},
+					&mock.MealRepository{},
+				),
+				metrics.NewNoOp(),
+			)
+			testClient := client.New(server)
+
+			var response map[string]any
+
+			err := testClient.Post(
+				`query test($id: ID!){ node(id: $id) { ...on User { favorites { edges { node { id }}}}}}`,
+				&response,
+				client.Var("id", model.NewUserID(entity.NewID("U1")).String()),
+			)
+
+			assert.Equal(t, test.response, response)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
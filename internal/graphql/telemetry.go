@@ -15,6 +15,7 @@ import (
 type Recorder interface {
 	ObserveResolverDuration(object string, field string, status string, duration time.Duration)
 	ObserveGraphqlError()
+	ObserveQueryRejected(reason string)
 }
 
 func fieldTelemetry(recorder Recorder) graphql.FieldMiddleware {
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	// maxDocumentSize is the most of a fetched document that is read.
+	maxDocumentSize = 5 << 20
+	maxRedirects    = 5
+	dialTimeout     = 5 * time.Second
+)
+
+// ErrFetch is returned when a document cannot be fetched.
+var ErrFetch = errors.New("fetch error")
+
+// ErrForbiddenAddress is returned when a document is not on the public internet.
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not covered by netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10") //nolint: gochecknoglobals
+
+func fetchError(err error) error {
+	return fmt.Errorf("%w: %w", ErrFetch, err)
+}
+
+// Fetcher retrieves documents from the web.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// HTTPFetcher fetches documents over HTTP.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a new HTTPFetcher using the given client.
+// Only http and https documents are fetched, following at most 5 redirects.
+func NewHTTPFetcher(client *http.Client) *HTTPFetcher {
+	guarded := *client
+	guarded.CheckRedirect = checkRedirect
+
+	return &HTTPFetcher{
+		client: &guarded,
+	}
+}
+
+// NewPublicClient creates an HTTP client that refuses to connect to loopback, private, link-local or otherwise
+// non-public addresses. The check runs on every connection, so it also covers redirects and DNS rebinding.
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: publicOnly,
+	}
+
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+// Fetch retrieves a document, reading at most 5MiB of it.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fetchError(err)
+	}
+
+	if err := checkScheme(request.URL); err != nil {
+		return nil, fetchError(err)
+	}
+
+	request.Header.Set("Accept", "text/html")
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fetchError(err)
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrFetch, response.StatusCode)
+	}
+
+	document, err := io.ReadAll(io.LimitReader(response.Body, maxDocumentSize))
+	if err != nil {
+		return nil, fetchError(err)
+	}
+
+	return document, nil
+}
+
+func checkScheme(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrForbiddenAddress, target.Scheme)
+	}
+
+	return nil
+}
+
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", ErrFetch, maxRedirects)
+	}
+
+	return checkScheme(request.URL)
+}
+
+// publicOnly rejects connections to addresses that are not on the public internet.
+func publicOnly(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+
+	return nil
+}
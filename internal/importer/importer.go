@@ -0,0 +1,134 @@
+// Package importer extracts recipes from external sources.
+package importer
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+)
+
+// Recipe is a recipe extracted from an external source.
+// Ingredients are kept as raw text lines, to be parsed against the known units.
+// Sources that keep ingredients structured also give the unit name of each ingredient in Units.
+type Recipe struct {
+	Name        string
+	URL         string
+	NumServings int
+	Steps       []string
+	Ingredients []string
+	Units       []string
+	Tags        []string
+}
+
+// Unmatched is an imported ingredient that could not be matched against the known units.
+// Unit is the unit name the source gave, when that is what could not be matched.
+type Unmatched struct {
+	Recipe     string
+	Ingredient string
+	Unit       string
+}
+
+// Options creates recipe options from an imported Recipe.
+// The parsed lines are expected to line up with the Recipe ingredients.
+func (r *Recipe) Options(lines []ingredient.Line) []recipe.Option {
+	options := make([]recipe.Option, 0)
+
+	if r.URL != "" {
+		options = append(options, recipe.SetURL(r.URL))
+	}
+
+	if r.NumServings > 0 {
+		options = append(options, recipe.SetNumServings(r.NumServings))
+	}
+
+	for _, step := range r.Steps {
+		options = append(options, recipe.AddStep(step))
+	}
+
+	for _, line := range lines {
+		options = append(options, recipe.AddIngredient(line.Name, line.Amount(), line.UnitID))
+	}
+
+	for _, tag := range r.Tags {
+		options = append(options, recipe.AddTag(tag))
+	}
+
+	return options
+}
+
+// Unmatched lists the ingredients left without a name, or whose unit from the source is not a known unit,
+// once parsed into lines.
+func (r *Recipe) Unmatched(lines []ingredient.Line) []Unmatched {
+	result := make([]Unmatched, 0)
+
+	for i, line := range lines {
+		unitName := ""
+		if i < len(r.Units) && r.Units[i] != "" && line.UnitID.String() == "" {
+			unitName = r.Units[i]
+		}
+
+		if line.Name != "" && unitName == "" {
+			continue
+		}
+
+		result = append(result, Unmatched{Recipe: r.Name, Ingredient: line.Text, Unit: unitName})
+	}
+
+	return result
+}
+
+// Importer extracts recipes from HTML documents.
+type Importer struct {
+	fetcher Fetcher
+}
+
+// New creates a new Importer that fetches documents with the given Fetcher.
+func New(fetcher Fetcher) *Importer {
+	return &Importer{
+		fetcher: fetcher,
+	}
+}
+
+// Import extracts a recipe from an HTML document, fetching the document from the url when none is given.
+// Documents that cannot be fetched or contain no recipe are reported as validation errors.
+func (i *Importer) Import(ctx context.Context, source string, document []byte) (*Recipe, error) {
+	field := "html"
+
+	if document == nil {
+		field = "url"
+
+		if source == "" {
+			return nil, validationError(field, "a url or html document is required")
+		}
+
+		parsed, err := url.Parse(source)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, validationError(field, "url must be an http or https address")
+		}
+
+		document, err = i.fetcher.Fetch(ctx, source)
+		if err != nil {
+			return nil, validationError(field, "document could not be fetched")
+		}
+	}
+
+	result := FromHTML(document)
+	if result == nil {
+		return nil, validationError(field, "document does not contain a recipe")
+	}
+
+	if source != "" {
+		result.URL = source
+	}
+
+	return result, nil
+}
+
+func validationError(field string, message string) error {
+	return &entity.ValidationError{
+		InnerErrors: []error{entity.NewFieldError(field, message)},
+	}
+}
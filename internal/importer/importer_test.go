@@ -0,0 +1,204 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/stretchr/testify/assert"
+)
+
+const pancakes = `<script type="application/ld+json">{"@type": "Recipe", "name": "Pancakes"}</script>`
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		fetcher  importer.Fetcher
+		source   string
+		document []byte
+		result   *importer.Recipe
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"fetched": {
+			fetcher: &mock.Fetcher{FetchResult: []byte(pancakes)},
+			source:  "https://example.com/pancakes",
+			result: &importer.Recipe{
+				Name:        "Pancakes",
+				URL:         "https://example.com/pancakes",
+				Steps:       []string{},
+				Ingredients: []string{},
+				Tags:        []string{},
+			},
+			err: nil,
+		},
+		"uploaded": {
+			fetcher:  &mock.Fetcher{FetchErr: errors.New("should not fetch")},
+			document: []byte(pancakes),
+			result: &importer.Recipe{
+				Name:        "Pancakes",
+				Steps:       []string{},
+				Ingredients: []string{},
+				Tags:        []string{},
+			},
+			err: nil,
+		},
+		"no source": {
+			fetcher: &mock.Fetcher{},
+			result:  nil,
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("url", "a url or html document is required")},
+			},
+		},
+		"bad url": {
+			fetcher: &mock.Fetcher{},
+			source:  "file:///etc/passwd",
+			result:  nil,
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("url", "url must be an http or https address")},
+			},
+		},
+		"fetch error": {
+			fetcher: &mock.Fetcher{FetchErr: errors.New("some random error")},
+			source:  "https://example.com/pancakes",
+			result:  nil,
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("url", "document could not be fetched")},
+			},
+		},
+		"no recipe": {
+			fetcher:  &mock.Fetcher{},
+			document: []byte(`<p>hello</p>`),
+			result:   nil,
+			err: &entity.ValidationError{
+				InnerErrors: []error{entity.NewFieldError("html", "document does not contain a recipe")},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := importer.New(test.fetcher).Import(context.Background(), test.source, test.document)
+
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	imported := &importer.Recipe{
+		Name:        "Pancakes",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix.", "Cook."},
+		Ingredients: []string{"1-2 cups flour", "salt"},
+		Tags:        []string{"breakfast", "Breakfast"},
+	}
+
+	lines := []ingredient.Line{
+		{Name: "flour", Quantity: 1, MaxQuantity: 2, UnitID: entity.NewID("cup")},
+		{Name: "salt", UnitID: entity.NewID("")},
+	}
+
+	result, err := recipe.New(
+		entity.NewID("R1"), imported.Name, time.Now(), entity.NewID("user-1"), imported.Options(lines)...,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/pancakes", result.URL())
+	assert.Equal(t, 4, result.NumServings())
+	assert.Equal(t, []string{"Mix.", "Cook."}, result.Steps())
+	assert.Len(t, result.Ingredients(), 2)
+	assert.Equal(t, float64(2), result.Ingredients()[0].Quantity())
+	assert.Equal(t, entity.NewID("cup"), result.Ingredients()[0].UnitID())
+	assert.Equal(t, float64(1), result.Ingredients()[1].Quantity())
+	assert.Equal(t, []string{"breakfast"}, result.Tags())
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/pancakes":
+			_, _ = writer.Write([]byte(pancakes))
+		case "/moved":
+			http.Redirect(writer, request, "/pancakes", http.StatusFound)
+		case "/loop":
+			http.Redirect(writer, request, "/loop", http.StatusFound)
+		case "/file":
+			http.Redirect(writer, request, "file:///etc/passwd", http.StatusFound)
+		default:
+			http.NotFound(writer, request)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := importer.NewHTTPFetcher(server.Client())
+
+	document, err := fetcher.Fetch(context.Background(), server.URL+"/pancakes")
+	assert.NoError(t, err)
+	assert.Equal(t, pancakes, string(document))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	assert.ErrorIs(t, err, importer.ErrFetch)
+
+	_, err = fetcher.Fetch(context.Background(), "http://%zz")
+	assert.ErrorIs(t, err, importer.ErrFetch)
+
+	// Follow a few redirects
+	document, err = fetcher.Fetch(context.Background(), server.URL+"/moved")
+	assert.NoError(t, err)
+	assert.Equal(t, pancakes, string(document))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/loop")
+	assert.ErrorIs(t, err, importer.ErrFetch)
+
+	// Only fetch web documents
+	_, err = fetcher.Fetch(context.Background(), "file:///etc/passwd")
+	assert.ErrorIs(t, err, importer.ErrForbiddenAddress)
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/file")
+	assert.ErrorIs(t, err, importer.ErrForbiddenAddress)
+}
+
+func TestPublicClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(pancakes))
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	fetcher := importer.NewHTTPFetcher(importer.NewPublicClient(time.Second))
+
+	for _, url := range []string{
+		server.URL,
+		"http://localhost:" + port,
+		"http://10.0.0.1/",
+		"http://192.168.1.1/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://100.64.0.1/",
+		"http://0.0.0.0/",
+		"http://[::1]/",
+		"http://[fe80::1]/",
+		"http://[::ffff:127.0.0.1]/",
+	} {
+		_, err := fetcher.Fetch(context.Background(), url)
+		assert.ErrorIs(t, err, importer.ErrForbiddenAddress, url)
+	}
+}
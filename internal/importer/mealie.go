@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Mealie reads Mealie JSON exports.
+// An export may hold a single recipe, a list of recipes, or a page of recipes under "items".
+type Mealie struct{}
+
+type mealieName struct {
+	Name string `json:"name"`
+}
+
+type mealieIngredient struct {
+	Quantity      float64     `json:"quantity"`
+	Unit          *mealieName `json:"unit"`
+	Food          *mealieName `json:"food"`
+	Note          string      `json:"note"`
+	OriginalText  string      `json:"originalText"`
+	Display       string      `json:"display"`
+	DisableAmount bool        `json:"disableAmount"`
+}
+
+type mealieRecipe struct {
+	Name               string             `json:"name"`
+	OrgURL             string             `json:"orgURL"`
+	RecipeServings     float64            `json:"recipeServings"`
+	RecipeYield        json.RawMessage    `json:"recipeYield"`
+	RecipeIngredient   []mealieIngredient `json:"recipeIngredient"`
+	RecipeInstructions []struct {
+		Text string `json:"text"`
+	} `json:"recipeInstructions"`
+	Tags           []mealieName `json:"tags"`
+	RecipeCategory []mealieName `json:"recipeCategory"`
+}
+
+// Recipes reads every recipe in a Mealie export.
+func (m *Mealie) Recipes(export []byte) ([]*Recipe, error) {
+	data := make([]mealieRecipe, 0)
+	trimmed := bytes.TrimSpace(export)
+
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &data); err != nil {
+			return nil, exportError(err)
+		}
+	} else {
+		var page struct {
+			Items []mealieRecipe `json:"items"`
+		}
+
+		if err := json.Unmarshal(trimmed, &page); err != nil {
+			return nil, exportError(err)
+		}
+
+		data = page.Items
+
+		if page.Items == nil {
+			var single mealieRecipe
+			if err := json.Unmarshal(trimmed, &single); err != nil {
+				return nil, exportError(err)
+			}
+
+			data = append(data, single)
+		}
+	}
+
+	result := make([]*Recipe, len(data))
+	for i := range data {
+		result[i] = m.recipe(data[i])
+	}
+
+	return result, nil
+}
+
+func (m *Mealie) recipe(data mealieRecipe) *Recipe {
+	result := &Recipe{
+		Name:        cleanText(data.Name),
+		URL:         cleanText(data.OrgURL),
+		NumServings: int(data.RecipeServings),
+		Steps:       make([]string, 0, len(data.RecipeInstructions)),
+		Ingredients: make([]string, 0, len(data.RecipeIngredient)),
+		Units:       make([]string, 0, len(data.RecipeIngredient)),
+		Tags:        make([]string, 0, len(data.Tags)+len(data.RecipeCategory)),
+	}
+
+	if result.NumServings <= 0 {
+		result.NumServings = parseYield(jsonStrings(rawJSON(data.RecipeYield)))
+	}
+
+	for _, step := range data.RecipeInstructions {
+		result.Steps = append(result.Steps, splitLines(step.Text)...)
+	}
+
+	for _, item := range data.RecipeIngredient {
+		text, unitName := mealieIngredientText(item)
+		if text == "" {
+			continue
+		}
+
+		result.Ingredients = append(result.Ingredients, text)
+		result.Units = append(result.Units, unitName)
+	}
+
+	for _, tag := range append(data.RecipeCategory, data.Tags...) {
+		result.Tags = append(result.Tags, cleanTexts([]string{tag.Name})...)
+	}
+
+	return result
+}
+
+// mealieIngredientText builds an ingredient line, using the structured food and unit unless amounts are disabled.
+func mealieIngredientText(item mealieIngredient) (string, string) {
+	if item.DisableAmount || item.Food == nil {
+		for _, text := range []string{item.Note, item.OriginalText, item.Display} {
+			if text = cleanText(text); text != "" {
+				return text, ""
+			}
+		}
+
+		return "", ""
+	}
+
+	unitName := ""
+	if item.Unit != nil {
+		unitName = cleanText(item.Unit.Name)
+	}
+
+	return ingredientText(item.Quantity, unitName, cleanText(item.Food.Name), cleanText(item.Note)), unitName
+}
+
+// rawJSON decodes a raw JSON value, ignoring anything that is not valid.
+func rawJSON(raw json.RawMessage) any {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+
+	return value
+}
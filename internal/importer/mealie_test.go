@@ -0,0 +1,90 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+const mealiePancakes = `{
+	"name": "Pancakes",
+	"orgURL": "https://example.com/pancakes",
+	"recipeServings": 0,
+	"recipeYield": "4 servings",
+	"recipeIngredient": [
+		{"quantity": 1.5, "unit": {"name": "cup"}, "food": {"name": "flour"}, "note": "sifted"},
+		{"quantity": 2, "unit": null, "food": {"name": "eggs"}, "note": ""},
+		{"quantity": 1, "unit": {"name": "pinch"}, "food": {"name": "salt"}, "note": ""},
+		{"disableAmount": true, "note": "butter for the pan", "display": "butter for the pan"},
+		{"quantity": 0, "food": null, "note": ""}
+	],
+	"recipeInstructions": [{"text": "Mix everything."}, {"text": "Cook."}],
+	"tags": [{"name": "quick"}],
+	"recipeCategory": [{"name": "Breakfast"}]
+}`
+
+func TestMealie(t *testing.T) {
+	t.Parallel()
+
+	pancakes := &importer.Recipe{
+		Name:        "Pancakes",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix everything.", "Cook."},
+		Ingredients: []string{"1.5 cup flour, sifted", "2 eggs", "1 pinch salt", "butter for the pan"},
+		Units:       []string{"cup", "", "pinch", ""},
+		Tags:        []string{"Breakfast", "quick"},
+	}
+
+	type testCase struct {
+		export string
+		result []*importer.Recipe
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"single recipe": {
+			export: mealiePancakes,
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"list": {
+			export: "[" + mealiePancakes + "," + mealiePancakes + "]",
+			result: []*importer.Recipe{pancakes, pancakes},
+			err:    nil,
+		},
+		"page": {
+			export: `{"page": 1, "items": [` + mealiePancakes + `]}`,
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"servings": {
+			export: `{"name": "Toast", "recipeServings": 2, "recipeYield": "4 slices"}`,
+			result: []*importer.Recipe{{
+				Name:        "Toast",
+				NumServings: 2,
+				Steps:       []string{},
+				Ingredients: []string{},
+				Units:       []string{},
+				Tags:        []string{},
+			}},
+			err: nil,
+		},
+		"bad json": {
+			export: `[{"name": `,
+			result: nil,
+			err:    importer.ErrExport,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := (&importer.Mealie{}).Recipes([]byte(test.export))
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+)
+
+// Paprika reads Paprika exports.
+// A .paprikarecipes export is a zip of gzipped JSON recipes, and a single .paprikarecipe is one gzipped JSON recipe.
+type Paprika struct{}
+
+type paprikaRecipe struct {
+	Name        string   `json:"name"`
+	SourceURL   string   `json:"source_url"`
+	Servings    string   `json:"servings"`
+	Ingredients string   `json:"ingredients"`
+	Directions  string   `json:"directions"`
+	Categories  []string `json:"categories"`
+}
+
+// Recipes reads every recipe in a Paprika export.
+func (p *Paprika) Recipes(export []byte) ([]*Recipe, error) {
+	if !bytes.HasPrefix(export, []byte("PK")) {
+		found, err := p.recipe(export)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*Recipe{found}, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(export), int64(len(export)))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	result := make([]*Recipe, 0, len(archive.File))
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		document, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := p.recipe(document)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, found)
+	}
+
+	return result, nil
+}
+
+func (p *Paprika) recipe(document []byte) (*Recipe, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(document))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	var data paprikaRecipe
+	if err := json.NewDecoder(io.LimitReader(reader, maxDocumentSize)).Decode(&data); err != nil {
+		return nil, exportError(err)
+	}
+
+	return &Recipe{
+		Name:        cleanText(data.Name),
+		URL:         cleanText(data.SourceURL),
+		NumServings: parseYield([]string{data.Servings}),
+		Steps:       splitLines(data.Directions),
+		Ingredients: splitLines(data.Ingredients),
+		Units:       nil,
+		Tags:        cleanTexts(data.Categories),
+	}, nil
+}
+
+// readZipFile reads at most maxDocumentSize of a file in a zip archive.
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	document, err := io.ReadAll(io.LimitReader(reader, maxDocumentSize))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,70 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+const paprikaPancakes = `{
+	"uid": "ABC-123",
+	"name": "Pancakes",
+	"source_url": "https://example.com/pancakes",
+	"servings": "4 servings",
+	"ingredients": "1 1/2 cups flour\n2 eggs\n\n1 cup milk",
+	"directions": "Mix everything.\n\nCook on a hot pan.",
+	"categories": ["Breakfast", "Quick"]
+}`
+
+func TestPaprika(t *testing.T) {
+	t.Parallel()
+
+	pancakes := &importer.Recipe{
+		Name:        "Pancakes",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix everything.", "Cook on a hot pan."},
+		Ingredients: []string{"1 1/2 cups flour", "2 eggs", "1 cup milk"},
+		Tags:        []string{"Breakfast", "Quick"},
+	}
+
+	type testCase struct {
+		export []byte
+		result []*importer.Recipe
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"archive": {
+			export: zipFiles(t, map[string][]byte{"Pancakes.paprikarecipe": gzipFile(t, paprikaPancakes)}),
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"single recipe": {
+			export: gzipFile(t, paprikaPancakes),
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"not gzipped": {
+			export: zipFiles(t, map[string][]byte{"Pancakes.paprikarecipe": []byte(paprikaPancakes)}),
+			result: nil,
+			err:    importer.ErrExport,
+		},
+		"bad json": {
+			export: gzipFile(t, `{"name": `),
+			result: nil,
+			err:    importer.ErrExport,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := (&importer.Paprika{}).Recipes(test.export)
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
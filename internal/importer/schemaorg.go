@@ -0,0 +1,329 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	tagPattern         = regexp.MustCompile(`<[^>]*>`)        //nolint: gochecknoglobals
+	numberPattern      = regexp.MustCompile(`\d+`)            //nolint: gochecknoglobals
+	punctuationPattern = regexp.MustCompile(`\s+([.,;:!?)])`) //nolint: gochecknoglobals
+)
+
+// FromHTML extracts a schema.org Recipe from an HTML document.
+// JSON-LD is preferred over microdata when a document has both.
+// It returns nil when the document contains no recipe.
+func FromHTML(document []byte) *Recipe {
+	root, err := html.Parse(bytes.NewReader(document))
+	if err != nil {
+		return nil
+	}
+
+	if result := fromJSONLD(root); result != nil {
+		return result
+	}
+
+	return fromMicrodata(root)
+}
+
+// fromJSONLD looks through every JSON-LD script for a Recipe object.
+func fromJSONLD(root *html.Node) *Recipe {
+	for node := range root.Descendants() {
+		if node.Type != html.ElementNode || node.DataAtom != atom.Script {
+			continue
+		}
+
+		if !strings.EqualFold(strings.TrimSpace(attribute(node, "type")), "application/ld+json") {
+			continue
+		}
+
+		var data any
+		if err := json.Unmarshal([]byte(textContent(node)), &data); err != nil {
+			continue
+		}
+
+		if found := findRecipeObject(data); found != nil {
+			return newJSONLDRecipe(found)
+		}
+	}
+
+	return nil
+}
+
+// findRecipeObject searches JSON-LD data, including any @graph, for an object typed as a Recipe.
+func findRecipeObject(data any) map[string]any {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			if found := findRecipeObject(item); found != nil {
+				return found
+			}
+		}
+	case map[string]any:
+		for _, kind := range jsonStrings(value["@type"]) {
+			if isRecipeType(kind) {
+				return value
+			}
+		}
+
+		if found := findRecipeObject(value["@graph"]); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func newJSONLDRecipe(data map[string]any) *Recipe {
+	ingredients := jsonStrings(data["recipeIngredient"])
+	if len(ingredients) == 0 {
+		ingredients = jsonStrings(data["ingredients"])
+	}
+
+	return &Recipe{
+		Name:        firstText(jsonStrings(data["name"])),
+		URL:         firstText(jsonStrings(data["url"])),
+		NumServings: parseYield(jsonStrings(data["recipeYield"])),
+		Steps:       jsonSteps(data["recipeInstructions"]),
+		Ingredients: cleanTexts(ingredients),
+		Tags:        splitKeywords(jsonStrings(data["keywords"])),
+	}
+}
+
+// jsonStrings flattens a JSON-LD value into its strings, whether it is a single value or a list.
+func jsonStrings(data any) []string {
+	switch value := data.(type) {
+	case string:
+		return []string{value}
+	case float64:
+		return []string{strconv.FormatFloat(value, 'f', -1, 64)}
+	case []any:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			result = append(result, jsonStrings(item)...)
+		}
+
+		return result
+	case map[string]any:
+		if text, ok := value["text"]; ok {
+			return jsonStrings(text)
+		}
+
+		return jsonStrings(value["name"])
+	default:
+		return nil
+	}
+}
+
+// jsonSteps reads recipe instructions given as text, a list of text, HowToSteps or HowToSections.
+func jsonSteps(data any) []string {
+	switch value := data.(type) {
+	case string:
+		return cleanTexts(strings.Split(tagPattern.ReplaceAllString(value, "\n"), "\n"))
+	case []any:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			result = append(result, jsonSteps(item)...)
+		}
+
+		return result
+	case map[string]any:
+		if items, ok := value["itemListElement"]; ok {
+			return jsonSteps(items)
+		}
+
+		return cleanTexts(jsonStrings(value))
+	default:
+		return []string{}
+	}
+}
+
+// fromMicrodata looks for the first element scoped as a Recipe and reads its properties.
+func fromMicrodata(root *html.Node) *Recipe {
+	for node := range root.Descendants() {
+		if node.Type != html.ElementNode || !hasAttribute(node, "itemscope") {
+			continue
+		}
+
+		if !isRecipeType(attribute(node, "itemtype")) {
+			continue
+		}
+
+		properties := make(map[string][]string)
+		readProperties(node, properties)
+
+		ingredients := properties["recipeIngredient"]
+		if len(ingredients) == 0 {
+			ingredients = properties["ingredients"]
+		}
+
+		return &Recipe{
+			Name:        firstText(properties["name"]),
+			URL:         firstText(properties["url"]),
+			NumServings: parseYield(properties["recipeYield"]),
+			Steps:       cleanTexts(properties["recipeInstructions"]),
+			Ingredients: cleanTexts(ingredients),
+			Tags:        splitKeywords(properties["keywords"]),
+		}
+	}
+
+	return nil
+}
+
+// readProperties collects the item properties below a scope, without descending into nested scopes.
+func readProperties(scope *html.Node, properties map[string][]string) {
+	for child := scope.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		names := strings.Fields(attribute(child, "itemprop"))
+		for _, name := range names {
+			properties[name] = append(properties[name], propertyValues(child)...)
+		}
+
+		if len(names) == 0 && !hasAttribute(child, "itemscope") {
+			readProperties(child, properties)
+		}
+	}
+}
+
+// propertyValues reads the value of an item property.
+// Nested items use their own text property, and lists have one value per list item.
+func propertyValues(node *html.Node) []string {
+	switch node.DataAtom { //nolint: exhaustive
+	case atom.Meta:
+		return []string{attribute(node, "content")}
+	case atom.A, atom.Link:
+		return []string{attribute(node, "href")}
+	case atom.Data, atom.Meter:
+		return []string{attribute(node, "value")}
+	case atom.Time:
+		if hasAttribute(node, "datetime") {
+			return []string{attribute(node, "datetime")}
+		}
+	}
+
+	if hasAttribute(node, "itemscope") {
+		nested := make(map[string][]string)
+		readProperties(node, nested)
+
+		if text, ok := nested["text"]; ok {
+			return text
+		}
+	}
+
+	items := make([]string, 0)
+
+	for child := range node.Descendants() {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Li {
+			items = append(items, textContent(child))
+		}
+	}
+
+	if len(items) > 0 {
+		return items
+	}
+
+	return []string{textContent(node)}
+}
+
+func isRecipeType(kind string) bool {
+	kind = strings.TrimSuffix(strings.TrimSpace(kind), "/")
+
+	return kind == "Recipe" || strings.HasSuffix(kind, "schema.org/Recipe")
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+func hasAttribute(node *html.Node, name string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textContent joins all text below a node.
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+
+	for child := range node.Descendants() {
+		if child.Type == html.TextNode {
+			builder.WriteString(child.Data)
+			builder.WriteString(" ")
+		}
+	}
+
+	return builder.String()
+}
+
+// cleanText strips markup and entities and collapses whitespace.
+func cleanText(value string) string {
+	value = strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(value, " "))), " ")
+
+	return punctuationPattern.ReplaceAllString(value, "$1")
+}
+
+// cleanTexts cleans every value, dropping any left empty.
+func cleanTexts(values []string) []string {
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value = cleanText(value); value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func firstText(values []string) string {
+	for _, value := range cleanTexts(values) {
+		return value
+	}
+
+	return ""
+}
+
+// parseYield reads the number of servings from the first yield that has one, like "4" or "Serves 4-6".
+func parseYield(values []string) int {
+	for _, value := range values {
+		if match := numberPattern.FindString(value); match != "" {
+			result, err := strconv.Atoi(match)
+			if err == nil && result > 0 {
+				return result
+			}
+		}
+	}
+
+	return 0
+}
+
+// splitKeywords splits comma separated keywords into tags.
+func splitKeywords(values []string) []string {
+	result := make([]string, 0)
+
+	for _, value := range values {
+		result = append(result, cleanTexts(strings.Split(value, ","))...)
+	}
+
+	return result
+}
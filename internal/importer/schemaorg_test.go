@@ -0,0 +1,158 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHTML(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		document string
+		result   *importer.Recipe
+	}
+
+	tests := map[string]testCase{
+		"json-ld": {
+			document: `<html><head>
+				<script type="application/ld+json">{
+					"@context": "https://schema.org",
+					"@type": "Recipe",
+					"name": "Pancakes &amp; Syrup",
+					"url": "https://example.com/pancakes",
+					"recipeYield": ["4", "4 servings"],
+					"recipeIngredient": ["1 1/2 cups flour", " 2  eggs "],
+					"recipeInstructions": [
+						{"@type": "HowToStep", "text": "Mix <b>everything</b>."},
+						{"@type": "HowToStep", "text": "Cook."}
+					],
+					"keywords": "breakfast, sweet"
+				}</script>
+			</head><body></body></html>`,
+			result: &importer.Recipe{
+				Name:        "Pancakes & Syrup",
+				URL:         "https://example.com/pancakes",
+				NumServings: 4,
+				Steps:       []string{"Mix everything.", "Cook."},
+				Ingredients: []string{"1 1/2 cups flour", "2 eggs"},
+				Tags:        []string{"breakfast", "sweet"},
+			},
+		},
+		"json-ld graph": {
+			document: `<script type="application/ld+json">{"@graph": [
+				{"@type": "WebPage", "name": "Not a recipe"},
+				{
+					"@type": ["Recipe", "NewsArticle"],
+					"name": "Soup",
+					"recipeYield": "Serves 6-8",
+					"ingredients": ["water"],
+					"recipeInstructions": [{
+						"@type": "HowToSection",
+						"name": "Prep",
+						"itemListElement": [{"@type": "HowToStep", "text": "Boil."}, "Season."]
+					}],
+					"keywords": ["soup", "easy"]
+				}
+			]}</script>`,
+			result: &importer.Recipe{
+				Name:        "Soup",
+				URL:         "",
+				NumServings: 6,
+				Steps:       []string{"Boil.", "Season."},
+				Ingredients: []string{"water"},
+				Tags:        []string{"soup", "easy"},
+			},
+		},
+		"json-ld text instructions": {
+			document: `<script type="application/ld+json">[{
+				"@type": "http://schema.org/Recipe",
+				"name": "Toast",
+				"recipeYield": 2,
+				"recipeInstructions": "Toast the bread.\nButter it.<br>Eat."
+			}]</script>`,
+			result: &importer.Recipe{
+				Name:        "Toast",
+				URL:         "",
+				NumServings: 2,
+				Steps:       []string{"Toast the bread.", "Butter it.", "Eat."},
+				Ingredients: []string{},
+				Tags:        []string{},
+			},
+		},
+		"microdata": {
+			document: `<div itemscope itemtype="https://schema.org/Recipe">
+				<h1 itemprop="name">Omelette</h1>
+				<link itemprop="url" href="https://example.com/omelette">
+				<meta itemprop="recipeYield" content="1">
+				<meta itemprop="keywords" content="eggs,quick">
+				<ul>
+					<li itemprop="recipeIngredient">3 eggs</li>
+					<li itemprop="recipeIngredient">1 T butter</li>
+				</ul>
+				<ol itemprop="recipeInstructions">
+					<li>Whisk the eggs.</li>
+					<li>Fry in butter.</li>
+				</ol>
+				<div itemprop="review" itemscope itemtype="https://schema.org/Review">
+					<span itemprop="name">Great</span>
+				</div>
+			</div>`,
+			result: &importer.Recipe{
+				Name:        "Omelette",
+				URL:         "https://example.com/omelette",
+				NumServings: 1,
+				Steps:       []string{"Whisk the eggs.", "Fry in butter."},
+				Ingredients: []string{"3 eggs", "1 T butter"},
+				Tags:        []string{"eggs", "quick"},
+			},
+		},
+		"microdata steps": {
+			document: `<div itemscope itemtype="http://schema.org/Recipe">
+				<span itemprop="name">Tea</span>
+				<div itemprop="recipeInstructions" itemscope itemtype="http://schema.org/HowToStep">
+					<span itemprop="text">Boil water.</span>
+				</div>
+				<div itemprop="recipeInstructions" itemscope itemtype="http://schema.org/HowToStep">
+					<span itemprop="text">Steep.</span>
+				</div>
+			</div>`,
+			result: &importer.Recipe{
+				Name:        "Tea",
+				URL:         "",
+				NumServings: 0,
+				Steps:       []string{"Boil water.", "Steep."},
+				Ingredients: []string{},
+				Tags:        []string{},
+			},
+		},
+		"json-ld over microdata": {
+			document: `<div itemscope itemtype="https://schema.org/Recipe"><span itemprop="name">Microdata</span></div>
+				<script type="application/ld+json">{"@type": "Recipe", "name": "JSON-LD"}</script>`,
+			result: &importer.Recipe{
+				Name:        "JSON-LD",
+				URL:         "",
+				NumServings: 0,
+				Steps:       []string{},
+				Ingredients: []string{},
+				Tags:        []string{},
+			},
+		},
+		"bad json-ld": {
+			document: `<script type="application/ld+json">{"@type": "Recipe",</script>`,
+			result:   nil,
+		},
+		"no recipe": {
+			document: `<script type="application/ld+json">{"@type": "WebPage"}</script><p>Nothing here</p>`,
+			result:   nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.result, importer.FromHTML([]byte(test.document)))
+		})
+	}
+}
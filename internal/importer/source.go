@@ -0,0 +1,61 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownSource is returned when there is no Source with a given name.
+	ErrUnknownSource = errors.New("unknown import source")
+	// ErrExport is returned when an export cannot be read.
+	ErrExport = errors.New("export error")
+)
+
+func exportError(err error) error {
+	return fmt.Errorf("%w: %w", ErrExport, err)
+}
+
+// Source reads recipes out of the export of another recipe manager.
+type Source interface {
+	Recipes(export []byte) ([]*Recipe, error)
+}
+
+// NewSource returns the Source for a recipe manager: paprika, mealie or tandoor.
+func NewSource(name string) (Source, error) { //nolint: ireturn
+	switch strings.ToLower(name) {
+	case "paprika":
+		return &Paprika{}, nil
+	case "mealie":
+		return &Mealie{}, nil
+	case "tandoor":
+		return &Tandoor{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSource, name)
+	}
+}
+
+// ingredientText writes a structured ingredient as a line the ingredient parser understands.
+func ingredientText(quantity float64, unit string, name string, note string) string {
+	parts := make([]string, 0)
+
+	if quantity > 0 {
+		parts = append(parts, strconv.FormatFloat(quantity, 'f', -1, 64))
+	}
+
+	parts = append(parts, strings.TrimSpace(unit), strings.TrimSpace(name))
+	text := strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+
+	if note = strings.TrimSpace(note); note != "" {
+		text += ", " + note
+	}
+
+	return text
+}
+
+// splitLines splits text into its non-empty lines.
+func splitLines(text string) []string {
+	return cleanTexts(strings.Split(text, "\n"))
+}
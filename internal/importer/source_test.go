@@ -0,0 +1,103 @@
+package importer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/stretchr/testify/assert"
+)
+
+// zipFiles builds a zip archive holding the given files.
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	writer := zip.NewWriter(&buffer)
+
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := file.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+// gzipFile compresses a single file.
+func gzipFile(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	writer := gzip.NewWriter(&buffer)
+
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestNewSource(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]importer.Source{
+		"paprika": &importer.Paprika{},
+		"Mealie":  &importer.Mealie{},
+		"TANDOOR": &importer.Tandoor{},
+	} {
+		source, err := importer.NewSource(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, source)
+	}
+
+	_, err := importer.NewSource("cookbook")
+	assert.ErrorIs(t, err, importer.ErrUnknownSource)
+}
+
+func TestUnmatched(t *testing.T) {
+	t.Parallel()
+
+	imported := &importer.Recipe{
+		Name:        "Pancakes",
+		Ingredients: []string{"2 cup flour", "1 pinch salt", "3", "2 eggs"},
+		Units:       []string{"cup", "pinch", "", ""},
+	}
+
+	lines := []ingredient.Line{
+		{Text: "2 cup flour", Quantity: 2, MaxQuantity: 2, UnitID: entity.NewID("cup"), Name: "flour"},
+		{Text: "1 pinch salt", Quantity: 1, MaxQuantity: 1, UnitID: entity.NewID(""), Name: "pinch salt"},
+		{Text: "3", Quantity: 3, MaxQuantity: 3, UnitID: entity.NewID(""), Name: ""},
+		{Text: "2 eggs", Quantity: 2, MaxQuantity: 2, UnitID: entity.NewID(""), Name: "eggs"},
+	}
+
+	assert.Equal(t, []importer.Unmatched{
+		{Recipe: "Pancakes", Ingredient: "1 pinch salt", Unit: "pinch"},
+		{Recipe: "Pancakes", Ingredient: "3", Unit: ""},
+	}, imported.Unmatched(lines))
+
+	// Text only sources have no units to miss
+	imported.Units = nil
+	assert.Equal(t, []importer.Unmatched{
+		{Recipe: "Pancakes", Ingredient: "3", Unit: ""},
+	}, imported.Unmatched(lines))
+}
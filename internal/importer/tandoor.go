@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// Tandoor reads Tandoor zip exports.
+// The export is a zip of one zip per recipe, each holding a recipe.json.
+type Tandoor struct{}
+
+type tandoorName struct {
+	Name string `json:"name"`
+}
+
+type tandoorIngredient struct {
+	Food     *tandoorName `json:"food"`
+	Unit     *tandoorName `json:"unit"`
+	Amount   float64      `json:"amount"`
+	Note     string       `json:"note"`
+	IsHeader bool         `json:"is_header"`
+	NoAmount bool         `json:"no_amount"`
+}
+
+type tandoorRecipe struct {
+	Name      string        `json:"name"`
+	SourceURL string        `json:"source_url"`
+	Servings  int           `json:"servings"`
+	Keywords  []tandoorName `json:"keywords"`
+	Steps     []struct {
+		Instruction string              `json:"instruction"`
+		Ingredients []tandoorIngredient `json:"ingredients"`
+	} `json:"steps"`
+}
+
+// Recipes reads every recipe in a Tandoor export.
+func (t *Tandoor) Recipes(export []byte) ([]*Recipe, error) {
+	archive, err := zip.NewReader(bytes.NewReader(export), int64(len(export)))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	result := make([]*Recipe, 0, len(archive.File))
+
+	for _, file := range archive.File {
+		switch {
+		case strings.EqualFold(path.Ext(file.Name), ".zip"):
+			document, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			found, err := t.Recipes(document)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, found...)
+		case path.Base(file.Name) == "recipe.json":
+			document, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			found, err := t.recipe(document)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, found)
+		}
+	}
+
+	return result, nil
+}
+
+func (t *Tandoor) recipe(document []byte) (*Recipe, error) {
+	var data tandoorRecipe
+	if err := json.Unmarshal(document, &data); err != nil {
+		return nil, exportError(err)
+	}
+
+	result := &Recipe{
+		Name:        cleanText(data.Name),
+		URL:         cleanText(data.SourceURL),
+		NumServings: data.Servings,
+		Steps:       make([]string, 0, len(data.Steps)),
+		Ingredients: make([]string, 0),
+		Units:       make([]string, 0),
+		Tags:        make([]string, 0, len(data.Keywords)),
+	}
+
+	for _, step := range data.Steps {
+		result.Steps = append(result.Steps, splitLines(step.Instruction)...)
+
+		for _, item := range step.Ingredients {
+			if item.IsHeader || item.Food == nil {
+				continue
+			}
+
+			unitName, amount := "", item.Amount
+			if item.Unit != nil {
+				unitName = cleanText(item.Unit.Name)
+			}
+
+			if item.NoAmount {
+				unitName, amount = "", 0
+			}
+
+			result.Ingredients = append(
+				result.Ingredients,
+				ingredientText(amount, unitName, cleanText(item.Food.Name), cleanText(item.Note)),
+			)
+			result.Units = append(result.Units, unitName)
+		}
+	}
+
+	for _, keyword := range data.Keywords {
+		result.Tags = append(result.Tags, cleanTexts([]string{keyword.Name})...)
+	}
+
+	return result, nil
+}
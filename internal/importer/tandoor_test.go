@@ -0,0 +1,86 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+const tandoorPancakes = `{
+	"name": "Pancakes",
+	"source_url": "https://example.com/pancakes",
+	"servings": 4,
+	"keywords": [{"name": "breakfast"}],
+	"steps": [
+		{
+			"instruction": "Mix everything.",
+			"ingredients": [
+				{"food": null, "unit": null, "amount": 0, "note": "Batter", "is_header": true},
+				{"food": {"name": "flour"}, "unit": {"name": "cup"}, "amount": 1.5, "note": "sifted"},
+				{"food": {"name": "eggs"}, "unit": null, "amount": 2, "note": ""}
+			]
+		},
+		{
+			"instruction": "Cook.",
+			"ingredients": [
+				{"food": {"name": "butter"}, "unit": {"name": "knob"}, "amount": 1, "note": "", "no_amount": true}
+			]
+		}
+	]
+}`
+
+func TestTandoor(t *testing.T) {
+	t.Parallel()
+
+	pancakes := &importer.Recipe{
+		Name:        "Pancakes",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix everything.", "Cook."},
+		Ingredients: []string{"1.5 cup flour, sifted", "2 eggs", "butter"},
+		Units:       []string{"cup", "", ""},
+		Tags:        []string{"breakfast"},
+	}
+
+	type testCase struct {
+		export []byte
+		result []*importer.Recipe
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"nested": {
+			export: zipFiles(t, map[string][]byte{
+				"1.zip": zipFiles(t, map[string][]byte{"recipe.json": []byte(tandoorPancakes), "image.jpg": {}}),
+			}),
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"flat": {
+			export: zipFiles(t, map[string][]byte{"1/recipe.json": []byte(tandoorPancakes)}),
+			result: []*importer.Recipe{pancakes},
+			err:    nil,
+		},
+		"not a zip": {
+			export: []byte(tandoorPancakes),
+			result: nil,
+			err:    importer.ErrExport,
+		},
+		"bad json": {
+			export: zipFiles(t, map[string][]byte{"recipe.json": []byte(`{"name": `)}),
+			result: nil,
+			err:    importer.ErrExport,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := (&importer.Tandoor{}).Recipes(test.export)
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package ingredient
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Key normalizes an ingredient name for matching, ignoring case, punctuation, spacing and plurals.
+// "Large Eggs" and "large egg" share a key.
+func Key(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i := range words {
+		words[i] = Singular(words[i])
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Matches reports whether an ingredient on hand can be used for a recipe ingredient.
+// Either name may be a key or a plain name. The names match when they are the same,
+// or when the recipe ingredient ends with the ingredient on hand, so "flour" covers "all-purpose flour".
+func Matches(have string, need string) bool {
+	have, need = Key(have), Key(need)
+	if have == "" {
+		return false
+	}
+
+	return need == have || strings.HasSuffix(need, " "+have)
+}
+
+// Singular turns a lowercase English plural into its singular form, like "tomatoes" into "tomato".
+// Words that do not look plural are returned unchanged, except that a final "ie" becomes "y",
+// because "cookies" and "berries" cannot be told apart by their spelling.
+func Singular(word string) string {
+	const minLength = 4
+
+	length := len([]rune(word))
+	if length < minLength {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && length > minLength:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "ie"):
+		return strings.TrimSuffix(word, "ie") + "y"
+	case strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	default:
+		return strings.TrimSuffix(word, "s")
+	}
+}
@@ -0,0 +1,63 @@
+package ingredient_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Eggs":              "egg",
+		"large  EGGS":       "large egg",
+		"all-purpose flour": "all purpose flour",
+		"tomatoes":          "tomato",
+		"berries":           "berry",
+		"cookies":           "cooky",
+		"cookie":            "cooky",
+		"pies":              "pie",
+		"peaches":           "peach",
+		"glass noodles":     "glass noodle",
+		"asparagus":         "asparagus",
+		"":                  "",
+	}
+
+	for name, result := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, result, ingredient.Key(name))
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		have   string
+		need   string
+		result bool
+	}
+
+	tests := map[string]testCase{
+		"same":            {have: "egg", need: "egg", result: true},
+		"plural and case": {have: "Eggs", need: "egg", result: true},
+		"ends with":       {have: "flour", need: "All-Purpose Flour", result: true},
+		"starts with":     {have: "chicken", need: "chicken stock", result: false},
+		"part of a word":  {have: "our", need: "flour", result: false},
+		"different":       {have: "milk", need: "butter", result: false},
+		"blank":           {have: " ", need: "egg", result: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.result, ingredient.Matches(test.have, test.need))
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Package ingredient parses free-text recipe ingredient lines.
+package ingredient
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// vulgarFractions maps unicode fraction characters to their plain text form.
+var vulgarFractions = map[rune]string{ //nolint: gochecknoglobals
+	'½': "1/2",
+	'⅓': "1/3",
+	'⅔': "2/3",
+	'¼': "1/4",
+	'¾': "3/4",
+	'⅕': "1/5",
+	'⅖': "2/5",
+	'⅗': "3/5",
+	'⅘': "4/5",
+	'⅙': "1/6",
+	'⅚': "5/6",
+	'⅛': "1/8",
+	'⅜': "3/8",
+	'⅝': "5/8",
+	'⅞': "7/8",
+}
+
+var (
+	decimalPattern  = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)$`) //nolint: gochecknoglobals
+	fractionPattern = regexp.MustCompile(`^(\d+)/(\d+)$`)         //nolint: gochecknoglobals
+	rangePattern    = regexp.MustCompile(`([\d/.])\s*-\s*(\d)`)   //nolint: gochecknoglobals
+	notePattern     = regexp.MustCompile(`\(([^)]*)\)`)           //nolint: gochecknoglobals
+)
+
+// Line is a parsed ingredient line.
+// Quantities are 0 when the line has none, and MaxQuantity equals Quantity unless the line gives a range.
+type Line struct {
+	Text        string
+	Quantity    float64
+	MaxQuantity float64
+	UnitID      entity.ID
+	Name        string
+	Note        string
+}
+
+// Amount returns how much of the ingredient to keep for a recipe.
+// Ranges use their upper end, so there is always enough, and lines without a quantity count as 1.
+func (l Line) Amount() float64 {
+	if l.MaxQuantity <= 0 {
+		return 1
+	}
+
+	return l.MaxQuantity
+}
+
+// Parser parses ingredient lines, resolving units against a known set.
+type Parser struct {
+	symbols map[string]*unit.Unit
+	names   map[string]*unit.Unit
+	words   int
+}
+
+// NewParser creates a new Parser that recognizes the names, plurals and symbols of the given units.
+// Symbols are matched exactly first, so units like "T" and "t" can be told apart.
+// Everything else is matched case-insensitively, with names and plurals taking priority over symbols.
+func NewParser(units []*unit.Unit) *Parser {
+	parser := &Parser{
+		symbols: make(map[string]*unit.Unit, len(units)),
+		names:   make(map[string]*unit.Unit, len(units)*3), //nolint: mnd
+		words:   1,
+	}
+
+	for _, found := range units {
+		parser.addSymbol(found.Symbol(), found)
+		parser.addName(found.Name(), found)
+		parser.addName(found.Plural(), found)
+	}
+
+	for _, found := range units {
+		parser.addName(found.Symbol(), found)
+	}
+
+	return parser
+}
+
+func (p *Parser) addSymbol(symbol string, found *unit.Unit) {
+	if symbol == "" {
+		return
+	}
+
+	if _, ok := p.symbols[symbol]; !ok {
+		p.symbols[symbol] = found
+	}
+
+	p.words = max(p.words, len(strings.Fields(symbol)))
+}
+
+func (p *Parser) addName(name string, found *unit.Unit) {
+	key := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if key == "" {
+		return
+	}
+
+	if _, ok := p.names[key]; !ok {
+		p.names[key] = found
+	}
+
+	p.words = max(p.words, len(strings.Fields(key)))
+}
+
+// Parse splits an ingredient line like "1 1/2 cups flour, sifted" into its quantity, unit, name and note.
+// Quantities may be whole numbers, decimals, fractions, unicode fractions, mixed numbers or ranges ("2-3", "2 to 3").
+// Anything after the first comma, and anything in parentheses, becomes the note.
+// Units are only recognized directly after a quantity.
+func (p *Parser) Parse(text string) Line {
+	result := Line{
+		Text:        strings.TrimSpace(text),
+		Quantity:    0,
+		MaxQuantity: 0,
+		UnitID:      entity.NewID(""),
+		Name:        "",
+		Note:        "",
+	}
+
+	normalized := normalize(result.Text)
+	notes := make([]string, 0)
+
+	for _, match := range notePattern.FindAllStringSubmatch(normalized, -1) {
+		if value := strings.TrimSpace(match[1]); value != "" {
+			notes = append(notes, value)
+		}
+	}
+
+	head, note, _ := strings.Cut(notePattern.ReplaceAllString(normalized, " "), ",")
+	if value := strings.TrimSpace(note); value != "" {
+		notes = append(notes, value)
+	}
+
+	result.Note = strings.Join(notes, ", ")
+
+	fields := strings.Fields(head)
+
+	low, high, count := parseQuantity(fields)
+	if count > 0 {
+		result.Quantity = low
+		result.MaxQuantity = high
+		fields = fields[count:]
+
+		if found, count := p.parseUnit(fields); found != nil {
+			result.UnitID = found.ID()
+			fields = fields[count:]
+		}
+
+		if len(fields) > 1 && strings.EqualFold(fields[0], "of") {
+			fields = fields[1:]
+		}
+	}
+
+	result.Name = strings.Join(fields, " ")
+
+	return result
+}
+
+// parseUnit matches the longest run of leading fields against the known units.
+func (p *Parser) parseUnit(fields []string) (*unit.Unit, int) {
+	for count := min(p.words, len(fields)); count > 0; count-- {
+		candidate := strings.TrimSuffix(strings.Join(fields[:count], " "), ".")
+
+		if found, ok := p.symbols[candidate]; ok {
+			return found, count
+		}
+
+		if found, ok := p.names[strings.ToLower(candidate)]; ok {
+			return found, count
+		}
+	}
+
+	return nil, 0
+}
+
+// normalize rewrites unicode fractions, fraction slashes and dashes into plain text,
+// and spaces out ranges so every number is its own field.
+func normalize(text string) string {
+	var builder strings.Builder
+
+	for _, char := range text {
+		switch char {
+		case '⁄':
+			builder.WriteRune('/')
+		case '–', '—':
+			builder.WriteRune('-')
+		default:
+			if fraction, ok := vulgarFractions[char]; ok {
+				builder.WriteString(" " + fraction + " ")
+			} else {
+				builder.WriteRune(char)
+			}
+		}
+	}
+
+	return rangePattern.ReplaceAllString(builder.String(), "$1 - $2")
+}
+
+// parseQuantity reads a quantity or range of quantities from the leading fields.
+// It returns the low and high ends and how many fields were used.
+func parseQuantity(fields []string) (float64, float64, int) {
+	low, count := parseAmount(fields)
+	if count == 0 {
+		return 0, 0, 0
+	}
+
+	if len(fields) > count && (fields[count] == "-" || strings.EqualFold(fields[count], "to")) {
+		high, extra := parseAmount(fields[count+1:])
+
+		switch {
+		case extra == 0:
+		case high >= low:
+			return low, high, count + 1 + extra
+		case fields[count] == "-" && count == 1 && high < 1 && !strings.ContainsAny(fields[0], "./"):
+			// A hyphenated mixed number like "1-1/2"
+			return low + high, low + high, count + 1 + extra
+		}
+	}
+
+	return low, low, count
+}
+
+// parseAmount reads a single number, fraction or mixed number from the leading fields.
+func parseAmount(fields []string) (float64, int) {
+	if len(fields) == 0 {
+		return 0, 0
+	}
+
+	value, ok := parseNumber(fields[0])
+	if !ok {
+		return 0, 0
+	}
+
+	if len(fields) > 1 && !strings.ContainsAny(fields[0], "./") && fractionPattern.MatchString(fields[1]) {
+		if fraction, ok := parseNumber(fields[1]); ok {
+			return value + fraction, 2 //nolint: mnd
+		}
+	}
+
+	return value, 1
+}
+
+func parseNumber(field string) (float64, bool) {
+	if decimalPattern.MatchString(field) {
+		value, err := strconv.ParseFloat(field, 64)
+
+		return value, err == nil
+	}
+
+	match := fractionPattern.FindStringSubmatch(field)
+	if match == nil {
+		return 0, false
+	}
+
+	numerator, _ := strconv.ParseFloat(match[1], 64)
+	denominator, _ := strconv.ParseFloat(match[2], 64)
+
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return numerator / denominator, true
+}
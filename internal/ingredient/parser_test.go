@@ -0,0 +1,139 @@
+package ingredient_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+	teaspoon := unit.New("teaspoon", "t", unit.US, unit.Volume)
+	tablespoon := unit.New("tablespoon", "T", unit.US, unit.Volume)
+	fluidOunce := unit.New("fluid ounce", "fl oz", unit.US, unit.Volume)
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+	leaf := unit.New("leaf", "lf", unit.WithCustomPlural("leaves"))
+
+	parser := ingredient.NewParser([]*unit.Unit{cup, teaspoon, tablespoon, fluidOunce, gram, leaf})
+
+	type testCase struct {
+		text   string
+		result ingredient.Line
+	}
+
+	tests := map[string]testCase{
+		"mixed fraction with note": {
+			text: "1 1/2 cups flour, sifted",
+			result: ingredient.Line{
+				Text: "1 1/2 cups flour, sifted", Quantity: 1.5, MaxQuantity: 1.5, UnitID: cup.ID(),
+				Name: "flour", Note: "sifted",
+			},
+		},
+		"unicode fraction": {
+			text: "½ cup sugar",
+			result: ingredient.Line{
+				Text: "½ cup sugar", Quantity: 0.5, MaxQuantity: 0.5, UnitID: cup.ID(), Name: "sugar",
+			},
+		},
+		"unicode mixed fraction": {
+			text: "1¾ Cups milk",
+			result: ingredient.Line{
+				Text: "1¾ Cups milk", Quantity: 1.75, MaxQuantity: 1.75, UnitID: cup.ID(), Name: "milk",
+			},
+		},
+		"hyphenated mixed fraction": {
+			text: "2-1/2 g salt",
+			result: ingredient.Line{
+				Text: "2-1/2 g salt", Quantity: 2.5, MaxQuantity: 2.5, UnitID: gram.ID(), Name: "salt",
+			},
+		},
+		"decimal": {
+			text: "0.25 g saffron",
+			result: ingredient.Line{
+				Text: "0.25 g saffron", Quantity: 0.25, MaxQuantity: 0.25, UnitID: gram.ID(), Name: "saffron",
+			},
+		},
+		"dash range": {
+			text: "2–3 eggs",
+			result: ingredient.Line{
+				Text: "2–3 eggs", Quantity: 2, MaxQuantity: 3, UnitID: entity.NewID(""), Name: "eggs",
+			},
+		},
+		"word range": {
+			text: "1 to 1 1/2 cups of water",
+			result: ingredient.Line{
+				Text: "1 to 1 1/2 cups of water", Quantity: 1, MaxQuantity: 1.5, UnitID: cup.ID(), Name: "water",
+			},
+		},
+		"case sensitive symbols": {
+			text: "1 T butter",
+			result: ingredient.Line{
+				Text: "1 T butter", Quantity: 1, MaxQuantity: 1, UnitID: tablespoon.ID(), Name: "butter",
+			},
+		},
+		"symbol with period": {
+			text: "2 t. vanilla",
+			result: ingredient.Line{
+				Text: "2 t. vanilla", Quantity: 2, MaxQuantity: 2, UnitID: teaspoon.ID(), Name: "vanilla",
+			},
+		},
+		"multiple words": {
+			text: "4 fluid ounces cream",
+			result: ingredient.Line{
+				Text: "4 fluid ounces cream", Quantity: 4, MaxQuantity: 4, UnitID: fluidOunce.ID(), Name: "cream",
+			},
+		},
+		"custom plural": {
+			text: "3 leaves basil",
+			result: ingredient.Line{
+				Text: "3 leaves basil", Quantity: 3, MaxQuantity: 3, UnitID: leaf.ID(), Name: "basil",
+			},
+		},
+		"parentheses": {
+			text: "1 (14 oz, drained) can tomatoes, diced",
+			result: ingredient.Line{
+				Text: "1 (14 oz, drained) can tomatoes, diced", Quantity: 1, MaxQuantity: 1, UnitID: entity.NewID(""),
+				Name: "can tomatoes", Note: "14 oz, drained, diced",
+			},
+		},
+		"no quantity": {
+			text: "  salt, to taste ",
+			result: ingredient.Line{
+				Text: "salt, to taste", UnitID: entity.NewID(""), Name: "salt", Note: "to taste",
+			},
+		},
+		"no quantity unit": {
+			text: "cup of tea",
+			result: ingredient.Line{
+				Text: "cup of tea", UnitID: entity.NewID(""), Name: "cup of tea",
+			},
+		},
+		"unit as name": {
+			text: "2 cups",
+			result: ingredient.Line{
+				Text: "2 cups", Quantity: 2, MaxQuantity: 2, UnitID: cup.ID(),
+			},
+		},
+		"bad fraction": {
+			text: "1/0 eggs",
+			result: ingredient.Line{
+				Text: "1/0 eggs", UnitID: entity.NewID(""), Name: "1/0 eggs",
+			},
+		},
+		"empty": {
+			text:   "",
+			result: ingredient.Line{UnitID: entity.NewID("")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.result, parser.Parse(test.text))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package mealplan defines which recipes are planned for which days.
+package mealplan
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Meal is a recipe planned for a single meal on a single day.
+type Meal struct {
+	id       entity.ID
+	date     time.Time
+	slot     Slot
+	recipeID entity.ID
+	servings int
+
+	createdAt time.Time
+	createdBy entity.ID
+	updatedAt time.Time
+	updatedBy entity.ID
+}
+
+// New creates a new Meal.
+// Meals are planned for dinner unless another slot is given.
+func New(
+	id entity.ID,
+	date time.Time,
+	recipeID entity.ID,
+	timestamp time.Time,
+	userID entity.ID,
+	options ...Option,
+) (*Meal, error) {
+	meal := &Meal{
+		id:        id,
+		date:      time.Time{},
+		slot:      Dinner,
+		recipeID:  entity.NewID(""),
+		servings:  0,
+		createdAt: timestamp,
+		createdBy: userID,
+		updatedAt: timestamp,
+		updatedBy: userID,
+	}
+
+	options = append([]Option{SetDate(date), SetRecipe(recipeID)}, options...)
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		if _, err := option(meal); err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+	}
+
+	if !validation.IsEmpty() {
+		return nil, validation
+	}
+
+	return meal, nil
+}
+
+// Update updates an existing Meal.
+// Updates are only applied if any data actually changes.
+func (m *Meal) Update(timestamp time.Time, userID entity.ID, options ...Option) error {
+	changed := false
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		result, err := option(m)
+		if err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+
+		if !result {
+			continue
+		}
+
+		changed = true
+	}
+
+	if !validation.IsEmpty() {
+		return validation
+	}
+
+	if !changed {
+		return nil
+	}
+
+	m.updatedAt = timestamp
+	m.updatedBy = userID
+
+	return nil
+}
+
+// ID returns the Meal id.
+func (m *Meal) ID() entity.ID {
+	return m.id
+}
+
+// Date returns the day the Meal is planned for, at midnight UTC.
+func (m *Meal) Date() time.Time {
+	return m.date
+}
+
+// Slot returns which meal of the day the Meal is.
+func (m *Meal) Slot() Slot {
+	return m.slot
+}
+
+// RecipeID returns the id of the planned recipe.
+func (m *Meal) RecipeID() entity.ID {
+	return m.recipeID
+}
+
+// Servings returns how many servings are planned.
+// A value of 0 means the recipe's own number of servings.
+func (m *Meal) Servings() int {
+	return m.servings
+}
+
+// CreatedAt returns when the Meal was created.
+func (m *Meal) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// CreatedBy returns the id of the user who created the Meal.
+func (m *Meal) CreatedBy() entity.ID {
+	return m.createdBy
+}
+
+// UpdatedAt returns when the Meal was last updated.
+func (m *Meal) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// UpdatedBy returns the id of the user who last updated the Meal.
+func (m *Meal) UpdatedBy() entity.ID {
+	return m.updatedBy
+}
@@ -0,0 +1,73 @@
+package mealplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMeal(t *testing.T) {
+	t.Parallel()
+
+	// Create a valid meal
+	id := entity.NewID("meal-123")
+	date := time.Date(2025, 3, 4, 18, 30, 0, 0, time.Local)
+	recipeID := entity.NewID("recipe-123")
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	test, err := mealplan.New(id, date, recipeID, timestamp, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, test.ID())
+	assert.Equal(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), test.Date())
+	assert.Equal(t, mealplan.Dinner, test.Slot())
+	assert.Equal(t, recipeID, test.RecipeID())
+	assert.Equal(t, 0, test.Servings())
+	assert.Equal(t, timestamp, test.CreatedAt())
+	assert.Equal(t, userID, test.CreatedBy())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Create a meal with no date or recipe
+	_, err = mealplan.New(entity.NewRandomID(), time.Time{}, entity.NewID(""), time.Now(), entity.NewRandomID())
+
+	var validation *entity.ValidationError
+
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, 2, len(validation.InnerErrors))
+}
+
+func TestUpdateMeal(t *testing.T) {
+	t.Parallel()
+
+	test, err := mealplan.New(entity.NewRandomID(), time.Now(), entity.NewRandomID(), time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Update the meal with a valid slot
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	err = test.Update(timestamp, userID, mealplan.SetSlot(mealplan.Lunch))
+
+	assert.NoError(t, err)
+	assert.Equal(t, mealplan.Lunch, test.Slot())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the meal with the same slot
+	err = test.Update(time.Now(), entity.NewRandomID(), mealplan.SetSlot(mealplan.Lunch))
+
+	assert.NoError(t, err)
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the meal with an invalid slot
+	err = test.Update(time.Now(), entity.NewRandomID(), mealplan.SetSlot("brunch"))
+
+	assert.Error(t, err)
+	assert.Equal(t, mealplan.Lunch, test.Slot())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+}
@@ -0,0 +1,105 @@
+package mealplan
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Option is a Meal create or update option.
+type Option func(m *Meal) (bool, error)
+
+// SetDate sets the day the Meal is planned for.
+// Only the calendar date is kept; the time of day and location are dropped.
+// Error cases:
+//   - Date is zero
+func SetDate(date time.Time) Option {
+	return func(m *Meal) (bool, error) {
+		if date.IsZero() {
+			return false, entity.NewFieldError("date", "meal date cannot be empty")
+		}
+
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+		if m.date.Equal(day) {
+			return false, nil
+		}
+
+		m.date = day
+
+		return true, nil
+	}
+}
+
+// SetSlot sets which meal of the day the Meal is.
+// Error cases:
+//   - Slot is unknown
+func SetSlot(slot Slot) Option {
+	return func(m *Meal) (bool, error) {
+		if !slot.IsValid() {
+			return false, entity.NewFieldError("slot", "unknown meal slot")
+		}
+
+		if m.slot == slot {
+			return false, nil
+		}
+
+		m.slot = slot
+
+		return true, nil
+	}
+}
+
+// SetRecipe sets the planned recipe.
+// Error cases:
+//   - Recipe id is empty
+func SetRecipe(recipeID entity.ID) Option {
+	return func(m *Meal) (bool, error) {
+		if recipeID.String() == "" {
+			return false, entity.NewFieldError("recipe", "meal recipe cannot be empty")
+		}
+
+		if m.recipeID == recipeID {
+			return false, nil
+		}
+
+		m.recipeID = recipeID
+
+		return true, nil
+	}
+}
+
+// SetServings sets how many servings are planned.
+// A value of 0 means the recipe's own number of servings.
+// Error cases:
+//   - Servings is less than 0
+func SetServings(servings int) Option {
+	return func(m *Meal) (bool, error) {
+		if servings < 0 {
+			return false, entity.NewFieldError("servings", "meal servings cannot be negative")
+		}
+
+		if m.servings == servings {
+			return false, nil
+		}
+
+		m.servings = servings
+
+		return true, nil
+	}
+}
+
+// SetUpdated sets when and by whom the Meal was last updated.
+// This is meant for rebuilding a stored Meal, not for user changes.
+func SetUpdated(timestamp time.Time, userID entity.ID) Option {
+	return func(m *Meal) (bool, error) {
+		if m.updatedAt.Equal(timestamp) && m.updatedBy == userID {
+			return false, nil
+		}
+
+		m.updatedAt = timestamp
+		m.updatedBy = userID
+
+		return true, nil
+	}
+}
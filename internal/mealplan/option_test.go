@@ -0,0 +1,114 @@
+package mealplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMeal(t *testing.T) *mealplan.Meal {
+	t.Helper()
+
+	date := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	result, err := mealplan.New(entity.NewRandomID(), date, entity.NewID("recipe-1"), time.Now(), entity.NewRandomID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestSetDate(t *testing.T) {
+	t.Parallel()
+
+	test := newMeal(t)
+
+	// Set an empty date
+	changed, err := mealplan.SetDate(time.Time{})(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+
+	// Set the same day at a different time
+	changed, err = mealplan.SetDate(time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Set a different day
+	changed, err = mealplan.SetDate(time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), test.Date())
+}
+
+func TestSetSlot(t *testing.T) {
+	t.Parallel()
+
+	test := newMeal(t)
+
+	// Set an unknown slot
+	changed, err := mealplan.SetSlot("brunch")(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, mealplan.Dinner, test.Slot())
+
+	// Set the slot
+	changed, err = mealplan.SetSlot(mealplan.Breakfast)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, mealplan.Breakfast, test.Slot())
+
+	// Set the slot to the same value
+	changed, err = mealplan.SetSlot(mealplan.Breakfast)(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
+
+func TestSetRecipe(t *testing.T) {
+	t.Parallel()
+
+	test := newMeal(t)
+
+	// Set an empty recipe
+	changed, err := mealplan.SetRecipe(entity.NewID(""))(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, entity.NewID("recipe-1"), test.RecipeID())
+
+	// Set the recipe
+	changed, err = mealplan.SetRecipe(entity.NewID("recipe-2"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID("recipe-2"), test.RecipeID())
+
+	// Set the recipe to the same value
+	changed, err = mealplan.SetRecipe(entity.NewID("recipe-2"))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
+
+func TestSetServings(t *testing.T) {
+	t.Parallel()
+
+	test := newMeal(t)
+
+	// Set negative servings
+	changed, err := mealplan.SetServings(-1)(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, 0, test.Servings())
+
+	// Set the servings
+	changed, err = mealplan.SetServings(4)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, test.Servings())
+
+	// Set the servings to the same value
+	changed, err = mealplan.SetServings(4)(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,15 @@
+package mealplan
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Repository defines all data interactions required for meal plans.
+type Repository interface {
+	GetMeal(ctx context.Context, id entity.ID) (*Meal, error)
+	CreateMeal(ctx context.Context, meal *Meal) error
+	UpdateMeal(ctx context.Context, meal *Meal) error
+	DeleteMeal(ctx context.Context, id entity.ID) error
+}
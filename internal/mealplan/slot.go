@@ -0,0 +1,22 @@
+package mealplan
+
+// Slot is a meal of the day.
+type Slot string
+
+// Breakfast, et al. are the meals of the day.
+const (
+	Breakfast Slot = "breakfast"
+	Lunch     Slot = "lunch"
+	Dinner    Slot = "dinner"
+	Snack     Slot = "snack"
+)
+
+// IsValid returns whether the Slot is a known meal of the day.
+func (s Slot) IsValid() bool {
+	switch s {
+	case Breakfast, Lunch, Dinner, Snack:
+		return true
+	default:
+		return false
+	}
+}
@@ -29,3 +29,6 @@ func (r *NoOp) ObserveResolverDuration(string, string, string, time.Duration) {}
 
 // ObserveGraphqlError records an unhandled GraphQL error.
 func (r *NoOp) ObserveGraphqlError() {}
+
+// ObserveQueryRejected records a GraphQL operation rejected for exceeding a limit.
+func (r *NoOp) ObserveQueryRejected(string) {}
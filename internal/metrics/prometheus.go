@@ -25,6 +25,7 @@ type Prometheus struct {
 
 	resolverDuration *prometheus.HistogramVec
 	graphqlError     prometheus.Counter
+	queryRejected    *prometheus.CounterVec
 }
 
 // NewPrometheus creates a new Prometheus recorder.
@@ -48,10 +49,20 @@ func NewPrometheus() *Prometheus {
 				Help:      "Unhandled GraphQL Errors",
 			},
 		),
+		queryRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: graphqlSubsystem,
+				Name:      "rejected_total",
+				Help:      "GraphQL Operations Rejected for Exceeding a Limit",
+			},
+			[]string{"reason"},
+		),
 	}
 
 	_ = prometheus.DefaultRegisterer.Register(recorder.resolverDuration)
 	_ = prometheus.DefaultRegisterer.Register(recorder.graphqlError)
+	_ = prometheus.DefaultRegisterer.Register(recorder.queryRejected)
 
 	return recorder
 }
@@ -65,3 +76,8 @@ func (p *Prometheus) ObserveResolverDuration(object string, field string, status
 func (p *Prometheus) ObserveGraphqlError() {
 	p.graphqlError.Inc()
 }
+
+// ObserveQueryRejected records a GraphQL operation rejected for exceeding a limit.
+func (p *Prometheus) ObserveQueryRejected(reason string) {
+	p.queryRejected.WithLabelValues(reason).Inc()
+}
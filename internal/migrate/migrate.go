@@ -0,0 +1,328 @@
+// Package migrate implements versioned database schema migrations.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var filePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrMigrate is raised when a migration fails.
+var ErrMigrate = errors.New("migration error")
+
+// ErrIrreversible is raised when rolling back a migration that has no down script.
+var ErrIrreversible = errors.New("migration is irreversible")
+
+// ErrNoMigration is raised when there is no applied migration to roll back.
+var ErrNoMigration = errors.New("no migration applied")
+
+func migrateError(err error) error {
+	return fmt.Errorf("%w: %w", ErrMigrate, err)
+}
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is the state of a Migration in a database.
+type Status struct {
+	Migration
+
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	table       string
+	placeholder func(n int) string
+}
+
+// New creates a new Migrator from the migration scripts in the source.
+// Scripts are named "<version>_<name>.up.sql", with an optional matching "<version>_<name>.down.sql".
+func New(db *sql.DB, source fs.FS, options ...Option) (*Migrator, error) {
+	migrator := &Migrator{
+		db:          db,
+		migrations:  nil,
+		table:       "schema_migrations",
+		placeholder: func(int) string { return "?" },
+	}
+
+	for _, option := range options {
+		option(migrator)
+	}
+
+	migrations, err := load(source)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator.migrations = migrations
+
+	return migrator, nil
+}
+
+func load(source fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(source, "*.sql")
+	if err != nil {
+		return nil, migrateError(err)
+	}
+
+	lookup := make(map[int]*Migration)
+
+	for _, file := range files {
+		match := filePattern.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, migrateError(fmt.Errorf("invalid migration file name %q", file)) //nolint: err113
+		}
+
+		version, _ := strconv.Atoi(match[1])
+
+		script, err := fs.ReadFile(source, file)
+		if err != nil {
+			return nil, migrateError(err)
+		}
+
+		migration, ok := lookup[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			lookup[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, migrateError(fmt.Errorf("conflicting names for version %d", version)) //nolint: err113
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	result := make([]Migration, 0, len(lookup))
+
+	for _, migration := range lookup {
+		if migration.Up == "" {
+			return nil, migrateError(fmt.Errorf("version %d has no up script", migration.Version)) //nolint: err113
+		}
+
+		result = append(result, *migration)
+	}
+
+	slices.SortFunc(result, func(a Migration, b Migration) int { return a.Version - b.Version })
+
+	return result, nil
+}
+
+// Latest returns the newest migration version known to the Migrator.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the newest migration version applied to the database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+
+	for key := range applied {
+		version = max(version, key)
+	}
+
+	return version, nil
+}
+
+// Pending returns all migrations not yet applied to the database.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0)
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Status returns the state of every known migration.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, len(m.migrations))
+
+	for i, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+
+		result[i] = Status{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+
+	return result, nil
+}
+
+// Up applies all pending migrations in order and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0, len(pending))
+
+	for _, migration := range pending {
+		err := m.transaction(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return err
+			}
+
+			_, err := tx.ExecContext(
+				ctx,
+				"INSERT INTO "+m.table+" (version, name, applied_at) VALUES ("+
+					m.placeholder(1)+", "+m.placeholder(2)+", "+m.placeholder(3)+")",
+				migration.Version,
+				migration.Name,
+				time.Now().UTC().Format(time.RFC3339Nano),
+			)
+
+			return err
+		})
+		if err != nil {
+			return result, migrateError(fmt.Errorf("version %d: %w", migration.Version, err))
+		}
+
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Down rolls back the newest applied migration and returns it.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		return nil, ErrNoMigration
+	}
+
+	index := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == version })
+	if index < 0 {
+		return nil, migrateError(fmt.Errorf("version %d is unknown", version)) //nolint: err113
+	}
+
+	migration := m.migrations[index]
+	if migration.Down == "" {
+		return nil, fmt.Errorf("%w: version %d", ErrIrreversible, version)
+	}
+
+	err = m.transaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+m.table+" WHERE version = "+m.placeholder(1), version)
+
+		return err
+	})
+	if err != nil {
+		return nil, migrateError(fmt.Errorf("version %d: %w", version, err))
+	}
+
+	return &migration, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	_, err := m.db.ExecContext(
+		ctx,
+		"CREATE TABLE IF NOT EXISTS "+m.table+` (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TEXT NOT NULL
+		)`,
+	)
+	if err != nil {
+		return nil, migrateError(err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM "+m.table)
+	if err != nil {
+		return nil, migrateError(err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[int]time.Time)
+
+	for rows.Next() {
+		var (
+			version   int
+			appliedAt string
+		)
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, migrateError(err)
+		}
+
+		result[version], _ = time.Parse(time.RFC3339Nano, appliedAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, migrateError(err)
+	}
+
+	return result, nil
+}
+
+func (m *Migrator) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err //nolint: wrapcheck
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit() //nolint: wrapcheck
+}
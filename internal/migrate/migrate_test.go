@@ -0,0 +1,184 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/b-sea/supply-run-api/internal/migrate"
+	"github.com/stretchr/testify/assert"
+
+	_ "modernc.org/sqlite"
+)
+
+func newDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		source fstest.MapFS
+		latest int
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"valid": {
+			source: fstest.MapFS{
+				"0002_second.up.sql": {Data: []byte("SELECT 1;")},
+				"0001_first.up.sql":  {Data: []byte("SELECT 1;")},
+				"0001_first.down.sql": {
+					Data: []byte("SELECT 1;"),
+				},
+			},
+			latest: 2,
+			err:    nil,
+		},
+		"empty": {
+			source: fstest.MapFS{},
+			latest: 0,
+			err:    nil,
+		},
+		"bad name": {
+			source: fstest.MapFS{"first.sql": {Data: []byte("SELECT 1;")}},
+			err:    migrate.ErrMigrate,
+		},
+		"conflicting names": {
+			source: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_other.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			err: migrate.ErrMigrate,
+		},
+		"missing up": {
+			source: fstest.MapFS{"0001_first.down.sql": {Data: []byte("SELECT 1;")}},
+			err:    migrate.ErrMigrate,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			migrator, err := migrate.New(nil, test.source)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.latest, migrator.Latest())
+		})
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newDatabase(t)
+
+	migrator, err := migrate.New(
+		db,
+		fstest.MapFS{
+			"0001_things.up.sql":    {Data: []byte("CREATE TABLE things (id TEXT);")},
+			"0001_things.down.sql":  {Data: []byte("DROP TABLE things;")},
+			"0002_widgets.up.sql":   {Data: []byte("CREATE TABLE widgets (id TEXT);")},
+			"0002_widgets.down.sql": {Data: []byte("DROP TABLE widgets;")},
+			"0003_forever.up.sql":   {Data: []byte("CREATE TABLE forever (id TEXT);")},
+		},
+		migrate.SetTable("test_migrations"),
+	)
+	assert.NoError(t, err)
+
+	// Check a fresh database
+	version, err := migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	pending, err := migrator.Pending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(pending))
+
+	_, err = migrator.Down(ctx)
+	assert.ErrorIs(t, err, migrate.ErrNoMigration)
+
+	// Apply everything
+	applied, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(applied))
+
+	version, err = migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	status, err := migrator.Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(status))
+
+	for _, item := range status {
+		assert.True(t, item.Applied)
+		assert.False(t, item.AppliedAt.IsZero())
+	}
+
+	// Applying again does nothing
+	applied, err = migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(applied))
+
+	// A migration without a down script cannot be rolled back
+	_, err = migrator.Down(ctx)
+	assert.ErrorIs(t, err, migrate.ErrIrreversible)
+
+	// Roll back a reversible migration
+	_, err = db.ExecContext(ctx, "DELETE FROM test_migrations WHERE version = 3")
+	assert.NoError(t, err)
+
+	rolledBack, err := migrator.Down(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rolledBack.Version)
+
+	_, err = db.ExecContext(ctx, "SELECT * FROM widgets")
+	assert.Error(t, err)
+
+	status, err = migrator.Status(ctx)
+	assert.NoError(t, err)
+	assert.True(t, status[0].Applied)
+	assert.False(t, status[1].Applied)
+}
+
+func TestUpFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newDatabase(t)
+
+	migrator, err := migrate.New(
+		db,
+		fstest.MapFS{
+			"0001_good.up.sql": {Data: []byte("CREATE TABLE good (id TEXT);")},
+			"0002_bad.up.sql":  {Data: []byte("CREATE TABLE good (id TEXT);")},
+		},
+	)
+	assert.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	assert.ErrorIs(t, err, migrate.ErrMigrate)
+	assert.Equal(t, 1, len(applied))
+
+	version, err := migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
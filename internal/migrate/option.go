@@ -0,0 +1,24 @@
+package migrate
+
+import "strconv"
+
+// Option is a Migrator creation option.
+type Option func(m *Migrator)
+
+// SetTable overrides the table used to track applied migrations.
+func SetTable(table string) Option {
+	return func(m *Migrator) {
+		if table == "" {
+			return
+		}
+
+		m.table = table
+	}
+}
+
+// WithDollarPlaceholders uses numbered "$1" style query placeholders instead of "?".
+func WithDollarPlaceholders() Option {
+	return func(m *Migrator) {
+		m.placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	}
+}
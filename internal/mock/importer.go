@@ -0,0 +1,18 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/importer"
+)
+
+var _ importer.Fetcher = (*Fetcher)(nil)
+
+type Fetcher struct {
+	FetchResult []byte
+	FetchErr    error
+}
+
+func (m *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return m.FetchResult, m.FetchErr
+}
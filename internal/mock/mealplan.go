@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+)
+
+var _ mealplan.Repository = (*MealRepository)(nil)
+
+type MealRepository struct {
+	GetMealResult *mealplan.Meal
+	GetMealErr    error
+	CreateMealErr error
+	UpdateMealErr error
+	DeleteMealErr error
+}
+
+func (m *MealRepository) GetMeal(ctx context.Context, id entity.ID) (*mealplan.Meal, error) {
+	return m.GetMealResult, m.GetMealErr
+}
+
+func (m *MealRepository) CreateMeal(ctx context.Context, meal *mealplan.Meal) error {
+	return m.CreateMealErr
+}
+
+func (m *MealRepository) UpdateMeal(ctx context.Context, meal *mealplan.Meal) error {
+	return m.UpdateMealErr
+}
+
+func (m *MealRepository) DeleteMeal(ctx context.Context, id entity.ID) error {
+	return m.DeleteMealErr
+}
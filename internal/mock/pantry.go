@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+)
+
+var _ pantry.Repository = (*PantryRepository)(nil)
+
+type PantryRepository struct {
+	GetPantryItemResult *pantry.Item
+	GetPantryItemErr    error
+	CreatePantryItemErr error
+	UpdatePantryItemErr error
+	DeletePantryItemErr error
+}
+
+func (m *PantryRepository) GetPantryItem(ctx context.Context, id entity.ID) (*pantry.Item, error) {
+	return m.GetPantryItemResult, m.GetPantryItemErr
+}
+
+func (m *PantryRepository) CreatePantryItem(ctx context.Context, item *pantry.Item) error {
+	return m.CreatePantryItemErr
+}
+
+func (m *PantryRepository) UpdatePantryItem(ctx context.Context, item *pantry.Item) error {
+	return m.UpdatePantryItemErr
+}
+
+func (m *PantryRepository) DeletePantryItem(ctx context.Context, id entity.ID) error {
+	return m.DeletePantryItemErr
+}
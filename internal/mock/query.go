@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
 	"github.com/b-sea/supply-run-api/internal/query"
@@ -10,14 +11,20 @@ import (
 var _ query.RecipeRepository = (*QueryRecipeRepository)(nil)
 var _ query.UnitRepository = (*QueryUnitRepository)(nil)
 var _ query.UserRepository = (*QueryUserRepository)(nil)
+var _ query.ShoppingListRepository = (*QueryShoppingListRepository)(nil)
+var _ query.PantryRepository = (*QueryPantryRepository)(nil)
+var _ query.MealRepository = (*QueryMealRepository)(nil)
+var _ query.RecipeSearch = (*RecipeSearch)(nil)
 
 type QueryRecipeRepository struct {
-	FindRecipesResult []*query.Recipe
-	FindRecipesErr    error
-	GetRecipesResult  []*query.Recipe
-	GetRecipesErr     error
-	FindTagsResult    []string
-	FindTagsErr       error
+	FindRecipesResult  []*query.Recipe
+	FindRecipesErr     error
+	CountRecipesResult int
+	CountRecipesErr    error
+	GetRecipesResult   []*query.Recipe
+	GetRecipesErr      error
+	FindTagsResult     []string
+	FindTagsErr        error
 }
 
 func (m *QueryRecipeRepository) FindRecipes(
@@ -29,6 +36,10 @@ func (m *QueryRecipeRepository) FindRecipes(
 	return m.FindRecipesResult, m.FindRecipesErr
 }
 
+func (m *QueryRecipeRepository) CountRecipes(ctx context.Context, filter query.RecipeFilter) (int, error) {
+	return m.CountRecipesResult, m.CountRecipesErr
+}
+
 func (m *QueryRecipeRepository) GetRecipes(ctx context.Context, id []entity.ID) ([]*query.Recipe, error) {
 	return m.GetRecipesResult, m.GetRecipesErr
 }
@@ -40,6 +51,8 @@ func (m *QueryRecipeRepository) FindTags(ctx context.Context, filter *string) ([
 type QueryUnitRepository struct {
 	GetUnitsResult          []*query.Unit
 	GetUnitsErr             error
+	GetConversionsResult    []*query.Conversion
+	GetConversionsErr       error
 	GetConversionPathResult []*query.Conversion
 	GetConversionPathErr    error
 	AllUnitsResult          []*query.Unit
@@ -50,6 +63,10 @@ func (m *QueryUnitRepository) GetUnits(ctx context.Context, ids []entity.ID) ([]
 	return m.GetUnitsResult, m.GetUnitsErr
 }
 
+func (m *QueryUnitRepository) GetConversions(ctx context.Context, ids []entity.ID) ([]*query.Conversion, error) {
+	return m.GetConversionsResult, m.GetConversionsErr
+}
+
 func (m *QueryUnitRepository) GetConversionPath(ctx context.Context, from *query.Unit, to *query.Unit) ([]*query.Conversion, error) {
 	return m.GetConversionPathResult, m.GetConversionPathErr
 }
@@ -59,10 +76,88 @@ func (m *QueryUnitRepository) AllUnits(ctx context.Context) ([]*query.Unit, erro
 }
 
 type QueryUserRepository struct {
-	GetUsersResult []*query.User
-	GetUsersErr    error
+	GetUsersResult     []*query.User
+	GetUsersErr        error
+	GetFavoritesResult []entity.ID
+	GetFavoritesErr    error
 }
 
 func (m *QueryUserRepository) GetUsers(ctx context.Context, ids []entity.ID) ([]*query.User, error) {
 	return m.GetUsersResult, m.GetUsersErr
 }
+
+func (m *QueryUserRepository) GetFavorites(
+	ctx context.Context,
+	userID entity.ID,
+	recipeIDs []entity.ID,
+) ([]entity.ID, error) {
+	return m.GetFavoritesResult, m.GetFavoritesErr
+}
+
+type QueryShoppingListRepository struct {
+	GetShoppingListsResult  []*query.ShoppingList
+	GetShoppingListsErr     error
+	FindShoppingListsResult []*query.ShoppingList
+	FindShoppingListsErr    error
+}
+
+func (m *QueryShoppingListRepository) GetShoppingLists(
+	ctx context.Context,
+	ids []entity.ID,
+) ([]*query.ShoppingList, error) {
+	return m.GetShoppingListsResult, m.GetShoppingListsErr
+}
+
+func (m *QueryShoppingListRepository) FindShoppingLists(
+	ctx context.Context,
+	userID entity.ID,
+) ([]*query.ShoppingList, error) {
+	return m.FindShoppingListsResult, m.FindShoppingListsErr
+}
+
+type QueryPantryRepository struct {
+	GetPantryItemsResult  []*query.PantryItem
+	GetPantryItemsErr     error
+	FindPantryItemsResult []*query.PantryItem
+	FindPantryItemsErr    error
+}
+
+func (m *QueryPantryRepository) GetPantryItems(ctx context.Context, ids []entity.ID) ([]*query.PantryItem, error) {
+	return m.GetPantryItemsResult, m.GetPantryItemsErr
+}
+
+func (m *QueryPantryRepository) FindPantryItems(
+	ctx context.Context,
+	userID entity.ID,
+	filter query.PantryFilter,
+) ([]*query.PantryItem, error) {
+	return m.FindPantryItemsResult, m.FindPantryItemsErr
+}
+
+type QueryMealRepository struct {
+	GetMealsResult  []*query.Meal
+	GetMealsErr     error
+	FindMealsResult []*query.Meal
+	FindMealsErr    error
+}
+
+func (m *QueryMealRepository) GetMeals(ctx context.Context, ids []entity.ID) ([]*query.Meal, error) {
+	return m.GetMealsResult, m.GetMealsErr
+}
+
+func (m *QueryMealRepository) FindMeals(
+	ctx context.Context,
+	userID entity.ID,
+	from time.Time,
+	to time.Time,
+) ([]*query.Meal, error) {
+	return m.FindMealsResult, m.FindMealsErr
+}
+
+type RecipeSearch struct {
+	SearchRecipesResult []query.Match
+}
+
+func (m *RecipeSearch) SearchRecipes(text string) []query.Match {
+	return m.SearchRecipesResult
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+)
+
+var _ recipe.Repository = (*RecipeRepository)(nil)
+
+type RecipeRepository struct {
+	GetRecipeResult *recipe.Recipe
+	GetRecipeErr    error
+	CreateRecipeErr error
+	UpdateRecipeErr error
+	DeleteRecipeErr error
+}
+
+func (m *RecipeRepository) GetRecipe(ctx context.Context, id entity.ID) (*recipe.Recipe, error) {
+	return m.GetRecipeResult, m.GetRecipeErr
+}
+
+func (m *RecipeRepository) CreateRecipe(ctx context.Context, recipe *recipe.Recipe) error {
+	return m.CreateRecipeErr
+}
+
+func (m *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *recipe.Recipe) error {
+	return m.UpdateRecipeErr
+}
+
+func (m *RecipeRepository) DeleteRecipe(ctx context.Context, id entity.ID) error {
+	return m.DeleteRecipeErr
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+)
+
+var _ shoppinglist.Repository = (*ShoppingListRepository)(nil)
+
+type ShoppingListRepository struct {
+	GetShoppingListResult *shoppinglist.List
+	GetShoppingListErr    error
+	CreateShoppingListErr error
+	UpdateShoppingListErr error
+	DeleteShoppingListErr error
+}
+
+func (m *ShoppingListRepository) GetShoppingList(ctx context.Context, id entity.ID) (*shoppinglist.List, error) {
+	return m.GetShoppingListResult, m.GetShoppingListErr
+}
+
+func (m *ShoppingListRepository) CreateShoppingList(ctx context.Context, list *shoppinglist.List) error {
+	return m.CreateShoppingListErr
+}
+
+func (m *ShoppingListRepository) UpdateShoppingList(ctx context.Context, list *shoppinglist.List) error {
+	return m.UpdateShoppingListErr
+}
+
+func (m *ShoppingListRepository) DeleteShoppingList(ctx context.Context, id entity.ID) error {
+	return m.DeleteShoppingListErr
+}
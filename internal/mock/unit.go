@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+var _ unit.Repository = (*UnitRepository)(nil)
+
+type UnitRepository struct {
+	GetUnitResult       map[entity.ID]*unit.Unit
+	GetUnitErr          error
+	CreateUnitErr       error
+	CreateConversionErr error
+}
+
+func (m *UnitRepository) GetUnit(ctx context.Context, id entity.ID) (*unit.Unit, error) {
+	if m.GetUnitErr != nil {
+		return nil, m.GetUnitErr
+	}
+
+	found, ok := m.GetUnitResult[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return found, nil
+}
+
+func (m *UnitRepository) CreateUnit(ctx context.Context, unit *unit.Unit) error {
+	return m.CreateUnitErr
+}
+
+func (m *UnitRepository) CreateConversion(ctx context.Context, conversion *unit.Conversion) error {
+	return m.CreateConversionErr
+}
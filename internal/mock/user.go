@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+var _ user.Repository = (*UserRepository)(nil)
+
+type UserRepository struct {
+	GetUserResult     *user.User
+	GetUserErr        error
+	CreateUserErr     error
+	UpdateUserErr     error
+	DeleteUserErr     error
+	AddFavoriteErr    error
+	RemoveFavoriteErr error
+}
+
+func (m *UserRepository) GetUser(ctx context.Context, id entity.ID) (*user.User, error) {
+	if m.GetUserErr != nil {
+		return nil, m.GetUserErr
+	}
+
+	if m.GetUserResult == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	return m.GetUserResult, nil
+}
+
+func (m *UserRepository) CreateUser(ctx context.Context, user *user.User) error {
+	return m.CreateUserErr
+}
+
+func (m *UserRepository) UpdateUser(ctx context.Context, user *user.User) error {
+	return m.UpdateUserErr
+}
+
+func (m *UserRepository) DeleteUser(ctx context.Context, id entity.ID) error {
+	return m.DeleteUserErr
+}
+
+func (m *UserRepository) AddFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	return m.AddFavoriteErr
+}
+
+func (m *UserRepository) RemoveFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	return m.RemoveFavoriteErr
+}
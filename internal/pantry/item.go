@@ -0,0 +1,139 @@
+// Package pantry defines what users have on hand.
+package pantry
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Item is something a user has in their pantry.
+type Item struct {
+	id        entity.ID
+	name      string
+	quantity  *float64
+	unitID    entity.ID
+	expiresAt *time.Time
+
+	createdAt time.Time
+	createdBy entity.ID
+	updatedAt time.Time
+	updatedBy entity.ID
+}
+
+// New creates a new Item.
+func New(id entity.ID, name string, timestamp time.Time, userID entity.ID, options ...Option) (*Item, error) {
+	item := &Item{
+		id:        id,
+		name:      "",
+		quantity:  nil,
+		unitID:    entity.NewID(""),
+		expiresAt: nil,
+		createdAt: timestamp,
+		createdBy: userID,
+		updatedAt: timestamp,
+		updatedBy: userID,
+	}
+
+	options = append([]Option{SetName(name)}, options...)
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		if _, err := option(item); err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+	}
+
+	if !validation.IsEmpty() {
+		return nil, validation
+	}
+
+	return item, nil
+}
+
+// Update updates an existing Item.
+// Updates are only applied if any data actually changes.
+func (i *Item) Update(timestamp time.Time, userID entity.ID, options ...Option) error {
+	changed := false
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		result, err := option(i)
+		if err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+
+		if !result {
+			continue
+		}
+
+		changed = true
+	}
+
+	if !validation.IsEmpty() {
+		return validation
+	}
+
+	if !changed {
+		return nil
+	}
+
+	i.updatedAt = timestamp
+	i.updatedBy = userID
+
+	return nil
+}
+
+// ID returns the Item id.
+func (i *Item) ID() entity.ID {
+	return i.id
+}
+
+// Name returns the Item name.
+func (i *Item) Name() string {
+	return i.name
+}
+
+// Quantity returns the amount of Item on hand, if it is tracked.
+func (i *Item) Quantity() *float64 {
+	return i.quantity
+}
+
+// UnitID returns the Item unit id.
+func (i *Item) UnitID() entity.ID {
+	return i.unitID
+}
+
+// ExpiresAt returns when the Item expires, if ever.
+func (i *Item) ExpiresAt() *time.Time {
+	return i.expiresAt
+}
+
+// CreatedAt returns when the Item was created.
+func (i *Item) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// CreatedBy returns the id of the user who created the Item.
+func (i *Item) CreatedBy() entity.ID {
+	return i.createdBy
+}
+
+// UpdatedAt returns when the Item was last updated.
+func (i *Item) UpdatedAt() time.Time {
+	return i.updatedAt
+}
+
+// UpdatedBy returns the id of the user who last updated the Item.
+func (i *Item) UpdatedBy() entity.ID {
+	return i.updatedBy
+}
@@ -0,0 +1,71 @@
+package pantry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewItem(t *testing.T) {
+	t.Parallel()
+
+	// Create a valid item
+	id := entity.NewID("item-123")
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	test, err := pantry.New(id, "flour", timestamp, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, test.ID())
+	assert.Equal(t, "flour", test.Name())
+	assert.Nil(t, test.Quantity())
+	assert.Equal(t, entity.NewID(""), test.UnitID())
+	assert.Nil(t, test.ExpiresAt())
+	assert.Equal(t, timestamp, test.CreatedAt())
+	assert.Equal(t, userID, test.CreatedBy())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Create an item with an empty name and a bad quantity
+	_, err = pantry.New(entity.NewRandomID(), "", time.Now(), entity.NewRandomID(), pantry.SetQuantity(-1))
+
+	var validation *entity.ValidationError
+
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, 2, len(validation.InnerErrors))
+}
+
+func TestUpdateItem(t *testing.T) {
+	t.Parallel()
+
+	test, err := pantry.New(entity.NewRandomID(), "flour", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Update the item with a valid quantity
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	err = test.Update(timestamp, userID, pantry.SetQuantity(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), *test.Quantity())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the item with the same quantity
+	err = test.Update(time.Now(), entity.NewRandomID(), pantry.SetQuantity(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the item with an invalid name
+	err = test.Update(time.Now(), entity.NewRandomID(), pantry.SetName(""))
+
+	assert.Error(t, err)
+	assert.Equal(t, "flour", test.Name())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+}
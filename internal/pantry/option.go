@@ -0,0 +1,115 @@
+package pantry
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Option is an Item create or update option.
+type Option func(i *Item) (bool, error)
+
+// SetName sets the Item name.
+// Error cases:
+//   - Name is empty
+func SetName(name string) Option {
+	return func(i *Item) (bool, error) {
+		if name == "" {
+			return false, entity.NewFieldError("name", "pantry item name cannot be empty")
+		}
+
+		if i.name == name {
+			return false, nil
+		}
+
+		i.name = name
+
+		return true, nil
+	}
+}
+
+// SetQuantity sets the amount of Item on hand.
+// Error cases:
+//   - Quantity is less than 0
+func SetQuantity(quantity float64) Option {
+	return func(i *Item) (bool, error) {
+		if quantity < 0 {
+			return false, entity.NewFieldError("quantity", "pantry item quantity cannot be negative")
+		}
+
+		if i.quantity != nil && *i.quantity == quantity {
+			return false, nil
+		}
+
+		i.quantity = &quantity
+
+		return true, nil
+	}
+}
+
+// ClearQuantity stops tracking the amount of Item on hand.
+func ClearQuantity() Option {
+	return func(i *Item) (bool, error) {
+		if i.quantity == nil {
+			return false, nil
+		}
+
+		i.quantity = nil
+
+		return true, nil
+	}
+}
+
+// SetUnit sets the Item unit.
+func SetUnit(unitID entity.ID) Option {
+	return func(i *Item) (bool, error) {
+		if i.unitID == unitID {
+			return false, nil
+		}
+
+		i.unitID = unitID
+
+		return true, nil
+	}
+}
+
+// SetExpiry sets when the Item expires.
+func SetExpiry(timestamp time.Time) Option {
+	return func(i *Item) (bool, error) {
+		if i.expiresAt != nil && i.expiresAt.Equal(timestamp) {
+			return false, nil
+		}
+
+		i.expiresAt = &timestamp
+
+		return true, nil
+	}
+}
+
+// ClearExpiry removes when the Item expires.
+func ClearExpiry() Option {
+	return func(i *Item) (bool, error) {
+		if i.expiresAt == nil {
+			return false, nil
+		}
+
+		i.expiresAt = nil
+
+		return true, nil
+	}
+}
+
+// SetUpdated sets when and by whom the Item was last updated.
+// This is meant for rebuilding a stored Item, not for user changes.
+func SetUpdated(timestamp time.Time, userID entity.ID) Option {
+	return func(i *Item) (bool, error) {
+		if i.updatedAt.Equal(timestamp) && i.updatedBy == userID {
+			return false, nil
+		}
+
+		i.updatedAt = timestamp
+		i.updatedBy = userID
+
+		return true, nil
+	}
+}
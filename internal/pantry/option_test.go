@@ -0,0 +1,145 @@
+package pantry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetName(t *testing.T) {
+	t.Parallel()
+
+	test, err := pantry.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Set an empty name
+	changed, err := pantry.SetName("")(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, "test", test.Name())
+
+	// Set the name
+	changed, err = pantry.SetName("new name")(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "new name", test.Name())
+
+	// Set the name to the same value
+	changed, err = pantry.SetName("new name")(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "new name", test.Name())
+}
+
+func TestSetQuantity(t *testing.T) {
+	t.Parallel()
+
+	test, err := pantry.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Set a negative quantity
+	changed, err := pantry.SetQuantity(-1)(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Nil(t, test.Quantity())
+
+	// Clear a quantity that is not tracked
+	changed, err = pantry.ClearQuantity()(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Set the quantity
+	changed, err = pantry.SetQuantity(1.5)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *test.Quantity())
+
+	// Set the quantity to the same value
+	changed, err = pantry.SetQuantity(1.5)(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Run out of the item
+	changed, err = pantry.SetQuantity(0)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), *test.Quantity())
+
+	// Clear the quantity
+	changed, err = pantry.ClearQuantity()(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Nil(t, test.Quantity())
+}
+
+func TestSetUnit(t *testing.T) {
+	t.Parallel()
+
+	test, err := pantry.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Set the unit
+	changed, err := pantry.SetUnit(entity.NewID("cup"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewID("cup"), test.UnitID())
+
+	// Set the unit to the same value
+	changed, err = pantry.SetUnit(entity.NewID("cup"))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
+
+func TestSetExpiry(t *testing.T) {
+	t.Parallel()
+
+	test, err := pantry.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Clear an expiry that is not set
+	changed, err := pantry.ClearExpiry()(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Set the expiry
+	expiry := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	changed, err = pantry.SetExpiry(expiry)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, &expiry, test.ExpiresAt())
+
+	// Set the expiry to the same value
+	changed, err = pantry.SetExpiry(expiry)(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Clear the expiry
+	changed, err = pantry.ClearExpiry()(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Nil(t, test.ExpiresAt())
+}
+
+func TestSetUpdated(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now()
+	test, err := pantry.New(entity.NewRandomID(), "test", created, entity.NewID("user-1"))
+	assert.NoError(t, err)
+
+	// Set the same updated values
+	changed, err := pantry.SetUpdated(created, entity.NewID("user-1"))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	// Set new updated values
+	updated := created.Add(time.Hour)
+	changed, err = pantry.SetUpdated(updated, entity.NewID("user-2"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, test.UpdatedAt())
+	assert.Equal(t, entity.NewID("user-2"), test.UpdatedBy())
+}
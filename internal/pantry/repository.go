@@ -0,0 +1,15 @@
+package pantry
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Repository defines all data interactions required for pantry items.
+type Repository interface {
+	GetPantryItem(ctx context.Context, id entity.ID) (*Item, error)
+	CreatePantryItem(ctx context.Context, item *Item) error
+	UpdatePantryItem(ctx context.Context, item *Item) error
+	DeletePantryItem(ctx context.Context, id entity.ID) error
+}
@@ -0,0 +1,86 @@
+//go:build postgres
+
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/postgres"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// testDSNEnv points the integration tests at a disposable PostgreSQL database.
+const testDSNEnv = "SUPPLYRUN_TEST_POSTGRES_DSN"
+
+// newRepository creates a Repository inside a fresh schema so tests never share data.
+func newRepository(t *testing.T) *postgres.Repository {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	admin, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = admin.Close() })
+
+	schema := "test_" + strings.ToLower(entity.NewRandomID().String())
+	if _, err := admin.ExecContext(context.Background(), "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _, _ = admin.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	separator := " "
+	if strings.Contains(dsn, "://") {
+		separator = "&"
+		if !strings.Contains(dsn, "?") {
+			separator = "?"
+		}
+	}
+
+	repo, err := postgres.New(dsn + separator + "search_path=" + schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = repo.Close() })
+
+	if _, err := repo.Migrator().Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return repo
+}
+
+func newRecipe(t *testing.T, id string, name string, created time.Time, options ...recipe.Option) *recipe.Recipe {
+	t.Helper()
+
+	result, err := recipe.New(entity.NewID(id), name, created, entity.NewID("user-123"), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func recipeIDs(recipes []*query.Recipe) []string {
+	result := make([]string, len(recipes))
+	for i := range recipes {
+		result[i] = recipes[i].ID.String()
+	}
+
+	return result
+}
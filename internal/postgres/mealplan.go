@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// GetMeal returns a single stored meal.
+func (r *Repository) GetMeal(ctx context.Context, id entity.ID) (*mealplan.Meal, error) {
+	found, err := r.GetMeals(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	result, err := mealplan.New(
+		found[0].ID, found[0].Date, found[0].RecipeID, found[0].CreatedAt, found[0].CreatedBy,
+		mealplan.SetSlot(mealplan.Slot(found[0].Slot)),
+		mealplan.SetServings(found[0].Servings),
+		mealplan.SetUpdated(found[0].UpdatedAt, found[0].UpdatedBy),
+	)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	return result, nil
+}
+
+// CreateMeal stores a new meal.
+func (r *Repository) CreateMeal(ctx context.Context, meal *mealplan.Meal) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO meals (id, date, slot, recipe_id, servings, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		meal.ID().String(),
+		meal.Date(),
+		string(meal.Slot()),
+		meal.RecipeID().String(),
+		meal.Servings(),
+		meal.CreatedAt().UTC(),
+		meal.CreatedBy().String(),
+		meal.UpdatedAt().UTC(),
+		meal.UpdatedBy().String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// UpdateMeal updates an existing meal.
+func (r *Repository) UpdateMeal(ctx context.Context, meal *mealplan.Meal) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE meals SET date = $1, slot = $2, recipe_id = $3, servings = $4, updated_at = $5, updated_by = $6
+		WHERE id = $7`,
+		meal.Date(),
+		string(meal.Slot()),
+		meal.RecipeID().String(),
+		meal.Servings(),
+		meal.UpdatedAt().UTC(),
+		meal.UpdatedBy().String(),
+		meal.ID().String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// DeleteMeal removes a meal.
+func (r *Repository) DeleteMeal(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM meals WHERE id = $1`, id.String())
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// GetMeals returns all meals matching the given ids.
+func (r *Repository) GetMeals(ctx context.Context, ids []entity.ID) ([]*query.Meal, error) {
+	if len(ids) == 0 {
+		return make([]*query.Meal, 0), nil
+	}
+
+	return r.findMeals(ctx, `WHERE id = ANY($1)`, []any{idStrings(ids)})
+}
+
+// FindMeals returns all meals a user has planned from one day up to, but not including, another.
+// Meals are ordered by date and then by the order of the day's meals.
+func (r *Repository) FindMeals(
+	ctx context.Context,
+	userID entity.ID,
+	from time.Time,
+	to time.Time,
+) ([]*query.Meal, error) {
+	return r.findMeals(
+		ctx,
+		`WHERE created_by = $1 AND date >= $2 AND date < $3
+		ORDER BY date, CASE slot WHEN 'breakfast' THEN 0 WHEN 'lunch' THEN 1 WHEN 'dinner' THEN 2 ELSE 3 END, id`,
+		[]any{userID.String(), from.UTC(), to.UTC()},
+	)
+}
+
+func (r *Repository) findMeals(ctx context.Context, where string, args []any) ([]*query.Meal, error) {
+	result := make([]*query.Meal, 0)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, date, slot, recipe_id, servings, created_at, created_by, updated_at, updated_by FROM meals `+where,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id, recipeID, createdBy, updatedBy string
+				meal                               = &query.Meal{}
+			)
+
+			err := rows.Scan(
+				&id, &meal.Date, &meal.Slot, &recipeID, &meal.Servings,
+				&meal.CreatedAt, &createdBy, &meal.UpdatedAt, &updatedBy,
+			)
+			if err != nil {
+				return err
+			}
+
+			meal.ID = entity.NewID(id)
+			meal.Date = meal.Date.UTC()
+			meal.RecipeID = entity.NewID(recipeID)
+			meal.CreatedAt = meal.CreatedAt.UTC()
+			meal.CreatedBy = entity.NewID(createdBy)
+			meal.UpdatedAt = meal.UpdatedAt.UTC()
+			meal.UpdatedBy = entity.NewID(updatedBy)
+
+			result = append(result, meal)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,102 @@
+//go:build postgres
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMealLifecycle(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	userID := entity.NewID("user-123")
+
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(t, "recipe-1", "pancakes", created)))
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(t, "recipe-2", "tacos", created)))
+
+	meal, err := mealplan.New(
+		entity.NewID("meal-1"), monday, entity.NewID("recipe-1"), created, userID,
+		mealplan.SetSlot(mealplan.Breakfast),
+		mealplan.SetServings(4),
+	)
+	assert.NoError(t, err)
+
+	// Create a meal
+	assert.NoError(t, repo.CreateMeal(ctx, meal))
+
+	loaded, err := repo.GetMeal(ctx, entity.NewID("meal-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, meal, loaded)
+
+	// Update the meal
+	err = loaded.Update(updated, entity.NewID("user-456"), mealplan.SetDate(monday.AddDate(0, 0, 1)))
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdateMeal(ctx, loaded))
+
+	found, err := repo.GetMeals(ctx, []entity.ID{entity.NewID("meal-1"), entity.NewID("unknown")})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*query.Meal{
+			{
+				ID:        entity.NewID("meal-1"),
+				Date:      monday.AddDate(0, 0, 1),
+				Slot:      "breakfast",
+				RecipeID:  entity.NewID("recipe-1"),
+				Servings:  4,
+				CreatedAt: created,
+				CreatedBy: userID,
+				UpdatedAt: updated,
+				UpdatedBy: entity.NewID("user-456"),
+			},
+		},
+		found,
+	)
+
+	// Find meals within a week
+	dinner, err := mealplan.New(entity.NewID("meal-2"), monday.AddDate(0, 0, 1), entity.NewID("recipe-2"), created, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateMeal(ctx, dinner))
+
+	later, err := mealplan.New(entity.NewID("meal-3"), monday.AddDate(0, 0, 7), entity.NewID("recipe-2"), created, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateMeal(ctx, later))
+
+	found, err = repo.FindMeals(ctx, userID, monday, monday.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(found))
+	assert.Equal(t, entity.NewID("meal-1"), found[0].ID)
+	assert.Equal(t, entity.NewID("meal-2"), found[1].ID)
+
+	found, err = repo.FindMeals(ctx, entity.NewID("someone-else"), monday, monday.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Meal{}, found)
+
+	// Deleting a recipe removes its meals
+	assert.NoError(t, repo.DeleteRecipe(ctx, entity.NewID("recipe-2")))
+
+	found, err = repo.FindMeals(ctx, userID, monday, monday.AddDate(0, 0, 14))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(found))
+
+	// Delete the meal
+	assert.NoError(t, repo.DeleteMeal(ctx, entity.NewID("meal-1")))
+
+	// Get, update, and delete a missing meal
+	_, err = repo.GetMeal(ctx, entity.NewID("meal-1"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateMeal(ctx, loaded), entity.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteMeal(ctx, entity.NewID("meal-1")), entity.ErrNotFound)
+}
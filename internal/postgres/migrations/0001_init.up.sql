@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS users (
+	id       TEXT PRIMARY KEY,
+	username TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS units (
+	id        TEXT PRIMARY KEY,
+	name      TEXT NOT NULL,
+	plural    TEXT NOT NULL,
+	symbol    TEXT NOT NULL,
+	base_type TEXT NOT NULL,
+	system    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS conversions (
+	from_id TEXT NOT NULL REFERENCES units (id) ON DELETE CASCADE,
+	to_id   TEXT NOT NULL REFERENCES units (id) ON DELETE CASCADE,
+	ratio   DOUBLE PRECISION NOT NULL,
+	PRIMARY KEY (from_id, to_id)
+);
+
+CREATE TABLE IF NOT EXISTS recipes (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	url          TEXT NOT NULL,
+	num_servings INTEGER NOT NULL,
+	is_favorite  BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at   TIMESTAMPTZ NOT NULL,
+	created_by   TEXT NOT NULL,
+	updated_at   TIMESTAMPTZ NOT NULL,
+	updated_by   TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS recipes_created_at_idx ON recipes (created_at, id);
+CREATE INDEX IF NOT EXISTS recipes_updated_at_idx ON recipes (updated_at, id);
+CREATE INDEX IF NOT EXISTS recipes_name_idx ON recipes (lower(name), id);
+
+CREATE TABLE IF NOT EXISTS recipe_steps (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	step      TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	name      TEXT NOT NULL,
+	quantity  DOUBLE PRECISION NOT NULL,
+	unit_id   TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS recipe_ingredients_name_idx ON recipe_ingredients (lower(name));
+
+CREATE TABLE IF NOT EXISTS recipe_tags (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	tag       TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS recipe_tags_tag_idx ON recipe_tags (lower(tag));
@@ -0,0 +1,5 @@
+ALTER TABLE recipes ADD COLUMN is_favorite BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE recipes SET is_favorite = TRUE WHERE EXISTS (SELECT 1 FROM favorites WHERE recipe_id = recipes.id);
+
+DROP TABLE favorites;
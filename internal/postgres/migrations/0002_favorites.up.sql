@@ -0,0 +1,12 @@
+CREATE TABLE favorites (
+	user_id   TEXT NOT NULL,
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+CREATE INDEX favorites_recipe_id_idx ON favorites (recipe_id);
+
+INSERT INTO favorites (user_id, recipe_id)
+SELECT created_by, id FROM recipes WHERE is_favorite;
+
+ALTER TABLE recipes DROP COLUMN is_favorite;
@@ -0,0 +1,21 @@
+CREATE TABLE shopping_lists (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	updated_by TEXT NOT NULL
+);
+
+CREATE INDEX shopping_lists_created_by_idx ON shopping_lists (created_by, created_at);
+
+CREATE TABLE shopping_list_items (
+	list_id  TEXT NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	id       TEXT NOT NULL,
+	name     TEXT NOT NULL,
+	quantity DOUBLE PRECISION NOT NULL,
+	unit_id  TEXT NOT NULL,
+	checked  BOOLEAN NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (list_id, position)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE pantry_items (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	quantity   DOUBLE PRECISION NOT NULL,
+	unit_id    TEXT NOT NULL,
+	expires_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	updated_by TEXT NOT NULL
+);
+
+CREATE INDEX pantry_items_created_by_idx ON pantry_items (created_by, lower(name));
+CREATE INDEX pantry_items_expires_at_idx ON pantry_items (created_by, expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE meals (
+	id         TEXT PRIMARY KEY,
+	date       DATE NOT NULL,
+	slot       TEXT NOT NULL,
+	recipe_id  TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	servings   INTEGER NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	updated_by TEXT NOT NULL
+);
+
+CREATE INDEX meals_created_by_date_idx ON meals (created_by, date);
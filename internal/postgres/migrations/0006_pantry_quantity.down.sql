@@ -0,0 +1,3 @@
+UPDATE pantry_items SET quantity = 0 WHERE quantity IS NULL;
+
+ALTER TABLE pantry_items ALTER COLUMN quantity SET NOT NULL;
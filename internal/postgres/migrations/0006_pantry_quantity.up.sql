@@ -0,0 +1,3 @@
+ALTER TABLE pantry_items ALTER COLUMN quantity DROP NOT NULL;
+
+UPDATE pantry_items SET quantity = NULL WHERE quantity = 0;
@@ -0,0 +1,73 @@
+package postgres
+
+import "time"
+
+// Option is a Repository creation option.
+type Option func(r *Repository)
+
+// SetMaxOpenConns sets the maximum number of open connections in the pool.
+func SetMaxOpenConns(count int) Option {
+	return func(r *Repository) {
+		if count <= 0 {
+			return
+		}
+
+		r.maxOpenConns = count
+	}
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections kept in the pool.
+func SetMaxIdleConns(count int) Option {
+	return func(r *Repository) {
+		if count < 0 {
+			return
+		}
+
+		r.maxIdleConns = count
+	}
+}
+
+// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
+func SetConnMaxLifetime(duration time.Duration) Option {
+	return func(r *Repository) {
+		if duration <= 0 {
+			return
+		}
+
+		r.connMaxLifetime = duration
+	}
+}
+
+// SetConnMaxIdleTime sets the maximum amount of time a connection may sit idle.
+func SetConnMaxIdleTime(duration time.Duration) Option {
+	return func(r *Repository) {
+		if duration <= 0 {
+			return
+		}
+
+		r.connMaxIdleTime = duration
+	}
+}
+
+// SetConnectTimeout sets how long to wait when establishing a connection.
+func SetConnectTimeout(duration time.Duration) Option {
+	return func(r *Repository) {
+		if duration <= 0 {
+			return
+		}
+
+		r.connectTimeout = duration
+	}
+}
+
+// SetStatementTimeout sets the server-side timeout for every statement.
+// A timeout of 0 or less leaves the server default in place.
+func SetStatementTimeout(duration time.Duration) Option {
+	return func(r *Repository) {
+		if duration <= 0 {
+			return
+		}
+
+		r.statementTimeout = duration
+	}
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// GetPantryItem returns a single stored pantry item.
+func (r *Repository) GetPantryItem(ctx context.Context, id entity.ID) (*pantry.Item, error) {
+	found, err := r.GetPantryItems(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	options := []pantry.Option{pantry.SetUnit(found[0].UnitID)}
+
+	if found[0].Quantity != nil {
+		options = append(options, pantry.SetQuantity(*found[0].Quantity))
+	}
+
+	if found[0].ExpiresAt != nil {
+		options = append(options, pantry.SetExpiry(*found[0].ExpiresAt))
+	}
+
+	options = append(options, pantry.SetUpdated(found[0].UpdatedAt, found[0].UpdatedBy))
+
+	result, err := pantry.New(found[0].ID, found[0].Name, found[0].CreatedAt, found[0].CreatedBy, options...)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	return result, nil
+}
+
+// CreatePantryItem stores a new pantry item.
+func (r *Repository) CreatePantryItem(ctx context.Context, item *pantry.Item) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO pantry_items (id, name, quantity, unit_id, expires_at, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		item.ID().String(),
+		item.Name(),
+		item.Quantity(),
+		item.UnitID().String(),
+		toNullTime(item.ExpiresAt()),
+		item.CreatedAt().UTC(),
+		item.CreatedBy().String(),
+		item.UpdatedAt().UTC(),
+		item.UpdatedBy().String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// UpdatePantryItem updates an existing pantry item.
+func (r *Repository) UpdatePantryItem(ctx context.Context, item *pantry.Item) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE pantry_items SET name = $1, quantity = $2, unit_id = $3, expires_at = $4, updated_at = $5, updated_by = $6
+		WHERE id = $7`,
+		item.Name(),
+		item.Quantity(),
+		item.UnitID().String(),
+		toNullTime(item.ExpiresAt()),
+		item.UpdatedAt().UTC(),
+		item.UpdatedBy().String(),
+		item.ID().String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// DeletePantryItem removes a pantry item.
+func (r *Repository) DeletePantryItem(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM pantry_items WHERE id = $1`, id.String())
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// GetPantryItems returns all pantry items matching the given ids.
+func (r *Repository) GetPantryItems(ctx context.Context, ids []entity.ID) ([]*query.PantryItem, error) {
+	if len(ids) == 0 {
+		return make([]*query.PantryItem, 0), nil
+	}
+
+	return r.findPantryItems(ctx, `WHERE id = ANY($1)`, []any{idStrings(ids)})
+}
+
+// FindPantryItems returns all pantry items of a user matching the given filter.
+// Items are ordered by name, or by expiry when filtering on it.
+func (r *Repository) FindPantryItems(
+	ctx context.Context,
+	userID entity.ID,
+	filter query.PantryFilter,
+) ([]*query.PantryItem, error) {
+	args := arguments{}
+	where := []string{"created_by = " + args.add(userID.String())}
+	order := "lower(name), id"
+
+	if filter.Name != nil {
+		where = append(where, "name ILIKE "+args.add(likePattern(*filter.Name)))
+	}
+
+	if filter.ExpiresBefore != nil {
+		where = append(where, "expires_at <= "+args.add(filter.ExpiresBefore.UTC()))
+		order = "expires_at, lower(name), id"
+	}
+
+	return r.findPantryItems(ctx, "WHERE "+strings.Join(where, " AND ")+" ORDER BY "+order, args)
+}
+
+func (r *Repository) findPantryItems(ctx context.Context, where string, args []any) ([]*query.PantryItem, error) {
+	result := make([]*query.PantryItem, 0)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, name, quantity, unit_id, expires_at, created_at, created_by, updated_at, updated_by
+		FROM pantry_items `+where,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id, unitID, createdBy, updatedBy string
+				expiresAt                        sql.NullTime
+				item                             = &query.PantryItem{}
+			)
+
+			err := rows.Scan(
+				&id, &item.Name, &item.Quantity, &unitID, &expiresAt,
+				&item.CreatedAt, &createdBy, &item.UpdatedAt, &updatedBy,
+			)
+			if err != nil {
+				return err
+			}
+
+			item.ID = entity.NewID(id)
+			item.UnitID = entity.NewID(unitID)
+			item.CreatedAt = item.CreatedAt.UTC()
+			item.CreatedBy = entity.NewID(createdBy)
+			item.UpdatedAt = item.UpdatedAt.UTC()
+			item.UpdatedBy = entity.NewID(updatedBy)
+
+			if expiresAt.Valid {
+				expiry := expiresAt.Time.UTC()
+				item.ExpiresAt = &expiry
+			}
+
+			result = append(result, item)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,235 @@
+// Package postgres implements all data repositories on a PostgreSQL database.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/migrate"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/b-sea/supply-run-api/internal/user"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	defaultMaxOpenConns    = 10
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = 30 * time.Minute
+	defaultConnMaxIdleTime = 5 * time.Minute
+	defaultConnectTimeout  = 5 * time.Second
+)
+
+var (
+	_ recipe.Repository            = (*Repository)(nil)
+	_ unit.Repository              = (*Repository)(nil)
+	_ user.Repository              = (*Repository)(nil)
+	_ shoppinglist.Repository      = (*Repository)(nil)
+	_ pantry.Repository            = (*Repository)(nil)
+	_ mealplan.Repository          = (*Repository)(nil)
+	_ query.RecipeRepository       = (*Repository)(nil)
+	_ query.UnitRepository         = (*Repository)(nil)
+	_ query.UserRepository         = (*Repository)(nil)
+	_ query.ShoppingListRepository = (*Repository)(nil)
+	_ query.PantryRepository       = (*Repository)(nil)
+	_ query.MealRepository         = (*Repository)(nil)
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrations, _ = fs.Sub(migrationFiles, "migrations") //nolint: gochecknoglobals
+
+// ErrPostgres is raised when the database fails.
+var ErrPostgres = errors.New("postgres error")
+
+func postgresError(err error) error {
+	return fmt.Errorf("%w: %w", ErrPostgres, err)
+}
+
+// Repository is a PostgreSQL-backed data repository.
+type Repository struct {
+	db               *sql.DB
+	migrator         *migrate.Migrator
+	maxOpenConns     int
+	maxIdleConns     int
+	connMaxLifetime  time.Duration
+	connMaxIdleTime  time.Duration
+	connectTimeout   time.Duration
+	statementTimeout time.Duration
+}
+
+// New connects to a PostgreSQL database and creates a new Repository.
+func New(dsn string, options ...Option) (*Repository, error) {
+	repo := &Repository{
+		db:               nil,
+		migrator:         nil,
+		maxOpenConns:     defaultMaxOpenConns,
+		maxIdleConns:     defaultMaxIdleConns,
+		connMaxLifetime:  defaultConnMaxLifetime,
+		connMaxIdleTime:  defaultConnMaxIdleTime,
+		connectTimeout:   defaultConnectTimeout,
+		statementTimeout: 0,
+	}
+
+	for _, option := range options {
+		option(repo)
+	}
+
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	config.ConnectTimeout = repo.connectTimeout
+
+	if repo.statementTimeout > 0 {
+		config.RuntimeParams["statement_timeout"] = strconv.FormatInt(repo.statementTimeout.Milliseconds(), 10)
+	}
+
+	repo.db = stdlib.OpenDB(*config)
+	repo.db.SetMaxOpenConns(repo.maxOpenConns)
+	repo.db.SetMaxIdleConns(repo.maxIdleConns)
+	repo.db.SetConnMaxLifetime(repo.connMaxLifetime)
+	repo.db.SetConnMaxIdleTime(repo.connMaxIdleTime)
+
+	repo.migrator, err = migrate.New(repo.db, migrations, migrate.WithDollarPlaceholders())
+	if err != nil {
+		_ = repo.db.Close()
+
+		return nil, postgresError(err)
+	}
+
+	if err := repo.HealthCheck(); err != nil {
+		_ = repo.db.Close()
+
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Migrator returns the schema Migrator for the database.
+func (r *Repository) Migrator() *migrate.Migrator {
+	return r.migrator
+}
+
+// Close closes the underlying connection pool.
+func (r *Repository) Close() error {
+	if err := r.db.Close(); err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// HealthCheck reports if the database is reachable.
+func (r *Repository) HealthCheck() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.connectTimeout)
+	defer cancel()
+
+	if err := r.db.PingContext(ctx); err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) eachRow(ctx context.Context, statement string, args []any, fn func(rows *sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return postgresError(err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// arguments collects positional query arguments.
+type arguments []any
+
+// add appends a value and returns its placeholder.
+func (a *arguments) add(value any) string {
+	*a = append(*a, value)
+
+	return "$" + strconv.Itoa(len(*a))
+}
+
+func expectAffected(result sql.Result) error {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return postgresError(err)
+	}
+
+	if count == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+func toNullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{Time: time.Time{}, Valid: false}
+	}
+
+	return sql.NullTime{Time: value.UTC(), Valid: true}
+}
+
+func likePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	return "%" + replacer.Replace(value) + "%"
+}
+
+func idStrings(ids []entity.ID) []string {
+	result := make([]string, len(ids))
+	for i := range ids {
+		result[i] = ids[i].String()
+	}
+
+	return result
+}
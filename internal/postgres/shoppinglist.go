@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+)
+
+// GetShoppingList returns a single stored shopping list.
+func (r *Repository) GetShoppingList(ctx context.Context, id entity.ID) (*shoppinglist.List, error) {
+	found, err := r.GetShoppingLists(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	options := make([]shoppinglist.Option, 0, len(found[0].Items)+1)
+
+	for _, item := range found[0].Items {
+		options = append(options, shoppinglist.AddItem(item.ID, item.Name, item.Quantity, item.UnitID))
+
+		if item.Checked {
+			options = append(options, shoppinglist.SetChecked(item.ID, true))
+		}
+	}
+
+	options = append(options, shoppinglist.SetUpdated(found[0].UpdatedAt, found[0].UpdatedBy))
+
+	result, err := shoppinglist.New(found[0].ID, found[0].Name, found[0].CreatedAt, found[0].CreatedBy, options...)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	return result, nil
+}
+
+// CreateShoppingList stores a new shopping list.
+func (r *Repository) CreateShoppingList(ctx context.Context, list *shoppinglist.List) error {
+	return r.transaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO shopping_lists (id, name, created_at, created_by, updated_at, updated_by)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			list.ID().String(),
+			list.Name(),
+			list.CreatedAt().UTC(),
+			list.CreatedBy().String(),
+			list.UpdatedAt().UTC(),
+			list.UpdatedBy().String(),
+		)
+		if err != nil {
+			return postgresError(err)
+		}
+
+		return insertShoppingItems(ctx, tx, list)
+	})
+}
+
+// UpdateShoppingList updates an existing shopping list.
+func (r *Repository) UpdateShoppingList(ctx context.Context, list *shoppinglist.List) error {
+	return r.transaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE shopping_lists SET name = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
+			list.Name(),
+			list.UpdatedAt().UTC(),
+			list.UpdatedBy().String(),
+			list.ID().String(),
+		)
+		if err != nil {
+			return postgresError(err)
+		}
+
+		if err := expectAffected(result); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM shopping_list_items WHERE list_id = $1`, list.ID().String())
+		if err != nil {
+			return postgresError(err)
+		}
+
+		return insertShoppingItems(ctx, tx, list)
+	})
+}
+
+// DeleteShoppingList removes a shopping list.
+func (r *Repository) DeleteShoppingList(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM shopping_lists WHERE id = $1`, id.String())
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+func insertShoppingItems(ctx context.Context, tx *sql.Tx, list *shoppinglist.List) error {
+	for i, item := range list.Items() {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO shopping_list_items (list_id, position, id, name, quantity, unit_id, checked)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			list.ID().String(), i, item.ID().String(), item.Name(), item.Quantity(), item.UnitID().String(), item.Checked(),
+		)
+		if err != nil {
+			return postgresError(err)
+		}
+	}
+
+	return nil
+}
+
+// GetShoppingLists returns all shopping lists matching the given ids.
+func (r *Repository) GetShoppingLists(ctx context.Context, ids []entity.ID) ([]*query.ShoppingList, error) {
+	if len(ids) == 0 {
+		return make([]*query.ShoppingList, 0), nil
+	}
+
+	return r.findShoppingLists(ctx, `WHERE id = ANY($1)`, []any{idStrings(ids)})
+}
+
+// FindShoppingLists returns all shopping lists created by a user, newest first.
+func (r *Repository) FindShoppingLists(ctx context.Context, userID entity.ID) ([]*query.ShoppingList, error) {
+	return r.findShoppingLists(ctx, `WHERE created_by = $1 ORDER BY created_at DESC, id DESC`, []any{userID.String()})
+}
+
+func (r *Repository) findShoppingLists(ctx context.Context, where string, args []any) ([]*query.ShoppingList, error) {
+	result := make([]*query.ShoppingList, 0)
+	lookup := make(map[string]*query.ShoppingList)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, name, created_at, created_by, updated_at, updated_by FROM shopping_lists `+where,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id, createdBy, updatedBy string
+				list                     = &query.ShoppingList{
+					Items: make([]query.ShoppingItem, 0),
+				}
+			)
+
+			err := rows.Scan(&id, &list.Name, &list.CreatedAt, &createdBy, &list.UpdatedAt, &updatedBy)
+			if err != nil {
+				return err
+			}
+
+			list.ID = entity.NewID(id)
+			list.CreatedAt = list.CreatedAt.UTC()
+			list.CreatedBy = entity.NewID(createdBy)
+			list.UpdatedAt = list.UpdatedAt.UTC()
+			list.UpdatedBy = entity.NewID(updatedBy)
+
+			lookup[id] = list
+			result = append(result, list)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	listIDs := make([]string, 0, len(lookup))
+	for id := range lookup {
+		listIDs = append(listIDs, id)
+	}
+
+	err = r.eachRow(
+		ctx,
+		`SELECT list_id, id, name, quantity, unit_id, checked FROM shopping_list_items
+		WHERE list_id = ANY($1) ORDER BY list_id, position`,
+		[]any{listIDs},
+		func(rows *sql.Rows) error {
+			var (
+				listID, id, unitID string
+				item               query.ShoppingItem
+			)
+
+			if err := rows.Scan(&listID, &id, &item.Name, &item.Quantity, &unitID, &item.Checked); err != nil {
+				return err
+			}
+
+			item.ID = entity.NewID(id)
+			item.UnitID = entity.NewID(unitID)
+
+			if list, ok := lookup[listID]; ok {
+				list.Items = append(list.Items, item)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+//go:build postgres
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShoppingListLifecycle(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	userID := entity.NewID("user-123")
+
+	list, err := shoppinglist.New(
+		entity.NewID("list-1"), "groceries", created, userID,
+		shoppinglist.AddItem(entity.NewID("item-1"), "flour", 2, entity.NewID("cup")),
+		shoppinglist.AddItem(entity.NewID("item-2"), "paper towels", 0, entity.NewID("")),
+	)
+	assert.NoError(t, err)
+
+	// Create a shopping list
+	assert.NoError(t, repo.CreateShoppingList(ctx, list))
+
+	loaded, err := repo.GetShoppingList(ctx, entity.NewID("list-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, list, loaded)
+
+	// Check an item and remove another
+	err = loaded.Update(
+		updated, entity.NewID("user-456"),
+		shoppinglist.SetName("weekly"),
+		shoppinglist.SetChecked(entity.NewID("item-1"), true),
+		shoppinglist.RemoveItem(entity.NewID("item-2")),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdateShoppingList(ctx, loaded))
+
+	found, err := repo.GetShoppingLists(ctx, []entity.ID{entity.NewID("list-1"), entity.NewID("unknown")})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*query.ShoppingList{
+			{
+				ID:   entity.NewID("list-1"),
+				Name: "weekly",
+				Items: []query.ShoppingItem{
+					{ID: entity.NewID("item-1"), Name: "flour", Quantity: 2, UnitID: entity.NewID("cup"), Checked: true},
+				},
+				CreatedAt: created,
+				CreatedBy: userID,
+				UpdatedAt: updated,
+				UpdatedBy: entity.NewID("user-456"),
+			},
+		},
+		found,
+	)
+
+	reloaded, err := repo.GetShoppingList(ctx, entity.NewID("list-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, loaded, reloaded)
+
+	// Find lists by who created them
+	another, err := shoppinglist.New(entity.NewID("list-2"), "party", updated, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateShoppingList(ctx, another))
+
+	found, err = repo.FindShoppingLists(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(found))
+	assert.Equal(t, entity.NewID("list-2"), found[0].ID)
+	assert.Equal(t, entity.NewID("list-1"), found[1].ID)
+
+	found, err = repo.FindShoppingLists(ctx, entity.NewID("someone-else"))
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.ShoppingList{}, found)
+
+	// Delete the shopping list
+	assert.NoError(t, repo.DeleteShoppingList(ctx, entity.NewID("list-1")))
+
+	// Get, update, and delete a missing shopping list
+	_, err = repo.GetShoppingList(ctx, entity.NewID("list-1"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateShoppingList(ctx, loaded), entity.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteShoppingList(ctx, entity.NewID("list-1")), entity.ErrNotFound)
+}
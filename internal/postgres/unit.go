@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// GetUnit returns a single stored unit.
+func (r *Repository) GetUnit(ctx context.Context, id entity.ID) (*unit.Unit, error) {
+	var name, plural, symbol, baseType, system string
+
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT name, plural, symbol, base_type, system FROM units WHERE id = $1`,
+		id.String(),
+	).Scan(&name, &plural, &symbol, &baseType, &system)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, postgresError(err)
+	}
+
+	return newUnit(name, plural, symbol, baseType, system), nil
+}
+
+func newUnit(name string, plural string, symbol string, baseType string, system string) *unit.Unit {
+	return unit.New(name, symbol, unit.SetSystem(system), unit.SetBaseType(baseType), unit.WithCustomPlural(plural))
+}
+
+// CreateUnit stores a new unit.
+func (r *Repository) CreateUnit(ctx context.Context, unit *unit.Unit) error {
+	return insertUnit(ctx, r.db, unit, false)
+}
+
+// CreateConversion stores a new unit conversion.
+// Any units in the conversion that do not exist yet are created as well.
+func (r *Repository) CreateConversion(ctx context.Context, conversion *unit.Conversion) error {
+	return r.transaction(ctx, func(tx *sql.Tx) error {
+		if err := insertUnit(ctx, tx, conversion.From(), true); err != nil {
+			return err
+		}
+
+		if err := insertUnit(ctx, tx, conversion.To(), true); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO conversions (from_id, to_id, ratio) VALUES ($1, $2, $3)`,
+			conversion.From().ID().String(),
+			conversion.To().ID().String(),
+			conversion.Ratio(),
+		)
+		if err != nil {
+			return postgresError(err)
+		}
+
+		return nil
+	})
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertUnit(ctx context.Context, db execer, unit *unit.Unit, ignoreExisting bool) error {
+	statement := "INSERT INTO units (id, name, plural, symbol, base_type, system) VALUES ($1, $2, $3, $4, $5, $6)"
+	if ignoreExisting {
+		statement += " ON CONFLICT (id) DO NOTHING"
+	}
+
+	_, err := db.ExecContext(
+		ctx,
+		statement,
+		unit.ID().String(),
+		unit.Name(),
+		unit.Plural(),
+		unit.Symbol(),
+		unit.BaseType(),
+		unit.System(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// GetUnits returns all units matching the given ids.
+func (r *Repository) GetUnits(ctx context.Context, ids []entity.ID) ([]*query.Unit, error) {
+	result := make([]*query.Unit, 0, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, name, plural, symbol, base_type, system FROM units WHERE id = ANY($1)`,
+		[]any{idStrings(ids)},
+		func(rows *sql.Rows) error {
+			var (
+				id   string
+				unit = &query.Unit{}
+			)
+
+			if err := rows.Scan(&id, &unit.Name, &unit.Plural, &unit.Symbol, &unit.BaseType, &unit.System); err != nil {
+				return err
+			}
+
+			unit.ID = entity.NewID(id)
+			result = append(result, unit)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AllUnits returns every stored unit.
+func (r *Repository) AllUnits(ctx context.Context) ([]*query.Unit, error) {
+	result := make([]*query.Unit, 0)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, name, plural, symbol, base_type, system FROM units ORDER BY name, id`,
+		nil,
+		func(rows *sql.Rows) error {
+			var (
+				id   string
+				unit = &query.Unit{}
+			)
+
+			if err := rows.Scan(&id, &unit.Name, &unit.Plural, &unit.Symbol, &unit.BaseType, &unit.System); err != nil {
+				return err
+			}
+
+			unit.ID = entity.NewID(id)
+			result = append(result, unit)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetConversions returns all conversions to or from the given unit ids.
+func (r *Repository) GetConversions(ctx context.Context, ids []entity.ID) ([]*query.Conversion, error) {
+	result := make([]*query.Conversion, 0)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	err := r.eachRow(
+		ctx,
+		`SELECT from_id, to_id, ratio FROM conversions WHERE from_id = ANY($1) OR to_id = ANY($1) ORDER BY from_id, to_id`,
+		[]any{idStrings(ids)},
+		func(rows *sql.Rows) error {
+			var (
+				fromID, toID string
+				conversion   = &query.Conversion{}
+			)
+
+			if err := rows.Scan(&fromID, &toID, &conversion.Ratio); err != nil {
+				return err
+			}
+
+			conversion.FromID = entity.NewID(fromID)
+			conversion.ToID = entity.NewID(toID)
+			result = append(result, conversion)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetConversionPath returns the shortest chain of conversions between two units of the same base type.
+// No conversions are returned when the units cannot be converted.
+func (r *Repository) GetConversionPath(
+	ctx context.Context,
+	from *query.Unit,
+	to *query.Unit,
+) ([]*query.Conversion, error) {
+	result := make([]*query.Conversion, 0)
+	if from.BaseType != to.BaseType {
+		return result, nil
+	}
+
+	graph := unit.NewGraph()
+
+	err := r.eachRow(
+		ctx,
+		`SELECT f.name, f.plural, f.symbol, f.system, t.name, t.plural, t.symbol, t.system, c.ratio
+		FROM conversions c
+		JOIN units f ON f.id = c.from_id
+		JOIN units t ON t.id = c.to_id
+		WHERE f.base_type = $1 AND t.base_type = $1`,
+		[]any{from.BaseType},
+		func(rows *sql.Rows) error {
+			var (
+				fromName, fromPlural, fromSymbol, fromSystem string
+				toName, toPlural, toSymbol, toSystem         string
+				ratio                                        float64
+			)
+
+			err := rows.Scan(
+				&fromName, &fromPlural, &fromSymbol, &fromSystem,
+				&toName, &toPlural, &toSymbol, &toSystem,
+				&ratio,
+			)
+			if err != nil {
+				return err
+			}
+
+			conversion, err := unit.NewConversion(
+				newUnit(fromName, fromPlural, fromSymbol, from.BaseType, fromSystem),
+				newUnit(toName, toPlural, toSymbol, from.BaseType, toSystem),
+				ratio,
+			)
+			if err != nil {
+				return err
+			}
+
+			graph.Add(conversion)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := graph.Path(
+		unit.New(from.Name, from.Symbol, unit.SetSystem(from.System), unit.SetBaseType(from.BaseType)),
+		unit.New(to.Name, to.Symbol, unit.SetSystem(to.System), unit.SetBaseType(to.BaseType)),
+	)
+	if err != nil {
+		return result, nil //nolint: nilerr
+	}
+
+	for _, conversion := range path {
+		result = append(result, &query.Conversion{
+			FromID: conversion.From().ID(),
+			ToID:   conversion.To().ID(),
+			Ratio:  conversion.Ratio(),
+		})
+	}
+
+	return result, nil
+}
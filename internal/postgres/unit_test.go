@@ -0,0 +1,120 @@
+//go:build postgres
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnits(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+
+	// Create a unit
+	assert.NoError(t, repo.CreateUnit(ctx, gram))
+
+	// Create the same unit again
+	assert.Error(t, repo.CreateUnit(ctx, gram))
+
+	// Create a conversion to a unit that does not exist yet
+	kilo := unit.Kilo(gram)
+	assert.NoError(t, repo.CreateConversion(ctx, kilo))
+
+	// Create the same conversion again
+	assert.Error(t, repo.CreateConversion(ctx, kilo))
+
+	found, err := repo.GetUnits(ctx, []entity.ID{gram.ID(), kilo.To().ID(), entity.NewID("unknown")})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*query.Unit{
+		{ID: gram.ID(), Name: "gram", Plural: "grams", Symbol: "g", BaseType: "mass", System: "metric"},
+		{ID: kilo.To().ID(), Name: "kilogram", Plural: "kilograms", Symbol: "kg", BaseType: "mass", System: "metric"},
+	}, found)
+
+	found, err = repo.GetUnits(ctx, []entity.ID{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Unit{}, found)
+
+	// List every unit
+	found, err = repo.AllUnits(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Unit{
+		{ID: gram.ID(), Name: "gram", Plural: "grams", Symbol: "g", BaseType: "mass", System: "metric"},
+		{ID: kilo.To().ID(), Name: "kilogram", Plural: "kilograms", Symbol: "kg", BaseType: "mass", System: "metric"},
+	}, found)
+
+	// Load the domain unit
+	loaded, err := repo.GetUnit(ctx, kilo.To().ID())
+	assert.NoError(t, err)
+	assert.Equal(t, kilo.To(), loaded)
+
+	_, err = repo.GetUnit(ctx, entity.NewID("unknown"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	// Find conversions from either side
+	conversions, err := repo.GetConversions(ctx, []entity.ID{kilo.To().ID()})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Conversion{
+		{FromID: gram.ID(), ToID: kilo.To().ID(), Ratio: 1000},
+	}, conversions)
+
+	conversions, err = repo.GetConversions(ctx, []entity.ID{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Conversion{}, conversions)
+}
+
+func TestConversionPath(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+	ounce := unit.New("ounce", "oz", unit.US, unit.Mass)
+	pound := unit.New("pound", "lb", unit.US, unit.Mass)
+	liter := unit.New("liter", "l", unit.Metric, unit.Volume)
+	kilo := unit.Kilo(gram)
+
+	toOunce, err := unit.NewConversion(gram, ounce, 28.3495)
+	assert.NoError(t, err)
+
+	toPound, err := unit.NewConversion(ounce, pound, 16)
+	assert.NoError(t, err)
+
+	for _, conversion := range []*unit.Conversion{kilo, toOunce, toPound, unit.Milli(liter)} {
+		assert.NoError(t, repo.CreateConversion(ctx, conversion))
+	}
+
+	queryUnit := func(unit *unit.Unit) *query.Unit {
+		return &query.Unit{
+			ID:       unit.ID(),
+			Name:     unit.Name(),
+			Symbol:   unit.Symbol(),
+			BaseType: unit.BaseType(),
+			System:   unit.System(),
+		}
+	}
+
+	// Walk conversions forwards and backwards
+	path, err := repo.GetConversionPath(ctx, queryUnit(pound), queryUnit(kilo.To()))
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Conversion{
+		{FromID: ounce.ID(), ToID: pound.ID(), Ratio: 16},
+		{FromID: gram.ID(), ToID: ounce.ID(), Ratio: 28.3495},
+		{FromID: gram.ID(), ToID: kilo.To().ID(), Ratio: 1000},
+	}, path)
+
+	// Different base types cannot be converted
+	path, err = repo.GetConversionPath(ctx, queryUnit(gram), queryUnit(liter))
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Conversion{}, path)
+}
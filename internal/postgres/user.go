@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+// GetUser returns a single stored user.
+func (r *Repository) GetUser(ctx context.Context, id entity.ID) (*user.User, error) {
+	var username string
+
+	err := r.db.QueryRowContext(ctx, `SELECT username FROM users WHERE id = $1`, id.String()).Scan(&username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, postgresError(err)
+	}
+
+	return user.New(id, username), nil
+}
+
+// CreateUser stores a new user.
+func (r *Repository) CreateUser(ctx context.Context, user *user.User) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO users (id, username) VALUES ($1, $2)`,
+		user.ID().String(),
+		user.Username(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// UpdateUser updates an existing user.
+func (r *Repository) UpdateUser(ctx context.Context, user *user.User) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE users SET username = $1 WHERE id = $2`,
+		user.Username(),
+		user.ID().String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// DeleteUser removes a user.
+func (r *Repository) DeleteUser(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id.String())
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// GetUsers returns all users matching the given ids.
+func (r *Repository) GetUsers(ctx context.Context, ids []entity.ID) ([]*query.User, error) {
+	result := make([]*query.User, 0, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, username FROM users WHERE id = ANY($1)`,
+		[]any{idStrings(ids)},
+		func(rows *sql.Rows) error {
+			var (
+				id   string
+				user = &query.User{}
+			)
+
+			if err := rows.Scan(&id, &user.Username); err != nil {
+				return err
+			}
+
+			user.ID = entity.NewID(id)
+			result = append(result, user)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddFavorite marks a recipe as a favorite of a user.
+func (r *Repository) AddFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO favorites (user_id, recipe_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		userID.String(),
+		recipeID.String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// RemoveFavorite unmarks a recipe as a favorite of a user.
+func (r *Repository) RemoveFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM favorites WHERE user_id = $1 AND recipe_id = $2`,
+		userID.String(),
+		recipeID.String(),
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	return nil
+}
+
+// GetFavorites returns which of the given recipe ids are favorites of a user.
+func (r *Repository) GetFavorites(ctx context.Context, userID entity.ID, recipeIDs []entity.ID) ([]entity.ID, error) {
+	result := make([]entity.ID, 0, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return result, nil
+	}
+
+	err := r.eachRow(
+		ctx,
+		`SELECT recipe_id FROM favorites WHERE user_id = $1 AND recipe_id = ANY($2)`,
+		[]any{userID.String(), idStrings(recipeIDs)},
+		func(rows *sql.Rows) error {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+
+			result = append(result, entity.NewID(id))
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,104 @@
+// Package pubsub implements publishing changes to entities to anyone subscribed to them.
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Topic is a kind of entity that can be subscribed to.
+type Topic string
+
+// RecipeTopic, et al. are the topics Events are published to.
+const (
+	RecipeTopic       = Topic("recipe")
+	ShoppingListTopic = Topic("shoppinglist")
+)
+
+// Action is the change made to an entity.
+type Action string
+
+// CreatedAction, et al. are the changes an Event can describe.
+const (
+	CreatedAction = Action("created")
+	UpdatedAction = Action("updated")
+	DeletedAction = Action("deleted")
+)
+
+// subscriberBuffer is how many Events a subscriber can fall behind before it misses any.
+const subscriberBuffer = 16
+
+// Event is a change to an entity.
+type Event struct {
+	Topic  Topic
+	Action Action
+	ID     entity.ID
+}
+
+// Broker delivers published Events to every subscriber of their Topic.
+// A Broker backed by a message queue lets subscribers on one instance see changes made on another.
+type Broker interface {
+	Publish(ctx context.Context, event Event)
+	Subscribe(ctx context.Context, topic Topic) <-chan Event
+}
+
+// Memory is an in-process Broker, for when only a single instance is running.
+// It is safe for concurrent use.
+type Memory struct {
+	mu          sync.RWMutex
+	subscribers map[Topic]map[chan Event]struct{}
+}
+
+// NewMemory creates a new Memory Broker.
+func NewMemory() *Memory {
+	return &Memory{
+		mu:          sync.RWMutex{},
+		subscribers: make(map[Topic]map[chan Event]struct{}),
+	}
+}
+
+// Publish sends an Event to every subscriber of its Topic.
+// It never blocks, so subscribers that have fallen too far behind miss the Event.
+func (m *Memory) Publish(_ context.Context, event Event) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for subscriber := range m.subscribers[event.Topic] {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns every Event published to a Topic until the Context is done, when the channel is closed.
+func (m *Memory) Subscribe(ctx context.Context, topic Topic) <-chan Event {
+	subscriber := make(chan Event, subscriberBuffer)
+
+	m.mu.Lock()
+	if m.subscribers[topic] == nil {
+		m.subscribers[topic] = make(map[chan Event]struct{})
+	}
+
+	m.subscribers[topic][subscriber] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.subscribers[topic], subscriber)
+
+		if len(m.subscribers[topic]) == 0 {
+			delete(m.subscribers, topic)
+		}
+
+		close(subscriber)
+	}()
+
+	return subscriber
+}
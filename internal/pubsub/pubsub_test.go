@@ -0,0 +1,51 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	broker := pubsub.NewMemory()
+
+	recipes := broker.Subscribe(ctx, pubsub.RecipeTopic)
+	lists := broker.Subscribe(ctx, pubsub.ShoppingListTopic)
+
+	created := pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.CreatedAction, ID: entity.NewID("recipe-1")}
+	broker.Publish(ctx, created)
+
+	assert.Equal(t, created, <-recipes)
+	assert.Empty(t, lists)
+
+	cancel()
+
+	_, ok := <-recipes
+	assert.False(t, ok)
+
+	_, ok = <-lists
+	assert.False(t, ok)
+
+	broker.Publish(context.Background(), created)
+}
+
+func TestMemorySlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	broker := pubsub.NewMemory()
+
+	recipes := broker.Subscribe(ctx, pubsub.RecipeTopic)
+
+	for range 100 {
+		broker.Publish(ctx, pubsub.Event{Topic: pubsub.RecipeTopic, Action: pubsub.UpdatedAction, ID: entity.NewID("1")})
+	}
+
+	assert.Len(t, recipes, cap(recipes))
+}
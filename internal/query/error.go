@@ -8,6 +8,9 @@ import (
 // ErrQuery is raised when a query fails.
 var ErrQuery = errors.New("query error")
 
+// ErrInvalidCursor is returned when a page cursor was made for a different sort than the one asked for.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 func queryError(err error) error {
 	return fmt.Errorf("%w: %w", ErrQuery, err)
 }
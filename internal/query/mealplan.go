@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// GetMeal returns a single planned meal of a user from an id.
+func (s *Service) GetMeal(ctx context.Context, userID entity.ID, id entity.ID) (*Meal, error) {
+	found, err := s.meals.GetMeals(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	if len(found) == 0 || found[0].CreatedBy != userID {
+		return nil, entity.ErrNotFound
+	}
+
+	return found[0], nil
+}
+
+// FindMeals returns all meals a user has planned from one day up to, but not including, another.
+func (s *Service) FindMeals(ctx context.Context, userID entity.ID, from time.Time, to time.Time) ([]*Meal, error) {
+	found, err := s.meals.FindMeals(ctx, userID, from, to)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
+
+// PlanMealIngredients totals the scaled ingredients of all meals a user has planned between two days.
+func (s *Service) PlanMealIngredients(
+	ctx context.Context,
+	userID entity.ID,
+	from time.Time,
+	to time.Time,
+) ([]Ingredient, error) {
+	meals, err := s.FindMeals(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	selections := make([]RecipeServings, len(meals))
+	for i := range meals {
+		selections[i] = RecipeServings{
+			RecipeID: meals[i].RecipeID,
+			Servings: meals[i].Servings,
+		}
+	}
+
+	return s.PlanShoppingList(ctx, selections)
+}
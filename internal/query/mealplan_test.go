@@ -0,0 +1,143 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMeal(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.MealRepository
+		result *query.Meal
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			repo: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{{ID: entity.NewID("meal-1"), Slot: "dinner", CreatedBy: entity.NewID("user-1")}},
+			},
+			result: &query.Meal{ID: entity.NewID("meal-1"), Slot: "dinner", CreatedBy: entity.NewID("user-1")},
+			err:    nil,
+		},
+		"not found": {
+			repo:   &mock.QueryMealRepository{},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.QueryMealRepository{
+				GetMealsResult: []*query.Meal{{ID: entity.NewID("meal-1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"unknown error": {
+			repo:   &mock.QueryMealRepository{GetMealsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				test.repo,
+			)
+			result, err := service.GetMeal(context.Background(), entity.NewID("user-1"), entity.NewID("meal-1"))
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestPlanMealIngredients(t *testing.T) {
+	t.Parallel()
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("pancakes"),
+				NumServings: 2,
+				Ingredients: []query.Ingredient{{Name: "egg", Quantity: 1}},
+			},
+			{
+				ID:          entity.NewID("omelette"),
+				NumServings: 1,
+				Ingredients: []query.Ingredient{{Name: "Egg", Quantity: 3}},
+			},
+		},
+	}
+
+	type testCase struct {
+		meals  query.MealRepository
+		result []query.Ingredient
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"totalled": {
+			meals: &mock.QueryMealRepository{
+				FindMealsResult: []*query.Meal{
+					{RecipeID: entity.NewID("pancakes"), Servings: 4},
+					{RecipeID: entity.NewID("omelette")},
+				},
+			},
+			result: []query.Ingredient{{Name: "egg", Quantity: 5}},
+			err:    nil,
+		},
+		"nothing planned": {
+			meals:  &mock.QueryMealRepository{},
+			result: []query.Ingredient{},
+			err:    nil,
+		},
+		"meal error": {
+			meals:  &mock.QueryMealRepository{FindMealsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				recipes,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				test.meals,
+			)
+			monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+			result, err := service.PlanMealIngredients(
+				context.Background(), entity.NewID("user-1"), monday, monday.AddDate(0, 0, 7),
+			)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
@@ -15,7 +15,6 @@ type Recipe struct {
 	Steps       []string
 	Ingredients []Ingredient
 	Tags        []string
-	IsFavorite  bool
 	CreatedAt   time.Time
 	CreatedBy   entity.ID
 	UpdatedAt   time.Time
@@ -30,17 +29,46 @@ type Ingredient struct {
 }
 
 // RecipeFilter defines all options available for finding recipes.
+// IsFavorite is checked against the favorites of the FavoriteOf user.
+// Recipes must contain every one of the Ingredients, which are matched as substrings of ingredient names.
+// Search is full-text searched by the Service, which limits repositories to the matching IDs.
 type RecipeFilter struct {
+	Search      *string
 	Name        *string
 	Ingredients []string
 	CreatedBy   *entity.ID
 	IsFavorite  *bool
+	FavoriteOf  entity.ID
+	IDs         []entity.ID
+}
+
+// Match is a recipe found by a full-text search, scored by how relevant it is.
+type Match struct {
+	ID    entity.ID
+	Score float64
+}
+
+// CookableRecipe is a recipe and how much of it can be made from the ingredients on hand.
+// Coverage is the fraction of its distinct ingredients on hand, and Missing names the rest.
+type CookableRecipe struct {
+	Recipe   *Recipe
+	Coverage float64
+	Missing  []string
+}
+
+// RecipeServings is a recipe and the number of servings wanted from it.
+// Servings of 0 or less use the number of servings in the recipe.
+type RecipeServings struct {
+	RecipeID entity.ID
+	Servings int
 }
 
 // RecipePage contains information about a page of recipes.
+// Cursors holds the Cursor of each of the Items.
 type RecipePage struct {
-	Info  PageInfo
-	Items []*Recipe
+	Info    PageInfo
+	Items   []*Recipe
+	Cursors []Cursor
 }
 
 // User is a query representation of a domain User.
@@ -53,11 +81,18 @@ type User struct {
 type Unit struct {
 	ID       entity.ID
 	Name     string
+	Plural   string
 	Symbol   string
 	BaseType string
 	System   string
 }
 
+// UnitFilter defines all options available for finding units.
+type UnitFilter struct {
+	BaseType *string
+	System   *string
+}
+
 // Conversion is a query representation of a domain Conversion.
 type Conversion struct {
 	FromID entity.ID
@@ -65,6 +100,58 @@ type Conversion struct {
 	Ratio  float64
 }
 
+// ShoppingList is a query representation of a domain shopping List.
+type ShoppingList struct {
+	ID        entity.ID
+	Name      string
+	Items     []ShoppingItem
+	CreatedAt time.Time
+	CreatedBy entity.ID
+	UpdatedAt time.Time
+	UpdatedBy entity.ID
+}
+
+// ShoppingItem is a query representation of a domain shopping list Item.
+type ShoppingItem struct {
+	ID       entity.ID
+	Name     string
+	Quantity float64
+	UnitID   entity.ID
+	Checked  bool
+}
+
+// PantryItem is a query representation of a domain pantry Item.
+type PantryItem struct {
+	ID        entity.ID
+	Name      string
+	Quantity  *float64
+	UnitID    entity.ID
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	CreatedBy entity.ID
+	UpdatedAt time.Time
+	UpdatedBy entity.ID
+}
+
+// PantryFilter defines all options available for finding pantry items.
+type PantryFilter struct {
+	Name          *string
+	ExpiresBefore *time.Time
+}
+
+// Meal is a query representation of a domain meal plan Meal.
+type Meal struct {
+	ID        entity.ID
+	Date      time.Time
+	Slot      string
+	RecipeID  entity.ID
+	Servings  int
+	CreatedAt time.Time
+	CreatedBy entity.ID
+	UpdatedAt time.Time
+	UpdatedBy entity.ID
+}
+
 // Direction is a sort direction.
 type Direction int
 
@@ -82,6 +169,7 @@ const (
 	CreatedSort Sort = iota
 	UpdatedSort
 	NameSort
+	RelevanceSort
 )
 
 // Order defines ordering information.
@@ -91,15 +179,23 @@ type Order struct {
 }
 
 // Cursor points to a specific item on a paged result.
+// It keeps the sort value of the item as well as its ID, so a page can carry on after the item even once it is gone.
+// Time is the created or updated time for those sorts, Name is set for NameSort,
+// and Score for RelevanceSort, which keeps Time as created time for when there is nothing to rank.
 type Cursor struct {
-	ID   entity.ID
-	Sort Sort
+	ID    entity.ID
+	Sort  Sort
+	Time  time.Time
+	Name  string
+	Score float64
 }
 
 // Pagination defines pagination controls for queries.
+// Pages start at the Cursor and run forward, or end at it when Backward is set.
 type Pagination struct {
-	Cursor *Cursor
-	Size   int
+	Cursor   *Cursor
+	Size     int
+	Backward bool
 }
 
 // PageInfo defines the boundries of a page.
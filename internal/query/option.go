@@ -0,0 +1,11 @@
+package query
+
+// Option is a query Service creation option.
+type Option func(s *Service)
+
+// WithRecipeSearch sets the full-text index used to search recipes.
+func WithRecipeSearch(search RecipeSearch) Option {
+	return func(s *Service) {
+		s.search = search
+	}
+}
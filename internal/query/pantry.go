@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// quantityTolerance absorbs floating point noise left over from unit conversions.
+const quantityTolerance = 1e-9
+
+// GetPantryItem returns a single pantry item of a user from an id.
+func (s *Service) GetPantryItem(ctx context.Context, userID entity.ID, id entity.ID) (*PantryItem, error) {
+	found, err := s.pantries.GetPantryItems(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	if len(found) == 0 || found[0].CreatedBy != userID {
+		return nil, entity.ErrNotFound
+	}
+
+	return found[0], nil
+}
+
+// FindPantryItems returns all pantry items of a user matching the given filter.
+func (s *Service) FindPantryItems(ctx context.Context, userID entity.ID, filter PantryFilter) ([]*PantryItem, error) {
+	found, err := s.pantries.FindPantryItems(ctx, userID, filter)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
+
+// ExpiringPantryItems returns all pantry items of a user that expire before the given time, soonest first.
+func (s *Service) ExpiringPantryItems(ctx context.Context, userID entity.ID, before time.Time) ([]*PantryItem, error) {
+	return s.FindPantryItems(ctx, userID, PantryFilter{Name: nil, ExpiresBefore: &before})
+}
+
+// StillNeeded plans the ingredients of several recipes and subtracts what a user already has in their pantry.
+// Pantry items match ingredients by name, and quantities are converted when the units differ but share a base type.
+// A pantry item without a quantity is assumed to cover any amount.
+// Missing recipes are reported as validation errors.
+func (s *Service) StillNeeded(
+	ctx context.Context,
+	userID entity.ID,
+	selections []RecipeServings,
+) ([]Ingredient, error) {
+	needed, graph, units, err := s.planIngredients(ctx, selections)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.pantries.FindPantryItems(ctx, userID, PantryFilter{Name: nil, ExpiresBefore: nil})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	available := make([]float64, len(items))
+	for i := range items {
+		if items[i].Quantity != nil {
+			available[i] = *items[i].Quantity
+		}
+	}
+
+	result := make([]Ingredient, 0, len(needed))
+
+	for _, ingredient := range needed {
+		remaining, covered := subtractPantry(ingredient, items, available, graph, units)
+		if covered {
+			continue
+		}
+
+		ingredient.Quantity = remaining
+		result = append(result, ingredient)
+	}
+
+	return simplifyIngredients(result, graph, units), nil
+}
+
+// subtractPantry takes an ingredient quantity out of the matching pantry items.
+// It returns the quantity still needed and whether the pantry covers the ingredient entirely.
+func subtractPantry(
+	ingredient Ingredient,
+	items []*PantryItem,
+	available []float64,
+	graph *unit.Graph,
+	units map[entity.ID]*unit.Unit,
+) (float64, bool) {
+	remaining := ingredient.Quantity
+
+	for i, item := range items {
+		if !strings.EqualFold(item.Name, ingredient.Name) {
+			continue
+		}
+
+		if item.Quantity == nil {
+			return 0, true
+		}
+
+		if available[i] <= quantityTolerance {
+			continue
+		}
+
+		ratio, ok := pantryRatio(item.UnitID, ingredient.UnitID, graph, units)
+		if !ok {
+			continue
+		}
+
+		used := min(available[i]*ratio, remaining)
+		available[i] -= used / ratio
+		remaining -= used
+
+		if remaining <= quantityTolerance {
+			return 0, true
+		}
+	}
+
+	return remaining, false
+}
+
+// pantryRatio returns how many of the ingredient unit make up one of the pantry unit.
+// Units can only be compared when they are the same or share a base type.
+func pantryRatio(from entity.ID, to entity.ID, graph *unit.Graph, units map[entity.ID]*unit.Unit) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	fromUnit, fromOK := units[from]
+	toUnit, toOK := units[to]
+
+	if !fromOK || !toOK || fromUnit.BaseType() != toUnit.BaseType() {
+		return 0, false
+	}
+
+	ratio, err := graph.Convert(1, fromUnit, toUnit)
+	if err != nil || ratio <= 0 {
+		return 0, false
+	}
+
+	return ratio, true
+}
@@ -0,0 +1,229 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func amount(value float64) *float64 {
+	return &value
+}
+
+func TestGetPantryItem(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.PantryRepository
+		result *query.PantryItem
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			repo: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{
+					{ID: entity.NewID("item-1"), Name: "flour", CreatedBy: entity.NewID("user-1")},
+				},
+			},
+			result: &query.PantryItem{ID: entity.NewID("item-1"), Name: "flour", CreatedBy: entity.NewID("user-1")},
+			err:    nil,
+		},
+		"not found": {
+			repo:   &mock.QueryPantryRepository{},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.QueryPantryRepository{
+				GetPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("item-1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"unknown error": {
+			repo:   &mock.QueryPantryRepository{GetPantryItemsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				test.repo,
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.GetPantryItem(context.Background(), entity.NewID("user-1"), entity.NewID("item-1"))
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestExpiringPantryItems(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.PantryRepository
+		result []*query.PantryItem
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			repo: &mock.QueryPantryRepository{
+				FindPantryItemsResult: []*query.PantryItem{{ID: entity.NewID("item-1")}},
+			},
+			result: []*query.PantryItem{{ID: entity.NewID("item-1")}},
+			err:    nil,
+		},
+		"unknown error": {
+			repo:   &mock.QueryPantryRepository{FindPantryItemsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				test.repo,
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.ExpiringPantryItems(context.Background(), entity.NewID("user-1"), time.Now())
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestStillNeeded(t *testing.T) {
+	t.Parallel()
+
+	teaspoon := unit.New("teaspoon", "tsp", unit.US, unit.Volume)
+	tablespoon := unit.New("tablespoon", "tbsp", unit.US, unit.Volume)
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+
+	units := &mock.QueryUnitRepository{
+		AllUnitsResult: []*query.Unit{
+			{ID: teaspoon.ID(), Name: "teaspoon", Symbol: "tsp", BaseType: "volume", System: "us"},
+			{ID: tablespoon.ID(), Name: "tablespoon", Symbol: "tbsp", BaseType: "volume", System: "us"},
+			{ID: cup.ID(), Name: "cup", Symbol: "c", BaseType: "volume", System: "us"},
+			{ID: gram.ID(), Name: "gram", Symbol: "g", BaseType: "mass", System: "metric"},
+		},
+		GetConversionsResult: []*query.Conversion{
+			{FromID: teaspoon.ID(), ToID: tablespoon.ID(), Ratio: 3},
+			{FromID: tablespoon.ID(), ToID: cup.ID(), Ratio: 16},
+		},
+	}
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("cookies"),
+				NumServings: 4,
+				Ingredients: []query.Ingredient{
+					{Name: "sugar", Quantity: 1, UnitID: cup.ID()},
+					{Name: "egg", Quantity: 2},
+					{Name: "flour", Quantity: 200, UnitID: gram.ID()},
+					{Name: "salt", Quantity: 0},
+					{Name: "butter", Quantity: 1, UnitID: cup.ID()},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		pantry query.PantryRepository
+		result []query.Ingredient
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"subtracted": {
+			pantry: &mock.QueryPantryRepository{
+				FindPantryItemsResult: []*query.PantryItem{
+					{Name: "Sugar", Quantity: amount(6), UnitID: tablespoon.ID()},
+					{Name: "sugar", Quantity: amount(0), UnitID: cup.ID()},
+					{Name: "egg", Quantity: amount(6)},
+					{Name: "flour", Quantity: amount(50), UnitID: gram.ID()},
+					{Name: "flour", Quantity: amount(50), UnitID: gram.ID()},
+					{Name: "salt", Quantity: nil},
+					{Name: "butter", Quantity: amount(500), UnitID: gram.ID()},
+				},
+			},
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 10, UnitID: tablespoon.ID()},
+				{Name: "flour", Quantity: 100, UnitID: gram.ID()},
+				{Name: "butter", Quantity: 1, UnitID: cup.ID()},
+			},
+			err: nil,
+		},
+		"empty pantry": {
+			pantry: &mock.QueryPantryRepository{},
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 1, UnitID: cup.ID()},
+				{Name: "egg", Quantity: 2},
+				{Name: "flour", Quantity: 200, UnitID: gram.ID()},
+				{Name: "salt", Quantity: 0},
+				{Name: "butter", Quantity: 1, UnitID: cup.ID()},
+			},
+			err: nil,
+		},
+		"pantry error": {
+			pantry: &mock.QueryPantryRepository{FindPantryItemsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				recipes,
+				units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				test.pantry,
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.StillNeeded(
+				context.Background(), entity.NewID("user-1"), []query.RecipeServings{{RecipeID: entity.NewID("cookies")}},
+			)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
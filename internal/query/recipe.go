@@ -1,15 +1,25 @@
 package query
 
 import (
+	"cmp"
 	"context"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+	"github.com/b-sea/supply-run-api/internal/unit"
 )
 
 const pagePadding = 2
 
+// cookablePageSize is how many recipes are loaded at a time while looking for cookable recipes.
+const cookablePageSize = 100
+
 // FindRecipes returns a list of recipes based on search criteria.
+// Backward pages end at their cursor instead of starting at it, and are still returned in the given order.
+// A cursor must have been made for the same sort it is used with.
 func (s *Service) FindRecipes(
 	ctx context.Context,
 	filter RecipeFilter,
@@ -17,19 +27,53 @@ func (s *Service) FindRecipes(
 	order Order,
 ) (*RecipePage, error) {
 	result := &RecipePage{
-		Info:  PageInfo{},
-		Items: make([]*Recipe, 0),
+		Info:    PageInfo{},
+		Items:   make([]*Recipe, 0),
+		Cursors: make([]Cursor, 0),
+	}
+
+	if page.Size < 0 {
+		return nil, &entity.ValidationError{
+			InnerErrors: []error{entity.NewFieldError("size", "page size must not be negative")},
+		}
 	}
 
 	if page.Size == 0 {
 		return result, nil
 	}
 
+	if page.Cursor != nil && page.Cursor.Sort != order.Sort {
+		return nil, ErrInvalidCursor
+	}
+
 	// Use the +2 trick: https://stackoverflow.com/a/66300422
 	pageSize := page.Size
 	page.Size += pagePadding
 
-	found, err := s.recipes.FindRecipes(ctx, filter, page, order)
+	if page.Backward {
+		// A backward page is a forward page in the opposite order, flipped back afterwards.
+		order.Direction = reverseDirection(order.Direction)
+	}
+
+	var (
+		found  []*Recipe
+		scores map[entity.ID]float64
+		err    error
+	)
+
+	if filter.Search == nil {
+		found, err = s.recipes.FindRecipes(ctx, filter, page, order)
+	} else {
+		matches := s.search.SearchRecipes(*filter.Search)
+
+		scores = make(map[entity.ID]float64, len(matches))
+		for _, match := range matches {
+			scores[match.ID] = match.Score
+		}
+
+		found, err = s.searchRecipes(ctx, filter, page, order, matches)
+	}
+
 	if err != nil {
 		return nil, queryError(err)
 	}
@@ -38,40 +82,179 @@ func (s *Service) FindRecipes(
 		return result, nil
 	}
 
-	sort := CreatedSort
-
+	// Pages start at their cursor, unless it has been removed since.
 	if page.Cursor != nil && page.Cursor.ID == found[0].ID {
-		sort = page.Cursor.Sort
-		result.Info.HasPreviousPage = true
 		found = found[1:]
 	}
 
 	count := len(found)
-	result.Info.HasNextPage = count > pageSize
+	hasBefore, hasAfter := page.Cursor != nil, count > pageSize
+
+	result.Info.HasPreviousPage, result.Info.HasNextPage = hasBefore, hasAfter
+	if page.Backward {
+		result.Info.HasPreviousPage, result.Info.HasNextPage = hasAfter, hasBefore
+	}
 
 	if count == 0 {
 		return result, nil
 	}
 
-	if pageSize > count {
-		pageSize = count
+	result.Items = append(result.Items, found[:min(pageSize, count)]...)
+
+	if page.Backward {
+		slices.Reverse(result.Items)
 	}
 
-	for i := range pageSize {
-		result.Items = append(result.Items, found[i])
+	for _, recipe := range result.Items {
+		result.Cursors = append(result.Cursors, newCursor(recipe, order.Sort, scores[recipe.ID]))
 	}
 
-	result.Info.StartCursor = &Cursor{
-		ID:   result.Items[0].ID,
-		Sort: sort,
+	result.Info.StartCursor = &result.Cursors[0]
+	result.Info.EndCursor = &result.Cursors[len(result.Cursors)-1]
+
+	return result, nil
+}
+
+// newCursor creates the Cursor of a recipe for the given sort.
+func newCursor(recipe *Recipe, sort Sort, score float64) Cursor {
+	result := Cursor{
+		ID:    recipe.ID,
+		Sort:  sort,
+		Time:  time.Time{},
+		Name:  "",
+		Score: 0,
 	}
 
-	result.Info.EndCursor = &Cursor{
-		ID:   result.Items[len(result.Items)-1].ID,
-		Sort: sort,
+	switch sort {
+	case NameSort:
+		result.Name = recipe.Name
+	case UpdatedSort:
+		result.Time = recipe.UpdatedAt
+	case RelevanceSort:
+		result.Score = score
+		result.Time = recipe.CreatedAt
+	case CreatedSort:
+		fallthrough
+	default:
+		result.Time = recipe.CreatedAt
 	}
 
-	return result, nil
+	return result
+}
+
+// CountRecipes returns how many recipes match the search criteria.
+func (s *Service) CountRecipes(ctx context.Context, filter RecipeFilter) (int, error) {
+	if filter.Search != nil {
+		matches := s.search.SearchRecipes(*filter.Search)
+		if len(matches) == 0 {
+			return 0, nil
+		}
+
+		filter.IDs = matchIDs(matches)
+	}
+
+	count, err := s.recipes.CountRecipes(ctx, filter)
+	if err != nil {
+		return 0, queryError(err)
+	}
+
+	return count, nil
+}
+
+func reverseDirection(direction Direction) Direction {
+	if direction == AscDirection {
+		return DescDirection
+	}
+
+	return AscDirection
+}
+
+// searchRecipes finds the recipes matching a full-text search.
+// Relevance is only known to the search index, so relevance sorted pages are cut here instead of in the repository.
+func (s *Service) searchRecipes(
+	ctx context.Context,
+	filter RecipeFilter,
+	page Pagination,
+	order Order,
+	matches []Match,
+) ([]*Recipe, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	filter.IDs = matchIDs(matches)
+
+	if order.Sort != RelevanceSort {
+		return s.recipes.FindRecipes(ctx, filter, page, order)
+	}
+
+	all := Pagination{Cursor: nil, Size: len(matches), Backward: false}
+
+	found, err := s.recipes.FindRecipes(ctx, filter, all, Order{})
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := make(map[entity.ID]*Recipe, len(found))
+	for _, recipe := range found {
+		lookup[recipe.ID] = recipe
+	}
+
+	ranked := make([]*Recipe, 0, len(found))
+	scores := make([]float64, 0, len(found))
+
+	for _, match := range matches {
+		if recipe, ok := lookup[match.ID]; ok {
+			ranked = append(ranked, recipe)
+			scores = append(scores, match.Score)
+		}
+	}
+
+	if order.Direction == AscDirection {
+		slices.Reverse(ranked)
+		slices.Reverse(scores)
+	}
+
+	start := 0
+
+	if page.Cursor != nil {
+		// Like the repositories, a page starts at its cursor, or where it would be if it is gone.
+		start = len(ranked)
+
+		for i, recipe := range ranked {
+			if !rankedBefore(scores[i], recipe.ID, page.Cursor, order.Direction) {
+				start = i
+
+				break
+			}
+		}
+	}
+
+	return ranked[start:min(start+page.Size, len(ranked))], nil
+}
+
+// rankedBefore reports whether a ranked recipe comes before the cursor.
+// Matches are ranked by score, most relevant first, and then by ID.
+func rankedBefore(score float64, id entity.ID, cursor *Cursor, direction Direction) bool {
+	compare := cmp.Compare(cursor.Score, score)
+	if compare == 0 {
+		compare = cmp.Compare(id.String(), cursor.ID.String())
+	}
+
+	if direction == AscDirection {
+		compare = -compare
+	}
+
+	return compare < 0
+}
+
+func matchIDs(matches []Match) []entity.ID {
+	result := make([]entity.ID, len(matches))
+	for i, match := range matches {
+		result[i] = match.ID
+	}
+
+	return result
 }
 
 // GetRecipe returns a single recipe from an id.
@@ -88,6 +271,47 @@ func (s *Service) GetRecipe(ctx context.Context, id entity.ID) (*Recipe, error)
 	return found[0], nil
 }
 
+// GetRecipes returns all recipes matching the given ids.
+func (s *Service) GetRecipes(ctx context.Context, ids []entity.ID) ([]*Recipe, error) {
+	found, err := s.recipes.GetRecipes(ctx, ids)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
+
+// ParseIngredients parses free-text ingredient lines, resolving units against every known unit.
+func (s *Service) ParseIngredients(ctx context.Context, lines []string) ([]ingredient.Line, error) {
+	parser, err := s.IngredientParser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ingredient.Line, len(lines))
+	for i, line := range lines {
+		result[i] = parser.Parse(line)
+	}
+
+	return result, nil
+}
+
+// IngredientParser returns an ingredient parser that knows every unit,
+// for parsing many batches of lines against the same units.
+func (s *Service) IngredientParser(ctx context.Context) (*ingredient.Parser, error) {
+	found, err := s.units.AllUnits(ctx)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	units := make([]*unit.Unit, len(found))
+	for i := range found {
+		units[i] = newUnit(found[i])
+	}
+
+	return ingredient.NewParser(units), nil
+}
+
 // FindTags returns a unique case-insensitive list of recipe tags.
 func (s *Service) FindTags(ctx context.Context, filter *string) ([]string, error) {
 	found, err := s.recipes.FindTags(ctx, filter)
@@ -99,3 +323,161 @@ func (s *Service) FindTags(ctx context.Context, filter *string) ([]string, error
 
 	return found, nil
 }
+
+// ScaleIngredients scales ingredient quantities from one number of servings to another.
+// Scaled quantities are moved into the most sensible unit of the same system.
+// Recipes without a number of servings are treated as a single serving.
+func (s *Service) ScaleIngredients(
+	ctx context.Context,
+	ingredients []Ingredient,
+	numServings int,
+	servings int,
+) ([]Ingredient, error) {
+	if servings <= 0 {
+		return nil, &entity.ValidationError{
+			InnerErrors: []error{entity.NewFieldError("servings", "servings must be greater than 0")},
+		}
+	}
+
+	factor := scaleFactor(numServings, servings)
+	result := make([]Ingredient, len(ingredients))
+
+	var (
+		graph *unit.Graph
+		units map[entity.ID]*unit.Unit
+	)
+
+	for i, ingredient := range ingredients {
+		result[i] = Ingredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity * factor,
+			UnitID:   ingredient.UnitID,
+		}
+
+		if ingredient.UnitID.String() == "" {
+			continue
+		}
+
+		if graph == nil {
+			var err error
+
+			graph, units, err = s.unitGraph(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		from, ok := units[ingredient.UnitID]
+		if !ok {
+			continue
+		}
+
+		quantity, to := graph.Simplify(result[i].Quantity, from)
+		result[i].Quantity = quantity
+		result[i].UnitID = to.ID()
+	}
+
+	return result, nil
+}
+
+// scaleFactor returns how much to multiply quantities by to go from one number of servings to another.
+// Recipes without a number of servings are treated as a single serving.
+func scaleFactor(numServings int, servings int) float64 {
+	factor := float64(servings)
+	if numServings > 0 {
+		factor /= float64(numServings)
+	}
+
+	return factor
+}
+
+// CookableRecipes finds the recipes using the ingredients on hand, the best covered first.
+// Ingredients on hand match recipe ingredients as described by ingredient.Matches.
+// Recipes using none of them are left out, as are recipes missing more than maxMissing ingredients when it is set.
+// At most limit recipes are returned.
+func (s *Service) CookableRecipes(
+	ctx context.Context,
+	have []string,
+	maxMissing *int,
+	limit int,
+) ([]*CookableRecipe, error) {
+	result := make([]*CookableRecipe, 0)
+	if len(have) == 0 {
+		return result, nil
+	}
+
+	page := Pagination{Cursor: nil, Size: cookablePageSize, Backward: false}
+	order := Order{Sort: CreatedSort, Direction: AscDirection}
+
+	for {
+		found, err := s.FindRecipes(ctx, RecipeFilter{}, page, order)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, recipe := range found.Items {
+			cookable := newCookableRecipe(recipe, have)
+			if cookable == nil || (maxMissing != nil && len(cookable.Missing) > *maxMissing) {
+				continue
+			}
+
+			result = append(result, cookable)
+		}
+
+		if !found.Info.HasNextPage {
+			break
+		}
+
+		page.Cursor = found.Info.EndCursor
+	}
+
+	slices.SortStableFunc(result, func(a *CookableRecipe, b *CookableRecipe) int {
+		if a.Coverage != b.Coverage {
+			return cmp.Compare(b.Coverage, a.Coverage)
+		}
+
+		if len(a.Missing) != len(b.Missing) {
+			return cmp.Compare(len(a.Missing), len(b.Missing))
+		}
+
+		return cmp.Compare(strings.ToLower(a.Recipe.Name), strings.ToLower(b.Recipe.Name))
+	})
+
+	return result[:min(len(result), max(limit, 0))], nil
+}
+
+// newCookableRecipe works out how much of a recipe is covered by the ingredients on hand.
+// Ingredients listed more than once only count once, and nil is returned when nothing is covered.
+func newCookableRecipe(recipe *Recipe, have []string) *CookableRecipe {
+	seen := make(map[string]bool, len(recipe.Ingredients))
+	missing := make([]string, 0)
+	total, covered := 0, 0
+
+	for _, item := range recipe.Ingredients {
+		key := ingredient.Key(item.Name)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		total++
+
+		if slices.ContainsFunc(have, func(name string) bool { return ingredient.Matches(name, key) }) {
+			covered++
+
+			continue
+		}
+
+		missing = append(missing, item.Name)
+	}
+
+	if covered == 0 {
+		return nil
+	}
+
+	return &CookableRecipe{
+		Recipe:   recipe,
+		Coverage: float64(covered) / float64(total),
+		Missing:  missing,
+	}
+}
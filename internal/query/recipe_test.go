@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/ingredient"
 	"github.com/b-sea/supply-run-api/internal/mock"
 	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,6 +60,11 @@ func TestFindRecipe(t *testing.T) {
 					{ID: entity.NewID("2")},
 					{ID: entity.NewID("3")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Sort: query.CreatedSort},
+					{ID: entity.NewID("2"), Sort: query.CreatedSort},
+					{ID: entity.NewID("3"), Sort: query.CreatedSort},
+				},
 			},
 			err: nil,
 		},
@@ -96,6 +103,11 @@ func TestFindRecipe(t *testing.T) {
 					{ID: entity.NewID("4")},
 					{ID: entity.NewID("5")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("3"), Sort: query.CreatedSort},
+					{ID: entity.NewID("4"), Sort: query.CreatedSort},
+					{ID: entity.NewID("5"), Sort: query.CreatedSort},
+				},
 			},
 			err: nil,
 		},
@@ -131,6 +143,137 @@ func TestFindRecipe(t *testing.T) {
 					{ID: entity.NewID("6")},
 					{ID: entity.NewID("7")},
 				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("6"), Sort: query.CreatedSort},
+					{ID: entity.NewID("7"), Sort: query.CreatedSort},
+				},
+			},
+			err: nil,
+		},
+		"last page backward": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{
+					{ID: entity.NewID("7")},
+					{ID: entity.NewID("6")},
+					{ID: entity.NewID("5")},
+					{ID: entity.NewID("4")},
+					{ID: entity.NewID("3")},
+				},
+				FindRecipesErr: nil,
+			},
+			filter: query.RecipeFilter{},
+			page: query.Pagination{
+				Size:     3,
+				Backward: true,
+			},
+			order: query.Order{},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: true,
+					StartCursor: &query.Cursor{
+						ID:   entity.NewID("5"),
+						Sort: query.CreatedSort,
+					},
+					EndCursor: &query.Cursor{
+						ID:   entity.NewID("7"),
+						Sort: query.CreatedSort,
+					},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("5")},
+					{ID: entity.NewID("6")},
+					{ID: entity.NewID("7")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("5"), Sort: query.CreatedSort},
+					{ID: entity.NewID("6"), Sort: query.CreatedSort},
+					{ID: entity.NewID("7"), Sort: query.CreatedSort},
+				},
+			},
+			err: nil,
+		},
+		"middle page backward": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{
+					{ID: entity.NewID("5")},
+					{ID: entity.NewID("4")},
+					{ID: entity.NewID("3")},
+					{ID: entity.NewID("2")},
+					{ID: entity.NewID("1")},
+				},
+				FindRecipesErr: nil,
+			},
+			filter: query.RecipeFilter{},
+			page: query.Pagination{
+				Cursor:   &query.Cursor{ID: entity.NewID("5")},
+				Size:     3,
+				Backward: true,
+			},
+			order: query.Order{},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: true,
+					StartCursor: &query.Cursor{
+						ID:   entity.NewID("2"),
+						Sort: query.CreatedSort,
+					},
+					EndCursor: &query.Cursor{
+						ID:   entity.NewID("4"),
+						Sort: query.CreatedSort,
+					},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("2")},
+					{ID: entity.NewID("3")},
+					{ID: entity.NewID("4")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("2"), Sort: query.CreatedSort},
+					{ID: entity.NewID("3"), Sort: query.CreatedSort},
+					{ID: entity.NewID("4"), Sort: query.CreatedSort},
+				},
+			},
+			err: nil,
+		},
+		"first page backward": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{
+					{ID: entity.NewID("3")},
+					{ID: entity.NewID("2")},
+					{ID: entity.NewID("1")},
+				},
+				FindRecipesErr: nil,
+			},
+			filter: query.RecipeFilter{},
+			page: query.Pagination{
+				Cursor:   &query.Cursor{ID: entity.NewID("3")},
+				Size:     3,
+				Backward: true,
+			},
+			order: query.Order{},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: false,
+					StartCursor: &query.Cursor{
+						ID:   entity.NewID("1"),
+						Sort: query.CreatedSort,
+					},
+					EndCursor: &query.Cursor{
+						ID:   entity.NewID("2"),
+						Sort: query.CreatedSort,
+					},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("1")},
+					{ID: entity.NewID("2")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Sort: query.CreatedSort},
+					{ID: entity.NewID("2"), Sort: query.CreatedSort},
+				},
 			},
 			err: nil,
 		},
@@ -143,8 +286,9 @@ func TestFindRecipe(t *testing.T) {
 			page:   query.Pagination{},
 			order:  query.Order{},
 			result: &query.RecipePage{
-				Info:  query.PageInfo{},
-				Items: []*query.Recipe{},
+				Info:    query.PageInfo{},
+				Items:   []*query.Recipe{},
+				Cursors: []query.Cursor{},
 			},
 			err: nil,
 		},
@@ -159,11 +303,39 @@ func TestFindRecipe(t *testing.T) {
 			},
 			order: query.Order{},
 			result: &query.RecipePage{
-				Info:  query.PageInfo{},
-				Items: []*query.Recipe{},
+				Info:    query.PageInfo{},
+				Items:   []*query.Recipe{},
+				Cursors: []query.Cursor{},
 			},
 			err: nil,
 		},
+		"cursor for another sort": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: []*query.Recipe{{ID: entity.NewID("1")}},
+				FindRecipesErr:    nil,
+			},
+			filter: query.RecipeFilter{},
+			page: query.Pagination{
+				Cursor: &query.Cursor{ID: entity.NewID("1"), Sort: query.NameSort, Name: "pie"},
+				Size:   3,
+			},
+			order:  query.Order{Sort: query.CreatedSort},
+			result: nil,
+			err:    query.ErrInvalidCursor,
+		},
+		"negative page size": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: nil,
+				FindRecipesErr:    errors.New("should not be called"),
+			},
+			filter: query.RecipeFilter{},
+			page: query.Pagination{
+				Size: -10,
+			},
+			order:  query.Order{},
+			result: nil,
+			err:    &entity.ValidationError{},
+		},
 		"unknown error": {
 			repo: &mock.QueryRecipeRepository{
 				FindRecipesResult: nil,
@@ -181,7 +353,14 @@ func TestFindRecipe(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			service := query.NewService(test.repo, &mock.QueryUnitRepository{}, &mock.QueryUserRepository{})
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
 			result, err := service.FindRecipes(context.Background(), test.filter, test.page, test.order)
 
 			assert.Equal(t, test.result, result)
@@ -194,6 +373,374 @@ func TestFindRecipe(t *testing.T) {
 	}
 }
 
+func TestCountRecipes(t *testing.T) {
+	t.Parallel()
+
+	search := "pasta"
+
+	type testCase struct {
+		repo   query.RecipeRepository
+		search query.RecipeSearch
+		filter query.RecipeFilter
+		result int
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo:   &mock.QueryRecipeRepository{CountRecipesResult: 12},
+			search: &mock.RecipeSearch{},
+			filter: query.RecipeFilter{},
+			result: 12,
+			err:    nil,
+		},
+		"search": {
+			repo: &mock.QueryRecipeRepository{CountRecipesResult: 1},
+			search: &mock.RecipeSearch{
+				SearchRecipesResult: []query.Match{{ID: entity.NewID("1"), Score: 1}},
+			},
+			filter: query.RecipeFilter{Search: &search},
+			result: 1,
+			err:    nil,
+		},
+		"search without matches": {
+			repo:   &mock.QueryRecipeRepository{CountRecipesResult: 12},
+			search: &mock.RecipeSearch{},
+			filter: query.RecipeFilter{Search: &search},
+			result: 0,
+			err:    nil,
+		},
+		"repo error": {
+			repo:   &mock.QueryRecipeRepository{CountRecipesErr: errors.New("something went wrong")},
+			search: &mock.RecipeSearch{},
+			filter: query.RecipeFilter{},
+			result: 0,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+				query.WithRecipeSearch(test.search),
+			)
+			result, err := service.CountRecipes(context.Background(), test.filter)
+
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestFindRecipeSearch(t *testing.T) {
+	t.Parallel()
+
+	search := "pasta"
+	matches := []query.Match{
+		{ID: entity.NewID("3"), Score: 3},
+		{ID: entity.NewID("1"), Score: 2},
+		{ID: entity.NewID("2"), Score: 1},
+	}
+	found := []*query.Recipe{
+		{ID: entity.NewID("1")},
+		{ID: entity.NewID("2")},
+		{ID: entity.NewID("3")},
+	}
+
+	type testCase struct {
+		search query.RecipeSearch
+		repo   query.RecipeRepository
+		page   query.Pagination
+		order  query.Order
+		result *query.RecipePage
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"relevance first page": {
+			search: &mock.RecipeSearch{SearchRecipesResult: matches},
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: found},
+			page:   query.Pagination{Size: 2},
+			order:  query.Order{Sort: query.RelevanceSort},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: false,
+					StartCursor:     &query.Cursor{ID: entity.NewID("3"), Sort: query.RelevanceSort, Score: 3},
+					EndCursor:       &query.Cursor{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 2},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("3")},
+					{ID: entity.NewID("1")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("3"), Sort: query.RelevanceSort, Score: 3},
+					{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 2},
+				},
+			},
+			err: nil,
+		},
+		"relevance last page": {
+			search: &mock.RecipeSearch{SearchRecipesResult: matches},
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: found},
+			page: query.Pagination{
+				Cursor: &query.Cursor{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 2},
+				Size:   2,
+			},
+			order: query.Order{Sort: query.RelevanceSort},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: true,
+					StartCursor:     &query.Cursor{ID: entity.NewID("2"), Sort: query.RelevanceSort, Score: 1},
+					EndCursor:       &query.Cursor{ID: entity.NewID("2"), Sort: query.RelevanceSort, Score: 1},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("2")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("2"), Sort: query.RelevanceSort, Score: 1},
+				},
+			},
+			err: nil,
+		},
+		"relevance ascending": {
+			search: &mock.RecipeSearch{SearchRecipesResult: matches},
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: found},
+			page:   query.Pagination{Size: 3},
+			order:  query.Order{Sort: query.RelevanceSort, Direction: query.AscDirection},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: false,
+					StartCursor:     &query.Cursor{ID: entity.NewID("2"), Sort: query.RelevanceSort, Score: 1},
+					EndCursor:       &query.Cursor{ID: entity.NewID("3"), Sort: query.RelevanceSort, Score: 3},
+				},
+				Items: []*query.Recipe{
+					{ID: entity.NewID("2")},
+					{ID: entity.NewID("1")},
+					{ID: entity.NewID("3")},
+				},
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("2"), Sort: query.RelevanceSort, Score: 1},
+					{ID: entity.NewID("1"), Sort: query.RelevanceSort, Score: 2},
+					{ID: entity.NewID("3"), Sort: query.RelevanceSort, Score: 3},
+				},
+			},
+			err: nil,
+		},
+		"other sort": {
+			search: &mock.RecipeSearch{SearchRecipesResult: matches},
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: found},
+			page:   query.Pagination{Size: 3},
+			order:  query.Order{Sort: query.NameSort},
+			result: &query.RecipePage{
+				Info: query.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: false,
+					StartCursor:     &query.Cursor{ID: entity.NewID("1"), Sort: query.NameSort},
+					EndCursor:       &query.Cursor{ID: entity.NewID("3"), Sort: query.NameSort},
+				},
+				Items: found,
+				Cursors: []query.Cursor{
+					{ID: entity.NewID("1"), Sort: query.NameSort},
+					{ID: entity.NewID("2"), Sort: query.NameSort},
+					{ID: entity.NewID("3"), Sort: query.NameSort},
+				},
+			},
+			err: nil,
+		},
+		"no matches": {
+			search: &mock.RecipeSearch{SearchRecipesResult: nil},
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: found},
+			page:   query.Pagination{Size: 3},
+			order:  query.Order{Sort: query.RelevanceSort},
+			result: &query.RecipePage{
+				Info:    query.PageInfo{},
+				Items:   []*query.Recipe{},
+				Cursors: []query.Cursor{},
+			},
+			err: nil,
+		},
+		"unknown error": {
+			search: &mock.RecipeSearch{SearchRecipesResult: matches},
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: nil,
+				FindRecipesErr:    errors.New("something went wrong"),
+			},
+			page:   query.Pagination{Size: 3},
+			order:  query.Order{Sort: query.RelevanceSort},
+			result: nil,
+			err:    errors.New("something went wrong"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+				query.WithRecipeSearch(test.search),
+			)
+			result, err := service.FindRecipes(
+				context.Background(),
+				query.RecipeFilter{Search: &search},
+				test.page,
+				test.order,
+			)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestCookableRecipes(t *testing.T) {
+	t.Parallel()
+
+	omelette := &query.Recipe{
+		ID:   entity.NewID("omelette"),
+		Name: "Omelette",
+		Ingredients: []query.Ingredient{
+			{Name: "eggs"},
+			{Name: "Milk"},
+			{Name: "egg"},
+		},
+	}
+	pancakes := &query.Recipe{
+		ID:   entity.NewID("pancakes"),
+		Name: "pancakes",
+		Ingredients: []query.Ingredient{
+			{Name: "all-purpose flour"},
+			{Name: "eggs"},
+			{Name: "milk"},
+			{Name: "baking powder"},
+		},
+	}
+	crepes := &query.Recipe{
+		ID:   entity.NewID("crepes"),
+		Name: "Crepes",
+		Ingredients: []query.Ingredient{
+			{Name: "flour"},
+			{Name: "egg"},
+			{Name: "milk"},
+			{Name: "butter"},
+		},
+	}
+	salad := &query.Recipe{
+		ID:          entity.NewID("salad"),
+		Name:        "Salad",
+		Ingredients: []query.Ingredient{{Name: "lettuce"}},
+	}
+	recipes := []*query.Recipe{omelette, pancakes, crepes, salad}
+	zero, one := 0, 1
+
+	type testCase struct {
+		repo       query.RecipeRepository
+		have       []string
+		maxMissing *int
+		limit      int
+		result     []*query.CookableRecipe
+		err        error
+	}
+
+	tests := map[string]testCase{
+		"ranked by coverage": {
+			repo:  &mock.QueryRecipeRepository{FindRecipesResult: recipes},
+			have:  []string{"Egg", "milk", "flour"},
+			limit: 10,
+			result: []*query.CookableRecipe{
+				{Recipe: omelette, Coverage: 1, Missing: []string{}},
+				{Recipe: crepes, Coverage: 0.75, Missing: []string{"butter"}},
+				{Recipe: pancakes, Coverage: 0.75, Missing: []string{"baking powder"}},
+			},
+			err: nil,
+		},
+		"limited": {
+			repo:  &mock.QueryRecipeRepository{FindRecipesResult: recipes},
+			have:  []string{"Egg", "milk", "flour"},
+			limit: 2,
+			result: []*query.CookableRecipe{
+				{Recipe: omelette, Coverage: 1, Missing: []string{}},
+				{Recipe: crepes, Coverage: 0.75, Missing: []string{"butter"}},
+			},
+			err: nil,
+		},
+		"at most one missing": {
+			repo:       &mock.QueryRecipeRepository{FindRecipesResult: recipes},
+			have:       []string{"eggs", "milk"},
+			maxMissing: &one,
+			limit:      10,
+			result: []*query.CookableRecipe{
+				{Recipe: omelette, Coverage: 1, Missing: []string{}},
+			},
+			err: nil,
+		},
+		"nothing missing": {
+			repo:       &mock.QueryRecipeRepository{FindRecipesResult: recipes},
+			have:       []string{"lettuce", "eggs"},
+			maxMissing: &zero,
+			limit:      10,
+			result: []*query.CookableRecipe{
+				{Recipe: salad, Coverage: 1, Missing: []string{}},
+			},
+			err: nil,
+		},
+		"nothing on hand": {
+			repo:   &mock.QueryRecipeRepository{FindRecipesResult: recipes},
+			have:   []string{},
+			limit:  10,
+			result: []*query.CookableRecipe{},
+			err:    nil,
+		},
+		"repo error": {
+			repo: &mock.QueryRecipeRepository{
+				FindRecipesResult: nil,
+				FindRecipesErr:    errors.New("something went wrong"),
+			},
+			have:   []string{"eggs"},
+			limit:  10,
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.CookableRecipes(context.Background(), test.have, test.maxMissing, test.limit)
+
+			assert.Equal(t, test.result, result)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
 func TestGetRecipe(t *testing.T) {
 	t.Parallel()
 
@@ -240,7 +787,14 @@ func TestGetRecipe(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			service := query.NewService(test.repo, &mock.QueryUnitRepository{}, &mock.QueryUserRepository{})
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
 			result, err := service.GetRecipe(context.Background(), test.id)
 
 			assert.Equal(t, test.result, result)
@@ -286,7 +840,14 @@ func TestFindTags(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			service := query.NewService(test.repo, &mock.QueryUnitRepository{}, &mock.QueryUserRepository{})
+			service := query.NewService(
+				test.repo,
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
 			result, err := service.FindTags(context.Background(), nil)
 
 			assert.Equal(t, test.result, result)
@@ -298,3 +859,168 @@ func TestFindTags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseIngredients(t *testing.T) {
+	t.Parallel()
+
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+	leaf := unit.New("leaf", "lf", unit.WithCustomPlural("leaves"))
+
+	type testCase struct {
+		units  query.UnitRepository
+		result []ingredient.Line
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			units: &mock.QueryUnitRepository{
+				AllUnitsResult: []*query.Unit{
+					{ID: cup.ID(), Name: "cup", Symbol: "c", BaseType: "volume", System: "us"},
+					{ID: leaf.ID(), Name: "leaf", Plural: "leaves", Symbol: "lf"},
+				},
+			},
+			result: []ingredient.Line{
+				{
+					Text: "1 1/2 cups flour, sifted", Quantity: 1.5, MaxQuantity: 1.5, UnitID: cup.ID(),
+					Name: "flour", Note: "sifted",
+				},
+				{Text: "2 leaves basil", Quantity: 2, MaxQuantity: 2, UnitID: leaf.ID(), Name: "basil"},
+			},
+			err: nil,
+		},
+		"unknown error": {
+			units:  &mock.QueryUnitRepository{AllUnitsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.ParseIngredients(
+				context.Background(), []string{"1 1/2 cups flour, sifted", "2 leaves basil"},
+			)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestScaleIngredients(t *testing.T) {
+	t.Parallel()
+
+	teaspoon := unit.New("teaspoon", "tsp", unit.US, unit.Volume)
+	tablespoon := unit.New("tablespoon", "tbsp", unit.US, unit.Volume)
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+
+	units := &mock.QueryUnitRepository{
+		AllUnitsResult: []*query.Unit{
+			{ID: teaspoon.ID(), Name: "teaspoon", Symbol: "tsp", BaseType: "volume", System: "us"},
+			{ID: tablespoon.ID(), Name: "tablespoon", Symbol: "tbsp", BaseType: "volume", System: "us"},
+			{ID: cup.ID(), Name: "cup", Symbol: "c", BaseType: "volume", System: "us"},
+		},
+		GetConversionsResult: []*query.Conversion{
+			{FromID: teaspoon.ID(), ToID: tablespoon.ID(), Ratio: 3},
+			{FromID: tablespoon.ID(), ToID: cup.ID(), Ratio: 16},
+		},
+	}
+
+	ingredients := []query.Ingredient{
+		{Name: "sugar", Quantity: 12, UnitID: teaspoon.ID()},
+		{Name: "egg", Quantity: 1},
+		{Name: "saffron", Quantity: 1, UnitID: entity.NewID("pinch")},
+	}
+
+	type testCase struct {
+		units       query.UnitRepository
+		numServings int
+		servings    int
+		result      []query.Ingredient
+		err         error
+	}
+
+	tests := map[string]testCase{
+		"scale up": {
+			units:       units,
+			numServings: 2,
+			servings:    8,
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 1, UnitID: cup.ID()},
+				{Name: "egg", Quantity: 4},
+				{Name: "saffron", Quantity: 4, UnitID: entity.NewID("pinch")},
+			},
+			err: nil,
+		},
+		"scale down": {
+			units:       units,
+			numServings: 4,
+			servings:    1,
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 1, UnitID: tablespoon.ID()},
+				{Name: "egg", Quantity: 0.25},
+				{Name: "saffron", Quantity: 0.25, UnitID: entity.NewID("pinch")},
+			},
+			err: nil,
+		},
+		"no servings": {
+			units:       units,
+			numServings: 0,
+			servings:    1,
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 4, UnitID: tablespoon.ID()},
+				{Name: "egg", Quantity: 1},
+				{Name: "saffron", Quantity: 1, UnitID: entity.NewID("pinch")},
+			},
+			err: nil,
+		},
+		"invalid servings": {
+			units:       units,
+			numServings: 2,
+			servings:    0,
+			result:      nil,
+			err:         &entity.ValidationError{},
+		},
+		"unknown error": {
+			units:       &mock.QueryUnitRepository{AllUnitsErr: errors.New("something went wrong")},
+			numServings: 2,
+			servings:    4,
+			result:      nil,
+			err:         query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.ScaleIngredients(context.Background(), ingredients, test.numServings, test.servings)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
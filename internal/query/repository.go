@@ -2,6 +2,7 @@ package query
 
 import (
 	"context"
+	"time"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
 )
@@ -9,6 +10,7 @@ import (
 // RecipeRepository defines all data interactions required for querying recipes.
 type RecipeRepository interface {
 	FindRecipes(ctx context.Context, filter RecipeFilter, page Pagination, order Order) ([]*Recipe, error)
+	CountRecipes(ctx context.Context, filter RecipeFilter) (int, error)
 	GetRecipes(ctx context.Context, ids []entity.ID) ([]*Recipe, error)
 	FindTags(ctx context.Context, filter *string) ([]string, error)
 }
@@ -16,9 +18,37 @@ type RecipeRepository interface {
 // UnitRepository defines all data interactions required for querying units.
 type UnitRepository interface {
 	GetUnits(ctx context.Context, ids []entity.ID) ([]*Unit, error)
+	GetConversions(ctx context.Context, ids []entity.ID) ([]*Conversion, error)
+	GetConversionPath(ctx context.Context, from *Unit, to *Unit) ([]*Conversion, error)
+	AllUnits(ctx context.Context) ([]*Unit, error)
 }
 
 // UserRepository defines all data interactions required for querying users.
 type UserRepository interface {
 	GetUsers(ctx context.Context, ids []entity.ID) ([]*User, error)
+	GetFavorites(ctx context.Context, userID entity.ID, recipeIDs []entity.ID) ([]entity.ID, error)
+}
+
+// ShoppingListRepository defines all data interactions required for querying shopping lists.
+type ShoppingListRepository interface {
+	GetShoppingLists(ctx context.Context, ids []entity.ID) ([]*ShoppingList, error)
+	FindShoppingLists(ctx context.Context, userID entity.ID) ([]*ShoppingList, error)
+}
+
+// PantryRepository defines all data interactions required for querying pantry items.
+type PantryRepository interface {
+	GetPantryItems(ctx context.Context, ids []entity.ID) ([]*PantryItem, error)
+	FindPantryItems(ctx context.Context, userID entity.ID, filter PantryFilter) ([]*PantryItem, error)
+}
+
+// MealRepository defines all data interactions required for querying meal plans.
+type MealRepository interface {
+	GetMeals(ctx context.Context, ids []entity.ID) ([]*Meal, error)
+	FindMeals(ctx context.Context, userID entity.ID, from time.Time, to time.Time) ([]*Meal, error)
+}
+
+// RecipeSearch defines a full-text index of recipes.
+// Matches are returned from most to least relevant.
+type RecipeSearch interface {
+	SearchRecipes(text string) []Match
 }
@@ -3,16 +3,45 @@ package query
 
 // Service is the business logic for queries.
 type Service struct {
-	recipes RecipeRepository
-	units   UnitRepository
-	users   UserRepository
+	recipes  RecipeRepository
+	units    UnitRepository
+	users    UserRepository
+	lists    ShoppingListRepository
+	pantries PantryRepository
+	meals    MealRepository
+	search   RecipeSearch
+}
+
+// noSearch is used when the Service has no RecipeSearch, and matches nothing.
+type noSearch struct{}
+
+func (noSearch) SearchRecipes(string) []Match {
+	return nil
 }
 
 // NewService creates a new query Service.
-func NewService(recipes RecipeRepository, units UnitRepository, users UserRepository) *Service {
-	return &Service{
-		recipes: recipes,
-		units:   units,
-		users:   users,
+func NewService(
+	recipes RecipeRepository,
+	units UnitRepository,
+	users UserRepository,
+	lists ShoppingListRepository,
+	pantries PantryRepository,
+	meals MealRepository,
+	options ...Option,
+) *Service {
+	service := &Service{
+		recipes:  recipes,
+		units:    units,
+		users:    users,
+		lists:    lists,
+		pantries: pantries,
+		meals:    meals,
+		search:   noSearch{},
 	}
+
+	for _, option := range options {
+		option(service)
+	}
+
+	return service
 }
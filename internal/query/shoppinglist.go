@@ -0,0 +1,163 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
+)
+
+// GetShoppingList returns a single shopping list of a user from an id.
+// Lists created by another user are not found.
+func (s *Service) GetShoppingList(ctx context.Context, userID entity.ID, id entity.ID) (*ShoppingList, error) {
+	found, err := s.lists.GetShoppingLists(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	if len(found) == 0 || found[0].CreatedBy != userID {
+		return nil, entity.ErrNotFound
+	}
+
+	return found[0], nil
+}
+
+// FindShoppingLists returns all shopping lists created by a user.
+func (s *Service) FindShoppingLists(ctx context.Context, userID entity.ID) ([]*ShoppingList, error) {
+	found, err := s.lists.FindShoppingLists(ctx, userID)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
+
+// PlanShoppingList combines the ingredients of several recipes into a single list of things to buy.
+// Ingredients with the same name are merged when their units can be converted into each other,
+// and merged quantities are moved into the most sensible unit of the same system.
+// Missing recipes are reported as validation errors.
+func (s *Service) PlanShoppingList(ctx context.Context, selections []RecipeServings) ([]Ingredient, error) {
+	result, graph, units, err := s.planIngredients(ctx, selections)
+	if err != nil {
+		return nil, err
+	}
+
+	return simplifyIngredients(result, graph, units), nil
+}
+
+// planIngredients scales and merges the ingredients of several recipes without simplifying their units.
+func (s *Service) planIngredients(
+	ctx context.Context,
+	selections []RecipeServings,
+) ([]Ingredient, *unit.Graph, map[entity.ID]*unit.Unit, error) {
+	ids := make([]entity.ID, len(selections))
+	for i := range selections {
+		ids[i] = selections[i].RecipeID
+	}
+
+	found, err := s.recipes.GetRecipes(ctx, ids)
+	if err != nil {
+		return nil, nil, nil, queryError(err)
+	}
+
+	lookup := make(map[entity.ID]*Recipe, len(found))
+	for _, recipe := range found {
+		lookup[recipe.ID] = recipe
+	}
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, id := range ids {
+		if _, ok := lookup[id]; !ok {
+			validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("recipes", "recipe does not exist"))
+		}
+	}
+
+	if !validation.IsEmpty() {
+		return nil, nil, nil, validation
+	}
+
+	graph, units, err := s.unitGraph(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	result := make([]Ingredient, 0)
+
+	for _, selection := range selections {
+		recipe := lookup[selection.RecipeID]
+
+		servings := selection.Servings
+		if servings <= 0 {
+			servings = recipe.NumServings
+		}
+
+		factor := scaleFactor(recipe.NumServings, servings)
+
+		for _, ingredient := range recipe.Ingredients {
+			result = mergeIngredient(result, ingredient.Name, ingredient.Quantity*factor, ingredient.UnitID, graph, units)
+		}
+	}
+
+	return result, graph, units, nil
+}
+
+// simplifyIngredients moves each ingredient quantity into the most sensible unit of the same system.
+func simplifyIngredients(ingredients []Ingredient, graph *unit.Graph, units map[entity.ID]*unit.Unit) []Ingredient {
+	for i := range ingredients {
+		from, ok := units[ingredients[i].UnitID]
+		if !ok {
+			continue
+		}
+
+		quantity, to := graph.Simplify(ingredients[i].Quantity, from)
+		ingredients[i].Quantity = quantity
+		ingredients[i].UnitID = to.ID()
+	}
+
+	return ingredients
+}
+
+// mergeIngredient adds a quantity to the first ingredient with the same name and a compatible unit.
+// Ingredients that cannot be merged are added to the end.
+func mergeIngredient(
+	ingredients []Ingredient,
+	name string,
+	quantity float64,
+	unitID entity.ID,
+	graph *unit.Graph,
+	units map[entity.ID]*unit.Unit,
+) []Ingredient {
+	for i := range ingredients {
+		if !strings.EqualFold(ingredients[i].Name, name) {
+			continue
+		}
+
+		if ingredients[i].UnitID == unitID {
+			ingredients[i].Quantity += quantity
+
+			return ingredients
+		}
+
+		from, fromOK := units[unitID]
+		to, toOK := units[ingredients[i].UnitID]
+
+		if !fromOK || !toOK {
+			continue
+		}
+
+		converted, err := graph.Convert(quantity, from, to)
+		if err != nil {
+			continue
+		}
+
+		ingredients[i].Quantity += converted
+
+		return ingredients
+	}
+
+	return append(ingredients, Ingredient{Name: name, Quantity: quantity, UnitID: unitID})
+}
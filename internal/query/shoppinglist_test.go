@@ -0,0 +1,233 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetShoppingList(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.ShoppingListRepository
+		result *query.ShoppingList
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			repo: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{
+					{ID: entity.NewID("list-1"), Name: "groceries", CreatedBy: entity.NewID("user-1")},
+				},
+			},
+			result: &query.ShoppingList{ID: entity.NewID("list-1"), Name: "groceries", CreatedBy: entity.NewID("user-1")},
+			err:    nil,
+		},
+		"not found": {
+			repo:   &mock.QueryShoppingListRepository{},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"another user": {
+			repo: &mock.QueryShoppingListRepository{
+				GetShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("list-1"), CreatedBy: entity.NewID("user-2")}},
+			},
+			result: nil,
+			err:    entity.ErrNotFound,
+		},
+		"unknown error": {
+			repo:   &mock.QueryShoppingListRepository{GetShoppingListsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				test.repo,
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.GetShoppingList(context.Background(), entity.NewID("user-1"), entity.NewID("list-1"))
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestFindShoppingLists(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.ShoppingListRepository
+		result []*query.ShoppingList
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			repo: &mock.QueryShoppingListRepository{
+				FindShoppingListsResult: []*query.ShoppingList{{ID: entity.NewID("list-1")}},
+			},
+			result: []*query.ShoppingList{{ID: entity.NewID("list-1")}},
+			err:    nil,
+		},
+		"unknown error": {
+			repo:   &mock.QueryShoppingListRepository{FindShoppingListsErr: errors.New("something went wrong")},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				&mock.QueryUserRepository{},
+				test.repo,
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.FindShoppingLists(context.Background(), entity.NewID("user-1"))
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestPlanShoppingList(t *testing.T) {
+	t.Parallel()
+
+	teaspoon := unit.New("teaspoon", "tsp", unit.US, unit.Volume)
+	tablespoon := unit.New("tablespoon", "tbsp", unit.US, unit.Volume)
+	cup := unit.New("cup", "c", unit.US, unit.Volume)
+	gram := unit.New("gram", "g", unit.Metric, unit.Mass)
+
+	units := &mock.QueryUnitRepository{
+		AllUnitsResult: []*query.Unit{
+			{ID: teaspoon.ID(), Name: "teaspoon", Symbol: "tsp", BaseType: "volume", System: "us"},
+			{ID: tablespoon.ID(), Name: "tablespoon", Symbol: "tbsp", BaseType: "volume", System: "us"},
+			{ID: cup.ID(), Name: "cup", Symbol: "c", BaseType: "volume", System: "us"},
+			{ID: gram.ID(), Name: "gram", Symbol: "g", BaseType: "mass", System: "metric"},
+		},
+		GetConversionsResult: []*query.Conversion{
+			{FromID: teaspoon.ID(), ToID: tablespoon.ID(), Ratio: 3},
+			{FromID: tablespoon.ID(), ToID: cup.ID(), Ratio: 16},
+		},
+	}
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{
+			{
+				ID:          entity.NewID("pancakes"),
+				NumServings: 2,
+				Ingredients: []query.Ingredient{
+					{Name: "sugar", Quantity: 6, UnitID: teaspoon.ID()},
+					{Name: "egg", Quantity: 1},
+				},
+			},
+			{
+				ID:          entity.NewID("cookies"),
+				NumServings: 4,
+				Ingredients: []query.Ingredient{
+					{Name: "Sugar", Quantity: 0.5, UnitID: cup.ID()},
+					{Name: "sugar", Quantity: 100, UnitID: gram.ID()},
+					{Name: "Egg", Quantity: 2},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		recipes    query.RecipeRepository
+		units      query.UnitRepository
+		selections []query.RecipeServings
+		result     []query.Ingredient
+		err        error
+	}
+
+	tests := map[string]testCase{
+		"merged": {
+			recipes: recipes,
+			units:   units,
+			selections: []query.RecipeServings{
+				{RecipeID: entity.NewID("pancakes"), Servings: 4},
+				{RecipeID: entity.NewID("cookies")},
+			},
+			result: []query.Ingredient{
+				{Name: "sugar", Quantity: 12, UnitID: tablespoon.ID()},
+				{Name: "egg", Quantity: 4},
+				{Name: "sugar", Quantity: 100, UnitID: gram.ID()},
+			},
+			err: nil,
+		},
+		"missing recipe": {
+			recipes: recipes,
+			units:   units,
+			selections: []query.RecipeServings{
+				{RecipeID: entity.NewID("pancakes")},
+				{RecipeID: entity.NewID("unknown")},
+			},
+			result: nil,
+			err:    &entity.ValidationError{},
+		},
+		"recipe error": {
+			recipes:    &mock.QueryRecipeRepository{GetRecipesErr: errors.New("something went wrong")},
+			units:      units,
+			selections: []query.RecipeServings{{RecipeID: entity.NewID("pancakes")}},
+			result:     nil,
+			err:        query.ErrQuery,
+		},
+		"unit error": {
+			recipes:    recipes,
+			units:      &mock.QueryUnitRepository{AllUnitsErr: errors.New("something went wrong")},
+			selections: []query.RecipeServings{{RecipeID: entity.NewID("pancakes")}},
+			result:     nil,
+			err:        query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				test.recipes,
+				test.units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.PlanShoppingList(context.Background(), test.selections)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package query
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/unit"
 )
 
 // GetUnits returns multiple units from a list of ids.
@@ -15,3 +18,159 @@ func (s *Service) GetUnits(ctx context.Context, ids []entity.ID) ([]*Unit, error
 
 	return found, nil
 }
+
+// GetConversions returns all conversions to or from a list of unit ids.
+func (s *Service) GetConversions(ctx context.Context, ids []entity.ID) ([]*Conversion, error) {
+	found, err := s.units.GetConversions(ctx, ids)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
+
+// FindUnits returns all units matching a filter.
+func (s *Service) FindUnits(ctx context.Context, filter UnitFilter) ([]*Unit, error) {
+	found, err := s.units.AllUnits(ctx)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	result := make([]*Unit, 0, len(found))
+
+	for _, unit := range found {
+		if filter.BaseType != nil && !strings.EqualFold(unit.BaseType, *filter.BaseType) {
+			continue
+		}
+
+		if filter.System != nil && !strings.EqualFold(unit.System, *filter.System) {
+			continue
+		}
+
+		result = append(result, unit)
+	}
+
+	return result, nil
+}
+
+// Convert converts a quantity from one unit to another along the shortest chain of conversions.
+// Missing or incompatible units are reported as validation errors.
+func (s *Service) Convert(ctx context.Context, quantity float64, fromID entity.ID, toID entity.ID) (float64, error) {
+	found, err := s.units.GetUnits(ctx, []entity.ID{fromID, toID})
+	if err != nil {
+		return 0, queryError(err)
+	}
+
+	lookup := make(map[entity.ID]*Unit, len(found))
+	for _, unit := range found {
+		lookup[unit.ID] = unit
+	}
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	from, ok := lookup[fromID]
+	if !ok {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("from", "unit does not exist"))
+	}
+
+	to, ok := lookup[toID]
+	if !ok {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("to", "unit does not exist"))
+	}
+
+	if !validation.IsEmpty() {
+		return 0, validation
+	}
+
+	if from.BaseType != to.BaseType {
+		validation.InnerErrors = append(
+			validation.InnerErrors,
+			entity.NewFieldError("to", fmt.Sprintf("cannot convert %q to %q", from.BaseType, to.BaseType)),
+		)
+
+		return 0, validation
+	}
+
+	path, err := s.units.GetConversionPath(ctx, from, to)
+	if err != nil {
+		return 0, queryError(err)
+	}
+
+	if len(path) == 0 && fromID != toID {
+		validation.InnerErrors = append(
+			validation.InnerErrors,
+			entity.NewFieldError("to", fmt.Sprintf("no conversion from %q to %q", from.Name, to.Name)),
+		)
+
+		return 0, validation
+	}
+
+	current := fromID
+
+	for _, conversion := range path {
+		switch current {
+		case conversion.FromID:
+			quantity /= conversion.Ratio
+			current = conversion.ToID
+		case conversion.ToID:
+			quantity *= conversion.Ratio
+			current = conversion.FromID
+		}
+	}
+
+	return quantity, nil
+}
+
+// unitGraph loads every unit and the conversions between them.
+// Units are returned by id, even when they have no conversions.
+func (s *Service) unitGraph(ctx context.Context) (*unit.Graph, map[entity.ID]*unit.Unit, error) {
+	units, err := s.units.AllUnits(ctx)
+	if err != nil {
+		return nil, nil, queryError(err)
+	}
+
+	lookup := make(map[entity.ID]*unit.Unit, len(units))
+	ids := make([]entity.ID, len(units))
+
+	for i, found := range units {
+		lookup[found.ID] = newUnit(found)
+		ids[i] = found.ID
+	}
+
+	conversions, err := s.units.GetConversions(ctx, ids)
+	if err != nil {
+		return nil, nil, queryError(err)
+	}
+
+	graph := unit.NewGraph()
+
+	for _, found := range conversions {
+		from, fromOK := lookup[found.FromID]
+		to, toOK := lookup[found.ToID]
+
+		if !fromOK || !toOK {
+			continue
+		}
+
+		conversion, err := unit.NewConversion(from, to, found.Ratio)
+		if err != nil {
+			continue
+		}
+
+		graph.Add(conversion)
+	}
+
+	return graph, lookup, nil
+}
+
+// newUnit creates a domain unit from a query unit.
+func newUnit(found *Unit) *unit.Unit {
+	options := []unit.Option{unit.SetSystem(found.System), unit.SetBaseType(found.BaseType)}
+	if found.Plural != "" {
+		options = append(options, unit.WithCustomPlural(found.Plural))
+	}
+
+	return unit.New(found.Name, found.Symbol, options...)
+}
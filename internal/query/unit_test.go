@@ -48,7 +48,14 @@ func TestGetUnits(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			service := query.NewService(&mock.QueryRecipeRepository{}, test.repo, &mock.QueryUserRepository{})
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.repo,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
 			result, err := service.GetUnits(context.Background(), test.ids)
 
 			assert.Equal(t, test.result, result)
@@ -60,3 +67,235 @@ func TestGetUnits(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConversions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.UnitRepository
+		ids    []entity.ID
+		result []*query.Conversion
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.QueryUnitRepository{
+				GetConversionsResult: []*query.Conversion{
+					{FromID: entity.NewID("unit-123"), ToID: entity.NewID("unit-456"), Ratio: 2},
+				},
+				GetConversionsErr: nil,
+			},
+			ids: []entity.ID{entity.NewID("unit-123")},
+			result: []*query.Conversion{
+				{FromID: entity.NewID("unit-123"), ToID: entity.NewID("unit-456"), Ratio: 2},
+			},
+			err: nil,
+		},
+		"unknown error": {
+			repo: &mock.QueryUnitRepository{
+				GetConversionsResult: nil,
+				GetConversionsErr:    errors.New("something went wrong"),
+			},
+			ids:    []entity.ID{entity.NewID("unit-123")},
+			result: nil,
+			err:    errors.New("something went wrong"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.repo,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.GetConversions(context.Background(), test.ids)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &test.err)
+			}
+		})
+	}
+}
+
+func TestFindUnits(t *testing.T) {
+	t.Parallel()
+
+	units := []*query.Unit{
+		{ID: entity.NewID("gram"), BaseType: "mass", System: "metric"},
+		{ID: entity.NewID("ounce"), BaseType: "mass", System: "us"},
+		{ID: entity.NewID("liter"), BaseType: "volume", System: "metric"},
+	}
+
+	str := func(value string) *string { return &value }
+
+	type testCase struct {
+		repo   query.UnitRepository
+		filter query.UnitFilter
+		result []*query.Unit
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"all": {
+			repo:   &mock.QueryUnitRepository{AllUnitsResult: units},
+			filter: query.UnitFilter{},
+			result: units,
+			err:    nil,
+		},
+		"base type": {
+			repo:   &mock.QueryUnitRepository{AllUnitsResult: units},
+			filter: query.UnitFilter{BaseType: str("MASS")},
+			result: units[:2],
+			err:    nil,
+		},
+		"base type and system": {
+			repo:   &mock.QueryUnitRepository{AllUnitsResult: units},
+			filter: query.UnitFilter{BaseType: str("mass"), System: str("metric")},
+			result: units[:1],
+			err:    nil,
+		},
+		"unknown error": {
+			repo:   &mock.QueryUnitRepository{AllUnitsErr: errors.New("something went wrong")},
+			filter: query.UnitFilter{},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.repo,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.FindUnits(context.Background(), test.filter)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	gram := &query.Unit{ID: entity.NewID("gram"), Name: "gram", BaseType: "mass"}
+	kilogram := &query.Unit{ID: entity.NewID("kilogram"), Name: "kilogram", BaseType: "mass"}
+	pound := &query.Unit{ID: entity.NewID("pound"), Name: "pound", BaseType: "mass"}
+	liter := &query.Unit{ID: entity.NewID("liter"), Name: "liter", BaseType: "volume"}
+
+	type testCase struct {
+		repo   query.UnitRepository
+		from   entity.ID
+		to     entity.ID
+		result float64
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"forwards": {
+			repo: &mock.QueryUnitRepository{
+				GetUnitsResult: []*query.Unit{gram, kilogram},
+				GetConversionPathResult: []*query.Conversion{
+					{FromID: gram.ID, ToID: kilogram.ID, Ratio: 1000},
+				},
+			},
+			from:   gram.ID,
+			to:     kilogram.ID,
+			result: 1.5,
+		},
+		"backwards through several units": {
+			repo: &mock.QueryUnitRepository{
+				GetUnitsResult: []*query.Unit{pound, kilogram},
+				GetConversionPathResult: []*query.Conversion{
+					{FromID: gram.ID, ToID: pound.ID, Ratio: 500},
+					{FromID: gram.ID, ToID: kilogram.ID, Ratio: 1000},
+				},
+			},
+			from:   pound.ID,
+			to:     kilogram.ID,
+			result: 750,
+		},
+		"same unit": {
+			repo:   &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{gram}},
+			from:   gram.ID,
+			to:     gram.ID,
+			result: 1500,
+		},
+		"not found": {
+			repo: &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{gram}},
+			from: gram.ID,
+			to:   kilogram.ID,
+			err:  &entity.ValidationError{},
+		},
+		"different base types": {
+			repo: &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{gram, liter}},
+			from: gram.ID,
+			to:   liter.ID,
+			err:  &entity.ValidationError{},
+		},
+		"no path": {
+			repo: &mock.QueryUnitRepository{GetUnitsResult: []*query.Unit{gram, pound}},
+			from: gram.ID,
+			to:   pound.ID,
+			err:  &entity.ValidationError{},
+		},
+		"unit error": {
+			repo: &mock.QueryUnitRepository{GetUnitsErr: errors.New("something went wrong")},
+			from: gram.ID,
+			to:   kilogram.ID,
+			err:  query.ErrQuery,
+		},
+		"path error": {
+			repo: &mock.QueryUnitRepository{
+				GetUnitsResult:       []*query.Unit{gram, kilogram},
+				GetConversionPathErr: errors.New("something went wrong"),
+			},
+			from: gram.ID,
+			to:   kilogram.ID,
+			err:  query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				test.repo,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.Convert(context.Background(), 1500, test.from, test.to)
+
+			var validation *entity.ValidationError
+
+			switch {
+			case test.err == nil:
+				assert.NoError(t, err)
+				assert.InDelta(t, test.result, result, 1e-9)
+			case errors.As(test.err, &validation):
+				assert.ErrorAs(t, err, &validation)
+			default:
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
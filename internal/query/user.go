@@ -15,3 +15,13 @@ func (s *Service) GetUsers(ctx context.Context, ids []entity.ID) ([]*User, error
 
 	return found, nil
 }
+
+// GetFavorites returns which of the given recipe ids are favorites of a user.
+func (s *Service) GetFavorites(ctx context.Context, userID entity.ID, recipeIDs []entity.ID) ([]entity.ID, error) {
+	found, err := s.users.GetFavorites(ctx, userID, recipeIDs)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return found, nil
+}
@@ -48,7 +48,14 @@ func TestGetUsers(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			service := query.NewService(&mock.QueryRecipeRepository{}, &mock.QueryUnitRepository{}, test.repo)
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				test.repo,
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
 			result, err := service.GetUsers(context.Background(), test.ids)
 
 			assert.Equal(t, test.result, result)
@@ -60,3 +67,55 @@ func TestGetUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFavorites(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		repo   query.UserRepository
+		result []entity.ID
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"success": {
+			repo: &mock.QueryUserRepository{
+				GetFavoritesResult: []entity.ID{entity.NewID("recipe-1")},
+			},
+			result: []entity.ID{entity.NewID("recipe-1")},
+			err:    nil,
+		},
+		"unknown error": {
+			repo: &mock.QueryUserRepository{
+				GetFavoritesErr: errors.New("something went wrong"),
+			},
+			result: nil,
+			err:    query.ErrQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := query.NewService(
+				&mock.QueryRecipeRepository{},
+				&mock.QueryUnitRepository{},
+				test.repo,
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			)
+			result, err := service.GetFavorites(
+				context.Background(),
+				entity.NewID("user-123"),
+				[]entity.ID{entity.NewID("recipe-1"), entity.NewID("recipe-2")},
+			)
+
+			assert.Equal(t, test.result, result)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package recipe
 
 import (
-	"errors"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/b-sea/supply-run-api/internal/entity"
 )
@@ -17,7 +17,7 @@ type Option func(r *Recipe) (bool, error)
 func SetName(name string) Option {
 	return func(r *Recipe) (bool, error) {
 		if name == "" {
-			return false, errors.New("recipe name cannot be empty")
+			return false, entity.NewFieldError("name", "recipe name cannot be empty")
 		}
 
 		if r.name == name {
@@ -36,7 +36,7 @@ func SetName(name string) Option {
 func SetURL(value string) Option {
 	return func(r *Recipe) (bool, error) {
 		if _, err := url.ParseRequestURI(value); err != nil {
-			return false, errors.New("recipe url must be a valid url")
+			return false, entity.NewFieldError("url", "recipe url must be a valid url")
 		}
 
 		if r.url == value {
@@ -96,11 +96,11 @@ func ClearSteps() Option {
 func AddIngredient(name string, quantity float64, unitID entity.ID) Option {
 	return func(r *Recipe) (bool, error) {
 		if name == "" {
-			return false, errors.New("ingredient name cannot be empty")
+			return false, entity.NewFieldError("ingredients", "ingredient name cannot be empty")
 		}
 
 		if quantity <= 0 {
-			return false, errors.New("ingredient quantity must be greater than 0")
+			return false, entity.NewFieldError("ingredients", "ingredient quantity must be greater than 0")
 		}
 
 		r.ingredients = append(
@@ -135,7 +135,7 @@ func ClearIngredients() Option {
 func AddTag(name string) Option {
 	return func(r *Recipe) (bool, error) {
 		if name == "" {
-			return false, errors.New("tag name cannot be empty")
+			return false, entity.NewFieldError("tags", "tag name cannot be empty")
 		}
 
 		for i := range r.tags {
@@ -164,3 +164,18 @@ func ClearTags() Option {
 		return true, nil
 	}
 }
+
+// SetUpdated sets when and by whom the Recipe was last updated.
+// This is meant for rebuilding a stored Recipe, not for user changes.
+func SetUpdated(timestamp time.Time, userID entity.ID) Option {
+	return func(r *Recipe) (bool, error) {
+		if r.updatedAt.Equal(timestamp) && r.updatedBy == userID {
+			return false, nil
+		}
+
+		r.updatedAt = timestamp
+		r.updatedBy = userID
+
+		return true, nil
+	}
+}
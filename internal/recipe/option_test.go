@@ -242,3 +242,25 @@ func TestClearTags(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(test.Tags()))
 }
+
+func TestSetUpdated(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	test, err := recipe.New(entity.NewRandomID(), "test", created, entity.NewID("user-123"))
+	assert.NoError(t, err)
+
+	// Set the updated info
+	updated := created.Add(time.Hour)
+	changed, err := recipe.SetUpdated(updated, entity.NewID("user-456"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, created, test.CreatedAt())
+	assert.Equal(t, updated, test.UpdatedAt())
+	assert.Equal(t, entity.NewID("user-456"), test.UpdatedBy())
+
+	// Set the updated info to the same value
+	changed, err = recipe.SetUpdated(updated, entity.NewID("user-456"))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
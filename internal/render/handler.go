@@ -0,0 +1,130 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/rs/zerolog"
+)
+
+// Handler is an HTTP handler rendering recipes from paths like /recipes/{id}.{md|html|jsonld},
+// where id is the recipe's global ID as the GraphQL API returns it.
+// A servings query parameter scales the ingredients to that many servings.
+type Handler struct {
+	queries *query.Service
+}
+
+// NewHandler creates a new recipe rendering Handler.
+func NewHandler(queries *query.Service) *Handler {
+	return &Handler{
+		queries: queries,
+	}
+}
+
+// ServeHTTP renders the requested recipe.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+
+	name := path.Base(request.URL.Path)
+	dot := strings.LastIndex(name, ".")
+
+	if dot <= 0 {
+		http.NotFound(writer, request)
+
+		return
+	}
+
+	format := Format(name[dot+1:])
+	if format != Markdown && format != HTML && format != JSONLD {
+		http.NotFound(writer, request)
+
+		return
+	}
+
+	id, err := model.ParseID(name[:dot])
+	if err != nil || id.Kind != model.RecipeKind {
+		http.NotFound(writer, request)
+
+		return
+	}
+
+	found, err := h.queries.GetRecipe(ctx, id.Key)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			http.NotFound(writer, request)
+
+			return
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to find recipe")
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	if value := request.URL.Query().Get("servings"); value != "" {
+		servings, err := strconv.Atoi(value)
+		if err != nil || servings <= 0 {
+			http.Error(writer, "servings must be a whole number greater than 0", http.StatusBadRequest)
+
+			return
+		}
+
+		scaled := *found
+
+		scaled.Ingredients, err = h.queries.ScaleIngredients(ctx, found.Ingredients, found.NumServings, servings)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to scale recipe")
+			http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		scaled.NumServings = servings
+		found = &scaled
+	}
+
+	units, err := h.queries.GetUnits(ctx, unitIDs(found.Ingredients))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to find units")
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	var body bytes.Buffer
+
+	if err := Write(&body, New(found, units), format); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to render recipe")
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", format.ContentType())
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(body.Bytes())
+}
+
+func unitIDs(ingredients []query.Ingredient) []entity.ID {
+	result := make([]entity.ID, 0, len(ingredients))
+	seen := make(map[entity.ID]bool, len(ingredients))
+
+	for _, ingredient := range ingredients {
+		if ingredient.UnitID.String() == "" || seen[ingredient.UnitID] {
+			continue
+		}
+
+		seen[ingredient.UnitID] = true
+		result = append(result, ingredient.UnitID)
+	}
+
+	return result
+}
@@ -0,0 +1,179 @@
+package render_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/graphql/model"
+	"github.com/b-sea/supply-run-api/internal/mock"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	recipes := &mock.QueryRecipeRepository{
+		GetRecipesResult: []*query.Recipe{{
+			ID:          entity.NewID("R1"),
+			Name:        "Pancakes",
+			NumServings: 2,
+			Ingredients: []query.Ingredient{
+				{Name: "flour", Quantity: 1, UnitID: cup.ID},
+				{Name: "eggs", Quantity: 2, UnitID: entity.NewID("")},
+			},
+		}},
+	}
+	units := &mock.QueryUnitRepository{
+		GetUnitsResult: []*query.Unit{cup},
+		AllUnitsResult: []*query.Unit{cup},
+	}
+
+	// IDs in paths are the global IDs the GraphQL API hands out.
+	recipeID := model.NewRecipeID(entity.NewID("R1")).String()
+
+	type testCase struct {
+		path        string
+		recipes     query.RecipeRepository
+		units       query.UnitRepository
+		status      int
+		contentType string
+		body        string
+	}
+
+	tests := map[string]testCase{
+		"markdown": {
+			path:        "/recipes/" + recipeID + ".md",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusOK,
+			contentType: "text/markdown; charset=utf-8",
+			body:        "# Pancakes\n\nServings: 2\n\n## Ingredients\n\n- 1 cup flour\n- 2 eggs\n",
+		},
+		"scaled": {
+			path:        "/recipes/" + recipeID + ".md?servings=5",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusOK,
+			contentType: "text/markdown; charset=utf-8",
+			body:        "# Pancakes\n\nServings: 5\n\n## Ingredients\n\n- 2 1/2 cups flour\n- 5 eggs\n",
+		},
+		"json-ld": {
+			path:        "/recipes/" + recipeID + ".jsonld",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusOK,
+			contentType: "application/ld+json",
+			body: `{
+  "@context": "https://schema.org",
+  "@type": "Recipe",
+  "name": "Pancakes",
+  "recipeYield": "2 servings",
+  "recipeIngredient": [
+    "1 cup flour",
+    "2 eggs"
+  ],
+  "recipeInstructions": []
+}
+`,
+		},
+		"bad servings": {
+			path:        "/recipes/" + recipeID + ".md?servings=lots",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusBadRequest,
+			contentType: "text/plain; charset=utf-8",
+			body:        "servings must be a whole number greater than 0\n",
+		},
+		"unknown format": {
+			path:        "/recipes/" + recipeID + ".pdf",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found\n",
+		},
+		"no format": {
+			path:        "/recipes/" + recipeID,
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found\n",
+		},
+		"url-safe id": {
+			path:        "/recipes/" + model.NewRecipeID(entity.NewID("R?")).String() + ".md",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusOK,
+			contentType: "text/markdown; charset=utf-8",
+			body:        "# Pancakes\n\nServings: 2\n\n## Ingredients\n\n- 1 cup flour\n- 2 eggs\n",
+		},
+		"raw key": {
+			path:        "/recipes/R1.md",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found\n",
+		},
+		"not a recipe": {
+			path:        "/recipes/" + model.NewUnitID(cup.ID).String() + ".md",
+			recipes:     recipes,
+			units:       units,
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found\n",
+		},
+		"not found": {
+			path:        "/recipes/" + model.NewRecipeID(entity.NewID("R2")).String() + ".html",
+			recipes:     &mock.QueryRecipeRepository{},
+			units:       units,
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found\n",
+		},
+		"recipe error": {
+			path:        "/recipes/" + recipeID + ".html",
+			recipes:     &mock.QueryRecipeRepository{GetRecipesErr: errors.New("some random error")},
+			units:       units,
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain; charset=utf-8",
+			body:        "Internal Server Error\n",
+		},
+		"unit error": {
+			path:        "/recipes/" + recipeID + ".html",
+			recipes:     recipes,
+			units:       &mock.QueryUnitRepository{GetUnitsErr: errors.New("some random error")},
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain; charset=utf-8",
+			body:        "Internal Server Error\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := render.NewHandler(query.NewService(
+				test.recipes,
+				test.units,
+				&mock.QueryUserRepository{},
+				&mock.QueryShoppingListRepository{},
+				&mock.QueryPantryRepository{},
+				&mock.QueryMealRepository{},
+			))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.body, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"io"
+	"strings"
+)
+
+//go:embed recipe.html
+var recipeTemplate string
+
+// htmlTemplate is a printable page, with the recipe JSON-LD embedded for other recipe tools.
+var htmlTemplate = template.Must(template.New("recipe").Parse(recipeTemplate)) //nolint: gochecknoglobals
+
+func writeHTML(w io.Writer, recipe *Recipe) error {
+	jsonld, err := json.Marshal(newJSONLD(recipe))
+	if err != nil {
+		return err
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		Recipe *Recipe
+		JSONLD template.JS
+		Tags   string
+	}{
+		Recipe: recipe,
+		JSONLD: template.JS(jsonld), //nolint: gosec
+		Tags:   strings.Join(recipe.Tags, ", "),
+	})
+}
@@ -0,0 +1,73 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type jsonldStep struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+type jsonldRecipe struct {
+	Context            string       `json:"@context"`
+	Type               string       `json:"@type"`
+	Name               string       `json:"name"`
+	URL                string       `json:"url,omitempty"`
+	RecipeYield        string       `json:"recipeYield,omitempty"`
+	RecipeIngredient   []string     `json:"recipeIngredient"`
+	RecipeInstructions []jsonldStep `json:"recipeInstructions"`
+	Keywords           string       `json:"keywords,omitempty"`
+	DateCreated        string       `json:"dateCreated,omitempty"`
+	DateModified       string       `json:"dateModified,omitempty"`
+}
+
+// newJSONLD creates a schema.org Recipe.
+func newJSONLD(recipe *Recipe) jsonldRecipe {
+	result := jsonldRecipe{
+		Context:            "https://schema.org",
+		Type:               "Recipe",
+		Name:               recipe.Name,
+		URL:                recipe.URL,
+		RecipeYield:        "",
+		RecipeIngredient:   make([]string, len(recipe.Ingredients)),
+		RecipeInstructions: make([]jsonldStep, len(recipe.Steps)),
+		Keywords:           strings.Join(recipe.Tags, ", "),
+		DateCreated:        "",
+		DateModified:       "",
+	}
+
+	if recipe.Servings > 0 {
+		result.RecipeYield = strconv.Itoa(recipe.Servings) + " servings"
+	}
+
+	for i, ingredient := range recipe.Ingredients {
+		result.RecipeIngredient[i] = ingredient.String()
+	}
+
+	for i, step := range recipe.Steps {
+		result.RecipeInstructions[i] = jsonldStep{Type: "HowToStep", Position: i + 1, Text: step}
+	}
+
+	if !recipe.CreatedAt.IsZero() {
+		result.DateCreated = recipe.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	if !recipe.UpdatedAt.IsZero() {
+		result.DateModified = recipe.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return result
+}
+
+func writeJSONLD(w io.Writer, recipe *Recipe) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(newJSONLD(recipe))
+}
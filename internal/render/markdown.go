@@ -0,0 +1,77 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// markdownEscaper escapes the characters Markdown would otherwise treat as formatting.
+var markdownEscaper = strings.NewReplacer( //nolint: gochecknoglobals
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+)
+
+func writeMarkdown(w io.Writer, recipe *Recipe) error {
+	var body bytes.Buffer
+
+	body.WriteString("# " + markdownEscaper.Replace(recipe.Name) + "\n\n")
+
+	if recipe.Servings > 0 {
+		body.WriteString("Servings: " + strconv.Itoa(recipe.Servings) + "\n")
+	}
+
+	if recipe.URL != "" {
+		body.WriteString("Source: <" + recipe.URL + ">\n")
+	}
+
+	if recipe.Servings > 0 || recipe.URL != "" {
+		body.WriteString("\n")
+	}
+
+	if len(recipe.Ingredients) > 0 {
+		body.WriteString("## Ingredients\n\n")
+
+		for _, ingredient := range recipe.Ingredients {
+			body.WriteString("- " + markdownEscaper.Replace(ingredient.String()) + "\n")
+		}
+
+		body.WriteString("\n")
+	}
+
+	if len(recipe.Steps) > 0 {
+		body.WriteString("## Steps\n\n")
+
+		for i, step := range recipe.Steps {
+			body.WriteString(strconv.Itoa(i+1) + ". " + markdownEscaper.Replace(step) + "\n")
+		}
+
+		body.WriteString("\n")
+	}
+
+	if len(recipe.Tags) > 0 {
+		tags := make([]string, len(recipe.Tags))
+		for i, tag := range recipe.Tags {
+			tags[i] = markdownEscaper.Replace(tag)
+		}
+
+		body.WriteString("Tags: " + strings.Join(tags, ", ") + "\n")
+	}
+
+	_, err := w.Write(bytes.TrimRight(body.Bytes(), "\n"))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
@@ -0,0 +1,176 @@
+// Package render writes recipes as Markdown, printable HTML and schema.org JSON-LD.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// ErrUnknownFormat is returned for formats other than md, html or jsonld.
+var ErrUnknownFormat = errors.New("unknown render format")
+
+// Format is a rendered recipe format.
+type Format string
+
+// Markdown, et al. are the supported render formats, named after their file extensions.
+const (
+	Markdown Format = "md"
+	HTML     Format = "html"
+	JSONLD   Format = "jsonld"
+)
+
+// ContentType returns the media type of the Format.
+func (f Format) ContentType() string {
+	switch f {
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case HTML:
+		return "text/html; charset=utf-8"
+	case JSONLD:
+		return "application/ld+json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Recipe is a recipe ready to be rendered, with its ingredients resolved to units.
+type Recipe struct {
+	Name        string
+	URL         string
+	Servings    int
+	Steps       []string
+	Ingredients []Ingredient
+	Tags        []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Ingredient is a recipe ingredient with its unit resolved.
+// Unit is the unit name, pluralised to fit the quantity, and is empty for ingredients without a unit.
+type Ingredient struct {
+	Quantity float64
+	Unit     string
+	Symbol   string
+	Name     string
+}
+
+// String writes the Ingredient the way a cookbook would, like "1 1/2 cups flour".
+func (i Ingredient) String() string {
+	parts := []string{formatQuantity(i.Quantity)}
+	if i.Unit != "" {
+		parts = append(parts, i.Unit)
+	}
+
+	return strings.Join(append(parts, i.Name), " ")
+}
+
+// New creates a new Recipe, resolving ingredient units from the given units.
+// Ingredients with units that cannot be found are rendered without a unit.
+func New(found *query.Recipe, units []*query.Unit) *Recipe {
+	lookup := make(map[entity.ID]*query.Unit, len(units))
+	for _, unit := range units {
+		lookup[unit.ID] = unit
+	}
+
+	result := &Recipe{
+		Name:        found.Name,
+		URL:         found.URL,
+		Servings:    found.NumServings,
+		Steps:       found.Steps,
+		Ingredients: make([]Ingredient, len(found.Ingredients)),
+		Tags:        found.Tags,
+		CreatedAt:   found.CreatedAt,
+		UpdatedAt:   found.UpdatedAt,
+	}
+
+	for i, ingredient := range found.Ingredients {
+		result.Ingredients[i] = Ingredient{
+			Quantity: ingredient.Quantity,
+			Unit:     "",
+			Symbol:   "",
+			Name:     ingredient.Name,
+		}
+
+		unit, ok := lookup[ingredient.UnitID]
+		if !ok {
+			continue
+		}
+
+		result.Ingredients[i].Unit = unit.Name
+		result.Ingredients[i].Symbol = unit.Symbol
+
+		if ingredient.Quantity > 1 && unit.Plural != "" {
+			result.Ingredients[i].Unit = unit.Plural
+		}
+	}
+
+	return result
+}
+
+// Write renders a Recipe in the given Format.
+func Write(w io.Writer, recipe *Recipe, format Format) error {
+	switch format {
+	case Markdown:
+		return writeMarkdown(w, recipe)
+	case HTML:
+		return writeHTML(w, recipe)
+	case JSONLD:
+		return writeJSONLD(w, recipe)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// fractions are the fractions cookbooks use, tried in order.
+var fractions = []struct { //nolint: gochecknoglobals
+	value float64
+	text  string
+}{
+	{1.0 / 2, "1/2"},
+	{1.0 / 3, "1/3"},
+	{2.0 / 3, "2/3"},
+	{1.0 / 4, "1/4"},
+	{3.0 / 4, "3/4"},
+	{1.0 / 8, "1/8"},
+	{3.0 / 8, "3/8"},
+	{5.0 / 8, "5/8"},
+	{7.0 / 8, "7/8"},
+}
+
+// formatQuantity writes a quantity as a whole or mixed number when it is close to one,
+// and as a decimal of at most two places otherwise.
+func formatQuantity(quantity float64) string {
+	const tolerance = 0.01
+
+	whole, part := math.Modf(quantity)
+
+	if part < tolerance {
+		return strconv.FormatFloat(whole, 'f', -1, 64)
+	}
+
+	if part > 1-tolerance {
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	}
+
+	for _, fraction := range fractions {
+		if math.Abs(part-fraction.value) >= tolerance {
+			continue
+		}
+
+		if whole == 0 {
+			return fraction.text
+		}
+
+		return strconv.FormatFloat(whole, 'f', -1, 64) + " " + fraction.text
+	}
+
+	return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64) //nolint: mnd
+}
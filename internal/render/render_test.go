@@ -0,0 +1,160 @@
+package render_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/importer"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cup   = &query.Unit{ID: entity.NewID("cup"), Name: "cup", Plural: "cups", Symbol: "c"}
+	pinch = &query.Unit{ID: entity.NewID("pinch"), Name: "pinch", Plural: "pinches", Symbol: "pn"}
+)
+
+func newPancakes() *render.Recipe {
+	return render.New(&query.Recipe{
+		ID:          entity.NewID("R1"),
+		Name:        "Pancakes & *Syrup*",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix <everything>.", "Cook."},
+		Ingredients: []query.Ingredient{
+			{Name: "flour", Quantity: 1.5, UnitID: cup.ID},
+			{Name: "milk", Quantity: 1, UnitID: cup.ID},
+			{Name: "salt", Quantity: 0.333, UnitID: pinch.ID},
+			{Name: "eggs", Quantity: 2, UnitID: entity.NewID("")},
+			{Name: "sugar", Quantity: 0.15, UnitID: entity.NewID("missing")},
+		},
+		Tags:      []string{"breakfast", "quick"},
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC),
+	}, []*query.Unit{cup, pinch})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	result := newPancakes()
+
+	assert.Equal(t, []render.Ingredient{
+		{Quantity: 1.5, Unit: "cups", Symbol: "c", Name: "flour"},
+		{Quantity: 1, Unit: "cup", Symbol: "c", Name: "milk"},
+		{Quantity: 0.333, Unit: "pinch", Symbol: "pn", Name: "salt"},
+		{Quantity: 2, Unit: "", Symbol: "", Name: "eggs"},
+		{Quantity: 0.15, Unit: "", Symbol: "", Name: "sugar"},
+	}, result.Ingredients)
+
+	lines := make([]string, len(result.Ingredients))
+	for i, ingredient := range result.Ingredients {
+		lines[i] = ingredient.String()
+	}
+
+	assert.Equal(t, []string{"1 1/2 cups flour", "1 cup milk", "1/3 pinch salt", "2 eggs", "0.15 sugar"}, lines)
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	assert.NoError(t, render.Write(&body, newPancakes(), render.Markdown))
+	assert.Equal(t, "# Pancakes & \\*Syrup\\*\n"+
+		"\n"+
+		"Servings: 4\n"+
+		"Source: <https://example.com/pancakes>\n"+
+		"\n"+
+		"## Ingredients\n"+
+		"\n"+
+		"- 1 1/2 cups flour\n"+
+		"- 1 cup milk\n"+
+		"- 1/3 pinch salt\n"+
+		"- 2 eggs\n"+
+		"- 0.15 sugar\n"+
+		"\n"+
+		"## Steps\n"+
+		"\n"+
+		"1. Mix \\<everything\\>.\n"+
+		"2. Cook.\n"+
+		"\n"+
+		"Tags: breakfast, quick\n",
+		body.String(),
+	)
+
+	body.Reset()
+	assert.NoError(t, render.Write(&body, &render.Recipe{Name: "Toast"}, render.Markdown))
+	assert.Equal(t, "# Toast\n", body.String())
+}
+
+func TestJSONLD(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	assert.NoError(t, render.Write(&body, newPancakes(), render.JSONLD))
+
+	var result map[string]any
+
+	assert.NoError(t, json.Unmarshal(body.Bytes(), &result))
+	assert.Equal(t, map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "Recipe",
+		"name":             "Pancakes & *Syrup*",
+		"url":              "https://example.com/pancakes",
+		"recipeYield":      "4 servings",
+		"recipeIngredient": []any{"1 1/2 cups flour", "1 cup milk", "1/3 pinch salt", "2 eggs", "0.15 sugar"},
+		"recipeInstructions": []any{
+			map[string]any{"@type": "HowToStep", "position": float64(1), "text": "Mix <everything>."},
+			map[string]any{"@type": "HowToStep", "position": float64(2), "text": "Cook."},
+		},
+		"keywords":     "breakfast, quick",
+		"dateCreated":  "2025-01-02T03:04:05Z",
+		"dateModified": "2025-02-03T04:05:06Z",
+	}, result)
+
+	// Other recipe tools read it back, treating markup in steps as html
+	imported := importer.FromHTML([]byte(`<script type="application/ld+json">` + body.String() + `</script>`))
+	assert.Equal(t, "Pancakes & *Syrup*", imported.Name)
+	assert.Equal(t, 4, imported.NumServings)
+	assert.Equal(t, []string{"Mix.", "Cook."}, imported.Steps)
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	assert.NoError(t, render.Write(&body, newPancakes(), render.HTML))
+
+	page := body.String()
+	assert.Contains(t, page, "<h1>Pancakes &amp; *Syrup*</h1>")
+	assert.Contains(t, page, "<li>1 1/2 cups flour</li>")
+	assert.Contains(t, page, "<li>Mix &lt;everything&gt;.</li>")
+	assert.Contains(t, page, `<a href="https://example.com/pancakes">`)
+	assert.Contains(t, page, "@media print")
+
+	// The embedded JSON-LD is the same recipe
+	imported := importer.FromHTML(body.Bytes())
+	assert.Equal(t, &importer.Recipe{
+		Name:        "Pancakes & *Syrup*",
+		URL:         "https://example.com/pancakes",
+		NumServings: 4,
+		Steps:       []string{"Mix.", "Cook."},
+		Ingredients: []string{"1 1/2 cups flour", "1 cup milk", "1/3 pinch salt", "2 eggs", "0.15 sugar"},
+		Tags:        []string{"breakfast", "quick"},
+	}, imported)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	assert.ErrorIs(t, render.Write(&body, newPancakes(), "pdf"), render.ErrUnknownFormat)
+}
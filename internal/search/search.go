@@ -0,0 +1,216 @@
+// Package search implements an in-process full-text index of recipes.
+package search
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+)
+
+// Words in a recipe name say more about it than words in its steps, so each field is weighted.
+const (
+	nameWeight       = 3
+	tagWeight        = 2
+	ingredientWeight = 1.5
+	stepWeight       = 1
+	prefixWeight     = 0.5
+)
+
+// loadPageSize is how many recipes are loaded at a time while filling an Index.
+const loadPageSize = 100
+
+// Index is a full-text index over recipe names, tags, ingredient names and steps.
+// It is safe for concurrent use.
+type Index struct {
+	mu        sync.RWMutex
+	postings  map[string]map[entity.ID]float64
+	documents map[entity.ID][]string
+}
+
+// NewIndex creates a new, empty Index.
+func NewIndex() *Index {
+	return &Index{
+		mu:        sync.RWMutex{},
+		postings:  make(map[string]map[entity.ID]float64),
+		documents: make(map[entity.ID][]string),
+	}
+}
+
+// Load indexes every recipe, replacing anything already indexed for them.
+func (i *Index) Load(ctx context.Context, queries *query.Service) error {
+	page := query.Pagination{Cursor: nil, Size: loadPageSize, Backward: false}
+	order := query.Order{Sort: query.CreatedSort, Direction: query.AscDirection}
+
+	for {
+		found, err := queries.FindRecipes(ctx, query.RecipeFilter{}, page, order)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range found.Items {
+			ingredients := make([]string, len(item.Ingredients))
+			for j, ingredient := range item.Ingredients {
+				ingredients[j] = ingredient.Name
+			}
+
+			i.add(item.ID, item.Name, item.Tags, ingredients, item.Steps)
+		}
+
+		if !found.Info.HasNextPage {
+			return nil
+		}
+
+		page.Cursor = found.Info.EndCursor
+	}
+}
+
+// IndexRecipe adds a recipe to the Index, replacing what was indexed for it before.
+func (i *Index) IndexRecipe(item *recipe.Recipe) {
+	ingredients := make([]string, len(item.Ingredients()))
+	for j, ingredient := range item.Ingredients() {
+		ingredients[j] = ingredient.Name()
+	}
+
+	i.add(item.ID(), item.Name(), item.Tags(), ingredients, item.Steps())
+}
+
+// RemoveRecipe removes a recipe from the Index.
+func (i *Index) RemoveRecipe(id entity.ID) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+}
+
+// SearchRecipes returns the recipes matching every word of the text, from most to least relevant.
+// Words also match any indexed word they are the start of, which scores lower than a whole word.
+func (i *Index) SearchRecipes(text string) []query.Match {
+	words := terms(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var scores map[entity.ID]float64
+
+	for _, word := range words {
+		found := i.score(word)
+
+		if scores == nil {
+			scores = found
+
+			continue
+		}
+
+		for id, score := range scores {
+			if _, ok := found[id]; !ok {
+				delete(scores, id)
+
+				continue
+			}
+
+			scores[id] = score + found[id]
+		}
+	}
+
+	result := make([]query.Match, 0, len(scores))
+	for id, score := range scores {
+		result = append(result, query.Match{ID: id, Score: score})
+	}
+
+	slices.SortFunc(result, func(a query.Match, b query.Match) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+
+		return cmp.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return result
+}
+
+// score finds the best score of every recipe with a word matching the given word, weighted by how rare it is.
+func (i *Index) score(word string) map[entity.ID]float64 {
+	result := make(map[entity.ID]float64)
+	total := float64(len(i.documents))
+
+	for term, documents := range i.postings {
+		weight := 1.0
+
+		switch {
+		case term == word:
+		case strings.HasPrefix(term, word):
+			weight = prefixWeight
+		default:
+			continue
+		}
+
+		idf := math.Log(1 + total/float64(len(documents)))
+
+		for id, frequency := range documents {
+			score := weight * (1 + math.Log(frequency)) * idf
+			if score > result[id] {
+				result[id] = score
+			}
+		}
+	}
+
+	return result
+}
+
+func (i *Index) add(id entity.ID, name string, tags []string, ingredients []string, steps []string) {
+	frequencies := make(map[string]float64)
+
+	addField := func(weight float64, texts ...string) {
+		for _, text := range texts {
+			for _, term := range terms(text) {
+				frequencies[term] += weight
+			}
+		}
+	}
+
+	addField(nameWeight, name)
+	addField(tagWeight, tags...)
+	addField(ingredientWeight, ingredients...)
+	addField(stepWeight, steps...)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+
+	indexed := make([]string, 0, len(frequencies))
+
+	for term, frequency := range frequencies {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[entity.ID]float64)
+		}
+
+		i.postings[term][id] = frequency
+		indexed = append(indexed, term)
+	}
+
+	i.documents[id] = indexed
+}
+
+// remove drops a recipe from the Index. The caller must hold the write lock.
+func (i *Index) remove(id entity.ID) {
+	for _, term := range i.documents[id] {
+		delete(i.postings[term], id)
+
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+
+	delete(i.documents, id)
+}
@@ -0,0 +1,168 @@
+package search_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/search"
+	"github.com/b-sea/supply-run-api/internal/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecipe(t *testing.T, id string, name string, options ...recipe.Option) *recipe.Recipe {
+	t.Helper()
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result, err := recipe.New(entity.NewID(id), name, created, entity.NewID("user-1"), options...)
+	assert.NoError(t, err)
+
+	return result
+}
+
+func newIndex(t *testing.T) *search.Index {
+	t.Helper()
+
+	index := search.NewIndex()
+	index.IndexRecipe(newRecipe(t, "soup", "Tomato Soup",
+		recipe.AddIngredient("tomatoes", 4, entity.NewID("")),
+		recipe.AddIngredient("basil", 1, entity.NewID("")),
+		recipe.AddStep("Simmer the chopped tomatoes."),
+		recipe.AddTag("dinner"),
+	))
+	index.IndexRecipe(newRecipe(t, "salad", "Caprese Salad",
+		recipe.AddIngredient("tomato", 2, entity.NewID("")),
+		recipe.AddIngredient("mozzarella", 1, entity.NewID("")),
+		recipe.AddStep("Slice and layer."),
+		recipe.AddTag("lunch"),
+	))
+	index.IndexRecipe(newRecipe(t, "bread", "Basil Bread",
+		recipe.AddIngredient("flour", 500, entity.NewID("")),
+		recipe.AddStep("Knead, then bake."),
+		recipe.AddTag("baking"),
+	))
+
+	return index
+}
+
+func ids(matches []query.Match) []entity.ID {
+	result := make([]entity.ID, len(matches))
+	for i, match := range matches {
+		result[i] = match.ID
+	}
+
+	return result
+}
+
+func TestSearchRecipes(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		text   string
+		result []entity.ID
+	}
+
+	tests := map[string]testCase{
+		"name ranks first": {
+			text:   "tomato",
+			result: []entity.ID{entity.NewID("soup"), entity.NewID("salad")},
+		},
+		"stemmed plural": {
+			text:   "Tomatoes",
+			result: []entity.ID{entity.NewID("soup"), entity.NewID("salad")},
+		},
+		"stemmed verb": {
+			text:   "chopping",
+			result: []entity.ID{entity.NewID("soup")},
+		},
+		"baking matches bake": {
+			text:   "baked",
+			result: []entity.ID{entity.NewID("bread")},
+		},
+		"prefix": {
+			text:   "mozz",
+			result: []entity.ID{entity.NewID("salad")},
+		},
+		"every word must match": {
+			text:   "basil soup",
+			result: []entity.ID{entity.NewID("soup")},
+		},
+		"whole word before prefix": {
+			text:   "basil",
+			result: []entity.ID{entity.NewID("bread"), entity.NewID("soup")},
+		},
+		"tag": {
+			text:   "lunch",
+			result: []entity.ID{entity.NewID("salad")},
+		},
+		"no match": {
+			text:   "chocolate",
+			result: []entity.ID{},
+		},
+		"only stop words": {
+			text:   "the and of",
+			result: []entity.ID{},
+		},
+	}
+
+	index := newIndex(t)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.result, ids(index.SearchRecipes(test.text)))
+		})
+	}
+}
+
+func TestIndexChanges(t *testing.T) {
+	t.Parallel()
+
+	index := newIndex(t)
+
+	index.IndexRecipe(newRecipe(t, "salad", "Greek Salad",
+		recipe.AddIngredient("feta", 1, entity.NewID("")),
+	))
+	assert.Equal(t, []entity.ID{entity.NewID("soup")}, ids(index.SearchRecipes("tomato")))
+	assert.Equal(t, []entity.ID{entity.NewID("salad")}, ids(index.SearchRecipes("feta")))
+
+	index.RemoveRecipe(entity.NewID("soup"))
+	assert.Equal(t, []entity.ID{}, ids(index.SearchRecipes("tomato")))
+	assert.Equal(t, []entity.ID{entity.NewID("bread")}, ids(index.SearchRecipes("basil")))
+
+	index.RemoveRecipe(entity.NewID("missing"))
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	repo, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { _ = repo.Close() })
+
+	_, err = repo.Migrator().Up(ctx)
+	assert.NoError(t, err)
+
+	for _, name := range []string{"pancakes", "waffles", "crepes"} {
+		assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(t, name, name, recipe.AddTag("breakfast"))))
+	}
+
+	index := search.NewIndex()
+	assert.NoError(t, index.Load(ctx, query.NewService(repo, repo, repo, repo, repo, repo)))
+
+	assert.ElementsMatch(
+		t,
+		[]entity.ID{entity.NewID("pancakes"), entity.NewID("waffles"), entity.NewID("crepes")},
+		ids(index.SearchRecipes("breakfast")),
+	)
+	assert.Equal(t, []entity.ID{entity.NewID("waffles")}, ids(index.SearchRecipes("waff")))
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/b-sea/supply-run-api/internal/ingredient"
+)
+
+// stopWords are too common in recipes to be worth searching for.
+var stopWords = map[string]bool{ //nolint: gochecknoglobals
+	"a":    true,
+	"an":   true,
+	"and":  true,
+	"for":  true,
+	"in":   true,
+	"of":   true,
+	"on":   true,
+	"or":   true,
+	"the":  true,
+	"to":   true,
+	"with": true,
+}
+
+// terms splits text into its stemmed, lowercase words, skipping stop words.
+func terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if stopWords[word] {
+			continue
+		}
+
+		result = append(result, stem(word))
+	}
+
+	return result
+}
+
+// stem reduces an English word to a rough root, so "tomatoes" finds "tomato" and "chopped" finds "chop".
+// Stems are only ever compared to other stems, so they do not need to be real words.
+func stem(word string) string {
+	const minLength = 4
+
+	if len([]rune(word)) < minLength {
+		return word
+	}
+
+	word = ingredient.Singular(word)
+
+	for _, suffix := range []string{"ing", "ed"} {
+		root, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(root) < 3 || !strings.ContainsAny(root, "aeiouy") {
+			continue
+		}
+
+		word = undouble(root)
+
+		break
+	}
+
+	if root, ok := strings.CutSuffix(word, "e"); ok && len(root) >= 3 {
+		word = root
+	}
+
+	return word
+}
+
+// undouble removes a doubled final consonant left behind by a suffix, like the second p of "chopp".
+func undouble(word string) string {
+	last := len(word) - 1
+	if last < 1 || word[last] != word[last-1] || strings.ContainsRune("aeioulsz", rune(word[last])) {
+		return word
+	}
+
+	return word[:last]
+}
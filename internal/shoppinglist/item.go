@@ -0,0 +1,39 @@
+package shoppinglist
+
+import (
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Item is a List item.
+type Item struct {
+	id       entity.ID
+	name     string
+	quantity float64
+	unitID   entity.ID
+	checked  bool
+}
+
+// ID returns the Item id.
+func (i *Item) ID() entity.ID {
+	return i.id
+}
+
+// Name returns the Item name.
+func (i *Item) Name() string {
+	return i.name
+}
+
+// Quantity returns the amount of Item to buy.
+func (i *Item) Quantity() float64 {
+	return i.quantity
+}
+
+// UnitID returns the Item unit id.
+func (i *Item) UnitID() entity.ID {
+	return i.unitID
+}
+
+// Checked returns if the Item has been checked off.
+func (i *Item) Checked() bool {
+	return i.checked
+}
@@ -0,0 +1,135 @@
+// Package shoppinglist defines shopping lists.
+package shoppinglist
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// List is a shopping list.
+type List struct {
+	id    entity.ID
+	name  string
+	items []Item
+
+	createdAt time.Time
+	createdBy entity.ID
+	updatedAt time.Time
+	updatedBy entity.ID
+}
+
+// New creates a new List.
+func New(id entity.ID, name string, timestamp time.Time, userID entity.ID, options ...Option) (*List, error) {
+	list := &List{
+		id:        id,
+		name:      "",
+		items:     make([]Item, 0),
+		createdAt: timestamp,
+		createdBy: userID,
+		updatedAt: timestamp,
+		updatedBy: userID,
+	}
+
+	options = append([]Option{SetName(name)}, options...)
+
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		if _, err := option(list); err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+	}
+
+	if !validation.IsEmpty() {
+		return nil, validation
+	}
+
+	return list, nil
+}
+
+// Update updates an existing List.
+// Updates are only applied if any data actually changes.
+func (l *List) Update(timestamp time.Time, userID entity.ID, options ...Option) error {
+	changed := false
+	validation := &entity.ValidationError{
+		InnerErrors: make([]error, 0),
+	}
+
+	for _, option := range options {
+		result, err := option(l)
+		if err != nil {
+			validation.InnerErrors = append(validation.InnerErrors, err)
+
+			continue
+		}
+
+		if !result {
+			continue
+		}
+
+		changed = true
+	}
+
+	if !validation.IsEmpty() {
+		return validation
+	}
+
+	if !changed {
+		return nil
+	}
+
+	l.updatedAt = timestamp
+	l.updatedBy = userID
+
+	return nil
+}
+
+// ID returns the List id.
+func (l *List) ID() entity.ID {
+	return l.id
+}
+
+// Name returns the List name.
+func (l *List) Name() string {
+	return l.name
+}
+
+// Items returns the List items.
+func (l *List) Items() []Item {
+	return l.items
+}
+
+// CreatedAt returns when the List was created.
+func (l *List) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// CreatedBy returns the id of the user who created the List.
+func (l *List) CreatedBy() entity.ID {
+	return l.createdBy
+}
+
+// UpdatedAt returns when the List was last updated.
+func (l *List) UpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+// UpdatedBy returns the id of the user who last updated the List.
+func (l *List) UpdatedBy() entity.ID {
+	return l.updatedBy
+}
+
+func (l *List) item(id entity.ID) int {
+	for i := range l.items {
+		if l.items[i].id == id {
+			return i
+		}
+	}
+
+	return -1
+}
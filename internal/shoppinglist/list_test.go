@@ -0,0 +1,65 @@
+package shoppinglist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewList(t *testing.T) {
+	t.Parallel()
+
+	// Create a valid list
+	id := entity.NewID("list-123")
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	test, err := shoppinglist.New(id, "groceries", timestamp, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, test.ID())
+	assert.Equal(t, "groceries", test.Name())
+	assert.Equal(t, 0, len(test.Items()))
+	assert.Equal(t, timestamp, test.CreatedAt())
+	assert.Equal(t, userID, test.CreatedBy())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Create a list with an empty name
+	_, err = shoppinglist.New(entity.NewRandomID(), "", time.Now(), entity.NewRandomID())
+	assert.Error(t, err)
+}
+
+func TestUpdateList(t *testing.T) {
+	t.Parallel()
+
+	test, err := shoppinglist.New(entity.NewRandomID(), "groceries", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Update the list with a valid name
+	timestamp := time.Now()
+	userID := entity.NewID("user-123")
+	err = test.Update(timestamp, userID, shoppinglist.SetName("weekly"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly", test.Name())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the list with the same name
+	err = test.Update(time.Now(), entity.NewRandomID(), shoppinglist.SetName("weekly"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+
+	// Update the list with an invalid name
+	err = test.Update(time.Now(), entity.NewRandomID(), shoppinglist.SetName(""))
+
+	assert.Error(t, err)
+	assert.Equal(t, "weekly", test.Name())
+	assert.Equal(t, timestamp, test.UpdatedAt())
+	assert.Equal(t, userID, test.UpdatedBy())
+}
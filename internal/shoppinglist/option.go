@@ -0,0 +1,115 @@
+package shoppinglist
+
+import (
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Option is a List create or update option.
+type Option func(l *List) (bool, error)
+
+// SetName sets the List name.
+// Error cases:
+//   - Name is empty
+func SetName(name string) Option {
+	return func(l *List) (bool, error) {
+		if name == "" {
+			return false, entity.NewFieldError("name", "shopping list name cannot be empty")
+		}
+
+		if l.name == name {
+			return false, nil
+		}
+
+		l.name = name
+
+		return true, nil
+	}
+}
+
+// AddItem adds an item to a List.
+// A quantity of 0 means the amount is not important.
+// Error cases:
+//   - Name is empty
+//   - Quantity is less than 0
+//   - Id is already used by another item
+func AddItem(id entity.ID, name string, quantity float64, unitID entity.ID) Option {
+	return func(l *List) (bool, error) {
+		if name == "" {
+			return false, entity.NewFieldError("items", "item name cannot be empty")
+		}
+
+		if quantity < 0 {
+			return false, entity.NewFieldError("items", "item quantity cannot be negative")
+		}
+
+		if l.item(id) >= 0 {
+			return false, entity.NewFieldError("items", "item already exists")
+		}
+
+		l.items = append(
+			l.items,
+			Item{
+				id:       id,
+				name:     name,
+				quantity: quantity,
+				unitID:   unitID,
+				checked:  false,
+			},
+		)
+
+		return true, nil
+	}
+}
+
+// RemoveItem removes an item from a List.
+// Error cases:
+//   - Item does not exist
+func RemoveItem(id entity.ID) Option {
+	return func(l *List) (bool, error) {
+		index := l.item(id)
+		if index < 0 {
+			return false, entity.NewFieldError("items", "item does not exist")
+		}
+
+		l.items = append(l.items[:index], l.items[index+1:]...)
+
+		return true, nil
+	}
+}
+
+// SetChecked checks or unchecks an item on a List.
+// Error cases:
+//   - Item does not exist
+func SetChecked(id entity.ID, checked bool) Option {
+	return func(l *List) (bool, error) {
+		index := l.item(id)
+		if index < 0 {
+			return false, entity.NewFieldError("items", "item does not exist")
+		}
+
+		if l.items[index].checked == checked {
+			return false, nil
+		}
+
+		l.items[index].checked = checked
+
+		return true, nil
+	}
+}
+
+// SetUpdated sets when and by whom the List was last updated.
+// This is meant for rebuilding a stored List, not for user changes.
+func SetUpdated(timestamp time.Time, userID entity.ID) Option {
+	return func(l *List) (bool, error) {
+		if l.updatedAt.Equal(timestamp) && l.updatedBy == userID {
+			return false, nil
+		}
+
+		l.updatedAt = timestamp
+		l.updatedBy = userID
+
+		return true, nil
+	}
+}
@@ -0,0 +1,157 @@
+package shoppinglist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetName(t *testing.T) {
+	t.Parallel()
+
+	test, err := shoppinglist.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Set an empty name
+	changed, err := shoppinglist.SetName("")(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, "test", test.Name())
+
+	// Set the name
+	changed, err = shoppinglist.SetName("new name")(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "new name", test.Name())
+
+	// Set the name to the same value
+	changed, err = shoppinglist.SetName("new name")(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "new name", test.Name())
+}
+
+func TestAddItem(t *testing.T) {
+	t.Parallel()
+
+	test, err := shoppinglist.New(entity.NewRandomID(), "test", time.Now(), entity.NewRandomID())
+	assert.NoError(t, err)
+
+	// Add an item
+	changed, err := shoppinglist.AddItem(entity.NewID("item-1"), "flour", 2, entity.NewID("123-unit"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(test.Items()))
+	assert.Equal(t, entity.NewID("item-1"), test.Items()[0].ID())
+	assert.Equal(t, "flour", test.Items()[0].Name())
+	assert.Equal(t, float64(2), test.Items()[0].Quantity())
+	assert.Equal(t, entity.NewID("123-unit"), test.Items()[0].UnitID())
+	assert.False(t, test.Items()[0].Checked())
+
+	// Add an item without a quantity
+	changed, err = shoppinglist.AddItem(entity.NewID("item-2"), "paper towels", 0, entity.NewID(""))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(test.Items()))
+
+	// Add an item with no name
+	changed, err = shoppinglist.AddItem(entity.NewID("item-3"), "", 1, entity.NewID(""))(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+
+	// Add an item with a bad quantity
+	changed, err = shoppinglist.AddItem(entity.NewID("item-3"), "milk", -1, entity.NewID(""))(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+
+	// Add an item with a duplicate id
+	changed, err = shoppinglist.AddItem(entity.NewID("item-1"), "milk", 1, entity.NewID(""))(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, len(test.Items()))
+}
+
+func TestRemoveItem(t *testing.T) {
+	t.Parallel()
+
+	test, err := shoppinglist.New(
+		entity.NewRandomID(), "test", time.Now(), entity.NewRandomID(),
+		shoppinglist.AddItem(entity.NewID("item-1"), "flour", 2, entity.NewID("")),
+		shoppinglist.AddItem(entity.NewID("item-2"), "water", 6, entity.NewID("")),
+	)
+	assert.NoError(t, err)
+
+	// Remove an item
+	changed, err := shoppinglist.RemoveItem(entity.NewID("item-1"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(test.Items()))
+	assert.Equal(t, "water", test.Items()[0].Name())
+
+	// Remove an item that does not exist
+	changed, err = shoppinglist.RemoveItem(entity.NewID("item-1"))(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(test.Items()))
+}
+
+func TestSetChecked(t *testing.T) {
+	t.Parallel()
+
+	test, err := shoppinglist.New(
+		entity.NewRandomID(), "test", time.Now(), entity.NewRandomID(),
+		shoppinglist.AddItem(entity.NewID("item-1"), "flour", 2, entity.NewID("")),
+	)
+	assert.NoError(t, err)
+
+	// Check an item
+	changed, err := shoppinglist.SetChecked(entity.NewID("item-1"), true)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.True(t, test.Items()[0].Checked())
+
+	// Check the item again
+	changed, err = shoppinglist.SetChecked(entity.NewID("item-1"), true)(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+	assert.True(t, test.Items()[0].Checked())
+
+	// Uncheck the item
+	changed, err = shoppinglist.SetChecked(entity.NewID("item-1"), false)(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.False(t, test.Items()[0].Checked())
+
+	// Check an item that does not exist
+	changed, err = shoppinglist.SetChecked(entity.NewID("item-2"), true)(test)
+	assert.False(t, changed)
+	assert.Error(t, err)
+}
+
+func TestSetUpdated(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	test, err := shoppinglist.New(entity.NewRandomID(), "test", created, entity.NewID("user-123"))
+	assert.NoError(t, err)
+
+	// Set the updated info
+	updated := created.Add(time.Hour)
+	changed, err := shoppinglist.SetUpdated(updated, entity.NewID("user-456"))(test)
+	assert.True(t, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, created, test.CreatedAt())
+	assert.Equal(t, updated, test.UpdatedAt())
+	assert.Equal(t, entity.NewID("user-456"), test.UpdatedBy())
+
+	// Set the updated info to the same value
+	changed, err = shoppinglist.SetUpdated(updated, entity.NewID("user-456"))(test)
+	assert.False(t, changed)
+	assert.NoError(t, err)
+}
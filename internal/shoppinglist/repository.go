@@ -0,0 +1,15 @@
+package shoppinglist
+
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// Repository defines all data interactions required for shopping lists.
+type Repository interface {
+	GetShoppingList(ctx context.Context, id entity.ID) (*List, error)
+	CreateShoppingList(ctx context.Context, list *List) error
+	UpdateShoppingList(ctx context.Context, list *List) error
+	DeleteShoppingList(ctx context.Context, id entity.ID) error
+}
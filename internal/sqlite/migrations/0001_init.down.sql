@@ -0,0 +1,7 @@
+DROP TABLE recipe_tags;
+DROP TABLE recipe_ingredients;
+DROP TABLE recipe_steps;
+DROP TABLE recipes;
+DROP TABLE conversions;
+DROP TABLE units;
+DROP TABLE users;
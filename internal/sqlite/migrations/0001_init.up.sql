@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS users (
+	id       TEXT PRIMARY KEY,
+	username TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS units (
+	id        TEXT PRIMARY KEY,
+	name      TEXT NOT NULL,
+	plural    TEXT NOT NULL,
+	symbol    TEXT NOT NULL,
+	base_type TEXT NOT NULL,
+	system    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS conversions (
+	from_id TEXT NOT NULL REFERENCES units (id) ON DELETE CASCADE,
+	to_id   TEXT NOT NULL REFERENCES units (id) ON DELETE CASCADE,
+	ratio   REAL NOT NULL,
+	PRIMARY KEY (from_id, to_id)
+);
+
+CREATE TABLE IF NOT EXISTS recipes (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	url          TEXT NOT NULL,
+	num_servings INTEGER NOT NULL,
+	is_favorite  INTEGER NOT NULL DEFAULT 0,
+	created_at   INTEGER NOT NULL,
+	created_by   TEXT NOT NULL,
+	updated_at   INTEGER NOT NULL,
+	updated_by   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS recipe_steps (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	step      TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	name      TEXT NOT NULL,
+	quantity  REAL NOT NULL,
+	unit_id   TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS recipe_tags (
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	tag       TEXT NOT NULL,
+	PRIMARY KEY (recipe_id, position)
+);
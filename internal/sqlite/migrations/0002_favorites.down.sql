@@ -0,0 +1,5 @@
+ALTER TABLE recipes ADD COLUMN is_favorite INTEGER NOT NULL DEFAULT 0;
+
+UPDATE recipes SET is_favorite = 1 WHERE EXISTS (SELECT 1 FROM favorites WHERE recipe_id = recipes.id);
+
+DROP TABLE favorites;
@@ -0,0 +1,10 @@
+CREATE TABLE favorites (
+	user_id   TEXT NOT NULL,
+	recipe_id TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+INSERT INTO favorites (user_id, recipe_id)
+SELECT created_by, id FROM recipes WHERE is_favorite = 1;
+
+ALTER TABLE recipes DROP COLUMN is_favorite;
@@ -0,0 +1,19 @@
+CREATE TABLE shopping_lists (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	updated_by TEXT NOT NULL
+);
+
+CREATE TABLE shopping_list_items (
+	list_id  TEXT NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	id       TEXT NOT NULL,
+	name     TEXT NOT NULL,
+	quantity REAL NOT NULL,
+	unit_id  TEXT NOT NULL,
+	checked  INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (list_id, position)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE pantry_items (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	quantity   REAL NOT NULL,
+	unit_id    TEXT NOT NULL,
+	expires_at INTEGER,
+	created_at INTEGER NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	updated_by TEXT NOT NULL
+);
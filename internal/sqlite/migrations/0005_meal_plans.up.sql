@@ -0,0 +1,11 @@
+CREATE TABLE meals (
+	id         TEXT PRIMARY KEY,
+	date       INTEGER NOT NULL,
+	slot       TEXT NOT NULL,
+	recipe_id  TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	servings   INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	updated_by TEXT NOT NULL
+);
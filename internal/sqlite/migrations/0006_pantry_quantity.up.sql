@@ -0,0 +1,19 @@
+CREATE TABLE pantry_items_new (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	quantity   REAL,
+	unit_id    TEXT NOT NULL,
+	expires_at INTEGER,
+	created_at INTEGER NOT NULL,
+	created_by TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	updated_by TEXT NOT NULL
+);
+
+INSERT INTO pantry_items_new
+SELECT id, name, NULLIF(quantity, 0), unit_id, expires_at, created_at, created_by, updated_at, updated_by
+FROM pantry_items;
+
+DROP TABLE pantry_items;
+
+ALTER TABLE pantry_items_new RENAME TO pantry_items;
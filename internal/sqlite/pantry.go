@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+)
+
+// GetPantryItem returns a single stored pantry item.
+func (r *Repository) GetPantryItem(ctx context.Context, id entity.ID) (*pantry.Item, error) {
+	found, err := r.GetPantryItems(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	options := []pantry.Option{pantry.SetUnit(found[0].UnitID)}
+
+	if found[0].Quantity != nil {
+		options = append(options, pantry.SetQuantity(*found[0].Quantity))
+	}
+
+	if found[0].ExpiresAt != nil {
+		options = append(options, pantry.SetExpiry(*found[0].ExpiresAt))
+	}
+
+	options = append(options, pantry.SetUpdated(found[0].UpdatedAt, found[0].UpdatedBy))
+
+	result, err := pantry.New(found[0].ID, found[0].Name, found[0].CreatedAt, found[0].CreatedBy, options...)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+
+	return result, nil
+}
+
+// CreatePantryItem stores a new pantry item.
+func (r *Repository) CreatePantryItem(ctx context.Context, item *pantry.Item) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO pantry_items (id, name, quantity, unit_id, expires_at, created_at, created_by, updated_at, updated_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		item.ID().String(),
+		item.Name(),
+		item.Quantity(),
+		item.UnitID().String(),
+		toNullTimestamp(item.ExpiresAt()),
+		toTimestamp(item.CreatedAt()),
+		item.CreatedBy().String(),
+		toTimestamp(item.UpdatedAt()),
+		item.UpdatedBy().String(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// UpdatePantryItem updates an existing pantry item.
+func (r *Repository) UpdatePantryItem(ctx context.Context, item *pantry.Item) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE pantry_items SET name = ?, quantity = ?, unit_id = ?, expires_at = ?, updated_at = ?, updated_by = ?
+		WHERE id = ?`,
+		item.Name(),
+		item.Quantity(),
+		item.UnitID().String(),
+		toNullTimestamp(item.ExpiresAt()),
+		toTimestamp(item.UpdatedAt()),
+		item.UpdatedBy().String(),
+		item.ID().String(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// DeletePantryItem removes a pantry item.
+func (r *Repository) DeletePantryItem(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM pantry_items WHERE id = ?`, id.String())
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// GetPantryItems returns all pantry items matching the given ids.
+func (r *Repository) GetPantryItems(ctx context.Context, ids []entity.ID) ([]*query.PantryItem, error) {
+	if len(ids) == 0 {
+		return make([]*query.PantryItem, 0), nil
+	}
+
+	return r.findPantryItems(ctx, `WHERE id IN (`+placeholders(len(ids))+`)`, idArgs(ids))
+}
+
+// FindPantryItems returns all pantry items of a user matching the given filter.
+// Items are ordered by name, or by expiry when filtering on it.
+func (r *Repository) FindPantryItems(
+	ctx context.Context,
+	userID entity.ID,
+	filter query.PantryFilter,
+) ([]*query.PantryItem, error) {
+	where := []string{"created_by = ?"}
+	args := []any{userID.String()}
+	order := "name, id"
+
+	if filter.Name != nil {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, likePattern(*filter.Name))
+	}
+
+	if filter.ExpiresBefore != nil {
+		where = append(where, "expires_at IS NOT NULL AND expires_at <= ?")
+		args = append(args, toTimestamp(*filter.ExpiresBefore))
+		order = "expires_at, name, id"
+	}
+
+	return r.findPantryItems(ctx, "WHERE "+strings.Join(where, " AND ")+" ORDER BY "+order, args)
+}
+
+func (r *Repository) findPantryItems(ctx context.Context, where string, args []any) ([]*query.PantryItem, error) {
+	result := make([]*query.PantryItem, 0)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, name, quantity, unit_id, expires_at, created_at, created_by, updated_at, updated_by
+		FROM pantry_items `+where,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id, unitID, createdBy, updatedBy string
+				createdAt, updatedAt             int64
+				expiresAt                        sql.NullInt64
+				item                             = &query.PantryItem{}
+			)
+
+			err := rows.Scan(
+				&id, &item.Name, &item.Quantity, &unitID, &expiresAt, &createdAt, &createdBy, &updatedAt, &updatedBy,
+			)
+			if err != nil {
+				return err
+			}
+
+			item.ID = entity.NewID(id)
+			item.UnitID = entity.NewID(unitID)
+			item.CreatedAt = fromTimestamp(createdAt)
+			item.CreatedBy = entity.NewID(createdBy)
+			item.UpdatedAt = fromTimestamp(updatedAt)
+			item.UpdatedBy = entity.NewID(updatedBy)
+
+			if expiresAt.Valid {
+				expiry := fromTimestamp(expiresAt.Int64)
+				item.ExpiresAt = &expiry
+			}
+
+			result = append(result, item)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
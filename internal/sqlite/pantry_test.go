@@ -0,0 +1,114 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPantryLifecycle(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	expiry := created.AddDate(0, 0, 3)
+	userID := entity.NewID("user-123")
+	half := 0.5
+
+	item, err := pantry.New(
+		entity.NewID("item-1"), "milk", created, userID,
+		pantry.SetQuantity(1),
+		pantry.SetUnit(entity.NewID("gallon")),
+		pantry.SetExpiry(expiry),
+	)
+	assert.NoError(t, err)
+
+	// Create a pantry item
+	assert.NoError(t, repo.CreatePantryItem(ctx, item))
+
+	loaded, err := repo.GetPantryItem(ctx, entity.NewID("item-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, item, loaded)
+
+	// Update the pantry item
+	err = loaded.Update(updated, entity.NewID("user-456"), pantry.SetQuantity(half))
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdatePantryItem(ctx, loaded))
+
+	found, err := repo.GetPantryItems(ctx, []entity.ID{entity.NewID("item-1"), entity.NewID("unknown")})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*query.PantryItem{
+			{
+				ID:        entity.NewID("item-1"),
+				Name:      "milk",
+				Quantity:  &half,
+				UnitID:    entity.NewID("gallon"),
+				ExpiresAt: &expiry,
+				CreatedAt: created,
+				CreatedBy: userID,
+				UpdatedAt: updated,
+				UpdatedBy: entity.NewID("user-456"),
+			},
+		},
+		found,
+	)
+
+	// Find pantry items by name and expiry
+	flour, err := pantry.New(entity.NewID("item-2"), "flour", created, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreatePantryItem(ctx, flour))
+
+	found, err = repo.FindPantryItems(ctx, userID, query.PantryFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(found))
+	assert.Equal(t, entity.NewID("item-2"), found[0].ID)
+	assert.Nil(t, found[0].ExpiresAt)
+
+	name := "FLO"
+	found, err = repo.FindPantryItems(ctx, userID, query.PantryFilter{Name: &name})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(found))
+	assert.Equal(t, entity.NewID("item-2"), found[0].ID)
+
+	before := created.AddDate(0, 0, 7)
+	found, err = repo.FindPantryItems(ctx, userID, query.PantryFilter{ExpiresBefore: &before})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(found))
+	assert.Equal(t, entity.NewID("item-1"), found[0].ID)
+
+	before = created.AddDate(0, 0, 1)
+	found, err = repo.FindPantryItems(ctx, userID, query.PantryFilter{ExpiresBefore: &before})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.PantryItem{}, found)
+
+	found, err = repo.FindPantryItems(ctx, entity.NewID("someone-else"), query.PantryFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.PantryItem{}, found)
+
+	// Clear the expiry
+	err = loaded.Update(updated, userID, pantry.ClearExpiry())
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdatePantryItem(ctx, loaded))
+
+	reloaded, err := repo.GetPantryItem(ctx, entity.NewID("item-1"))
+	assert.NoError(t, err)
+	assert.Nil(t, reloaded.ExpiresAt())
+
+	// Delete the pantry item
+	assert.NoError(t, repo.DeletePantryItem(ctx, entity.NewID("item-1")))
+
+	// Get, update, and delete a missing pantry item
+	_, err = repo.GetPantryItem(ctx, entity.NewID("item-1"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdatePantryItem(ctx, loaded), entity.ErrNotFound)
+	assert.ErrorIs(t, repo.DeletePantryItem(ctx, entity.NewID("item-1")), entity.ErrNotFound)
+}
@@ -0,0 +1,508 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+)
+
+// GetRecipe returns a single stored recipe.
+func (r *Repository) GetRecipe(ctx context.Context, id entity.ID) (*recipe.Recipe, error) {
+	found, err := r.GetRecipes(ctx, []entity.ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	options := []recipe.Option{
+		recipe.SetNumServings(found[0].NumServings),
+		recipe.SetUpdated(found[0].UpdatedAt, found[0].UpdatedBy),
+	}
+
+	if found[0].URL != "" {
+		options = append(options, recipe.SetURL(found[0].URL))
+	}
+
+	for _, step := range found[0].Steps {
+		options = append(options, recipe.AddStep(step))
+	}
+
+	for _, ingredient := range found[0].Ingredients {
+		options = append(options, recipe.AddIngredient(ingredient.Name, ingredient.Quantity, ingredient.UnitID))
+	}
+
+	for _, tag := range found[0].Tags {
+		options = append(options, recipe.AddTag(tag))
+	}
+
+	result, err := recipe.New(found[0].ID, found[0].Name, found[0].CreatedAt, found[0].CreatedBy, options...)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+
+	return result, nil
+}
+
+// CreateRecipe stores a new recipe.
+func (r *Repository) CreateRecipe(ctx context.Context, recipe *recipe.Recipe) error {
+	return r.transaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipes (id, name, url, num_servings, created_at, created_by, updated_at, updated_by)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			recipe.ID().String(),
+			recipe.Name(),
+			recipe.URL(),
+			recipe.NumServings(),
+			toTimestamp(recipe.CreatedAt()),
+			recipe.CreatedBy().String(),
+			toTimestamp(recipe.UpdatedAt()),
+			recipe.UpdatedBy().String(),
+		)
+		if err != nil {
+			return sqliteError(err)
+		}
+
+		return insertRecipeDetails(ctx, tx, recipe)
+	})
+}
+
+// UpdateRecipe updates an existing recipe.
+func (r *Repository) UpdateRecipe(ctx context.Context, recipe *recipe.Recipe) error {
+	return r.transaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE recipes SET name = ?, url = ?, num_servings = ?, updated_at = ?, updated_by = ? WHERE id = ?`,
+			recipe.Name(),
+			recipe.URL(),
+			recipe.NumServings(),
+			toTimestamp(recipe.UpdatedAt()),
+			recipe.UpdatedBy().String(),
+			recipe.ID().String(),
+		)
+		if err != nil {
+			return sqliteError(err)
+		}
+
+		if err := expectAffected(result); err != nil {
+			return err
+		}
+
+		for _, table := range []string{"recipe_steps", "recipe_ingredients", "recipe_tags"} {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE recipe_id = ?", recipe.ID().String()); err != nil {
+				return sqliteError(err)
+			}
+		}
+
+		return insertRecipeDetails(ctx, tx, recipe)
+	})
+}
+
+// DeleteRecipe removes a recipe.
+func (r *Repository) DeleteRecipe(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recipes WHERE id = ?`, id.String())
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return expectAffected(result)
+}
+
+func insertRecipeDetails(ctx context.Context, tx *sql.Tx, recipe *recipe.Recipe) error {
+	for i, step := range recipe.Steps() {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipe_steps (recipe_id, position, step) VALUES (?, ?, ?)`,
+			recipe.ID().String(), i, step,
+		)
+		if err != nil {
+			return sqliteError(err)
+		}
+	}
+
+	for i, ingredient := range recipe.Ingredients() {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipe_ingredients (recipe_id, position, name, quantity, unit_id) VALUES (?, ?, ?, ?, ?)`,
+			recipe.ID().String(), i, ingredient.Name(), ingredient.Quantity(), ingredient.UnitID().String(),
+		)
+		if err != nil {
+			return sqliteError(err)
+		}
+	}
+
+	for i, tag := range recipe.Tags() {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipe_tags (recipe_id, position, tag) VALUES (?, ?, ?)`,
+			recipe.ID().String(), i, tag,
+		)
+		if err != nil {
+			return sqliteError(err)
+		}
+	}
+
+	return nil
+}
+
+func expectAffected(result sql.Result) error {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	if count == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindRecipes returns a list of recipes based on search criteria.
+// When paging, the recipe the cursor points to is included as the first result. Empty or negative pages find nothing.
+func (r *Repository) FindRecipes(
+	ctx context.Context,
+	filter query.RecipeFilter,
+	page query.Pagination,
+	order query.Order,
+) ([]*query.Recipe, error) {
+	if page.Size <= 0 {
+		return []*query.Recipe{}, nil
+	}
+
+	column := sortColumn(order.Sort)
+	direction, compare := "DESC", "<"
+
+	if order.Direction == query.AscDirection {
+		direction, compare = "ASC", ">"
+	}
+
+	where, args := recipeFilter(filter)
+
+	if page.Cursor != nil {
+		value := sortValue(page.Cursor)
+		where = append(
+			where,
+			"("+column+" "+compare+" ? OR ("+column+" = ? AND id "+compare+"= ?))",
+		)
+		args = append(args, value, value, page.Cursor.ID.String())
+	}
+
+	statement := "SELECT id FROM recipes"
+	if len(where) > 0 {
+		statement += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	statement += " ORDER BY " + column + " " + direction + ", id " + direction + " LIMIT ?"
+
+	args = append(args, page.Size)
+
+	ids := make([]entity.ID, 0, page.Size)
+
+	err := r.eachRow(ctx, statement, args, func(rows *sql.Rows) error {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+
+		ids = append(ids, entity.NewID(id))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.GetRecipes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := make(map[entity.ID]*query.Recipe, len(found))
+	for _, recipe := range found {
+		lookup[recipe.ID] = recipe
+	}
+
+	result := make([]*query.Recipe, 0, len(ids))
+
+	for _, id := range ids {
+		if recipe, ok := lookup[id]; ok {
+			result = append(result, recipe)
+		}
+	}
+
+	return result, nil
+}
+
+// CountRecipes returns how many recipes match the filter.
+func (r *Repository) CountRecipes(ctx context.Context, filter query.RecipeFilter) (int, error) {
+	where, args := recipeFilter(filter)
+
+	statement := "SELECT COUNT(*) FROM recipes"
+	if len(where) > 0 {
+		statement += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, statement, args...).Scan(&count); err != nil {
+		return 0, sqliteError(err)
+	}
+
+	return count, nil
+}
+
+func sortColumn(sort query.Sort) string {
+	switch sort {
+	case query.NameSort:
+		return "name COLLATE NOCASE"
+	case query.UpdatedSort:
+		return "updated_at"
+	case query.CreatedSort:
+		fallthrough
+	default:
+		return "created_at"
+	}
+}
+
+// sortValue returns the value of the sort column kept in a cursor.
+func sortValue(cursor *query.Cursor) any {
+	if cursor.Sort == query.NameSort {
+		return cursor.Name
+	}
+
+	return toTimestamp(cursor.Time)
+}
+
+func recipeFilter(filter query.RecipeFilter) ([]string, []any) {
+	where := make([]string, 0)
+	args := make([]any, 0)
+
+	if len(filter.IDs) > 0 {
+		where = append(where, "id IN ("+placeholders(len(filter.IDs))+")")
+		args = append(args, idArgs(filter.IDs)...)
+	}
+
+	if filter.Name != nil {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, likePattern(*filter.Name))
+	}
+
+	for _, ingredient := range filter.Ingredients {
+		where = append(
+			where,
+			`EXISTS (SELECT 1 FROM recipe_ingredients WHERE recipe_id = recipes.id AND name LIKE ? ESCAPE '\')`,
+		)
+		args = append(args, likePattern(ingredient))
+	}
+
+	if filter.CreatedBy != nil {
+		where = append(where, "created_by = ?")
+		args = append(args, filter.CreatedBy.String())
+	}
+
+	if filter.IsFavorite != nil {
+		favorite := "EXISTS (SELECT 1 FROM favorites WHERE recipe_id = recipes.id AND user_id = ?)"
+		if !*filter.IsFavorite {
+			favorite = "NOT " + favorite
+		}
+
+		where = append(where, favorite)
+		args = append(args, filter.FavoriteOf.String())
+	}
+
+	return where, args
+}
+
+// GetRecipes returns all recipes matching the given ids.
+func (r *Repository) GetRecipes(ctx context.Context, ids []entity.ID) ([]*query.Recipe, error) {
+	result := make([]*query.Recipe, 0, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	args := idArgs(ids)
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, name, url, num_servings, created_at, created_by, updated_at, updated_by
+		FROM recipes WHERE id IN (`+placeholders(len(ids))+`)`,
+		args...,
+	)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	lookup := make(map[string]*query.Recipe, len(ids))
+
+	for rows.Next() {
+		var (
+			id, createdBy, updatedBy string
+			createdAt, updatedAt     int64
+			recipe                   = &query.Recipe{
+				Steps:       make([]string, 0),
+				Ingredients: make([]query.Ingredient, 0),
+				Tags:        make([]string, 0),
+			}
+		)
+
+		err := rows.Scan(
+			&id, &recipe.Name, &recipe.URL, &recipe.NumServings,
+			&createdAt, &createdBy, &updatedAt, &updatedBy,
+		)
+		if err != nil {
+			return nil, sqliteError(err)
+		}
+
+		recipe.ID = entity.NewID(id)
+		recipe.CreatedAt = fromTimestamp(createdAt)
+		recipe.CreatedBy = entity.NewID(createdBy)
+		recipe.UpdatedAt = fromTimestamp(updatedAt)
+		recipe.UpdatedBy = entity.NewID(updatedBy)
+
+		lookup[id] = recipe
+		result = append(result, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sqliteError(err)
+	}
+
+	if err := r.loadRecipeDetails(ctx, lookup, args); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Repository) loadRecipeDetails(ctx context.Context, lookup map[string]*query.Recipe, args []any) error {
+	in := placeholders(len(args))
+
+	err := r.eachRow(
+		ctx,
+		`SELECT recipe_id, step FROM recipe_steps WHERE recipe_id IN (`+in+`) ORDER BY recipe_id, position`,
+		args,
+		func(rows *sql.Rows) error {
+			var id, step string
+			if err := rows.Scan(&id, &step); err != nil {
+				return err
+			}
+
+			if recipe, ok := lookup[id]; ok {
+				recipe.Steps = append(recipe.Steps, step)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = r.eachRow(
+		ctx,
+		`SELECT recipe_id, name, quantity, unit_id FROM recipe_ingredients
+		WHERE recipe_id IN (`+in+`) ORDER BY recipe_id, position`,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id, unitID string
+				ingredient query.Ingredient
+			)
+
+			if err := rows.Scan(&id, &ingredient.Name, &ingredient.Quantity, &unitID); err != nil {
+				return err
+			}
+
+			ingredient.UnitID = entity.NewID(unitID)
+
+			if recipe, ok := lookup[id]; ok {
+				recipe.Ingredients = append(recipe.Ingredients, ingredient)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.eachRow(
+		ctx,
+		`SELECT recipe_id, tag FROM recipe_tags WHERE recipe_id IN (`+in+`) ORDER BY recipe_id, position`,
+		args,
+		func(rows *sql.Rows) error {
+			var id, tag string
+			if err := rows.Scan(&id, &tag); err != nil {
+				return err
+			}
+
+			if recipe, ok := lookup[id]; ok {
+				recipe.Tags = append(recipe.Tags, tag)
+			}
+
+			return nil
+		},
+	)
+}
+
+func (r *Repository) eachRow(ctx context.Context, statement string, args []any, fn func(rows *sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return sqliteError(err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// FindTags returns a unique case-insensitive list of recipe tags.
+func (r *Repository) FindTags(ctx context.Context, filter *string) ([]string, error) {
+	statement := "SELECT MIN(tag) FROM recipe_tags"
+	args := make([]any, 0)
+
+	if filter != nil {
+		statement += ` WHERE tag LIKE ? ESCAPE '\'`
+
+		args = append(args, likePattern(*filter))
+	}
+
+	statement += " GROUP BY tag COLLATE NOCASE"
+
+	result := make([]string, 0)
+
+	err := r.eachRow(ctx, statement, args, func(rows *sql.Rows) error {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return err
+		}
+
+		result = append(result, tag)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
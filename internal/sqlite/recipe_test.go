@@ -0,0 +1,343 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepository(t *testing.T) *sqlite.Repository {
+	t.Helper()
+
+	repo, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = repo.Close() })
+
+	if _, err := repo.Migrator().Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return repo
+}
+
+func newRecipe(t *testing.T, id string, name string, created time.Time, options ...recipe.Option) *recipe.Recipe {
+	t.Helper()
+
+	result, err := recipe.New(entity.NewID(id), name, created, entity.NewID("user-123"), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func recipeIDs(recipes []*query.Recipe) []string {
+	result := make([]string, len(recipes))
+	for i := range recipes {
+		result[i] = recipes[i].ID.String()
+	}
+
+	return result
+}
+
+func TestRecipeLifecycle(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	test := newRecipe(
+		t, "recipe-1", "pancakes", created,
+		recipe.SetURL("http://test.org/pancakes"),
+		recipe.SetNumServings(4),
+		recipe.AddStep("mix"),
+		recipe.AddStep("cook"),
+		recipe.AddIngredient("flour", 2, entity.NewID("cup")),
+		recipe.AddIngredient("egg", 1, entity.NewID("")),
+		recipe.AddTag("breakfast"),
+	)
+
+	// Create a recipe
+	assert.NoError(t, repo.CreateRecipe(ctx, test))
+
+	found, err := repo.GetRecipes(ctx, []entity.ID{entity.NewID("recipe-1")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Recipe{
+		{
+			ID:          entity.NewID("recipe-1"),
+			Name:        "pancakes",
+			URL:         "http://test.org/pancakes",
+			NumServings: 4,
+			Steps:       []string{"mix", "cook"},
+			Ingredients: []query.Ingredient{
+				{Name: "flour", Quantity: 2, UnitID: entity.NewID("cup")},
+				{Name: "egg", Quantity: 1, UnitID: entity.NewID("")},
+			},
+			Tags:      []string{"breakfast"},
+			CreatedAt: created,
+			CreatedBy: entity.NewID("user-123"),
+			UpdatedAt: created,
+			UpdatedBy: entity.NewID("user-123"),
+		},
+	}, found)
+
+	// Load the domain recipe
+	loaded, err := repo.GetRecipe(ctx, entity.NewID("recipe-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, test, loaded)
+
+	// Create a duplicate recipe
+	assert.Error(t, repo.CreateRecipe(ctx, test))
+
+	// Update the recipe
+	updated := created.Add(time.Hour)
+	err = test.Update(
+		updated, entity.NewID("user-456"),
+		recipe.SetName("crepes"),
+		recipe.ClearSteps(),
+		recipe.AddStep("cook thin"),
+		recipe.ClearTags(),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdateRecipe(ctx, test))
+
+	found, err = repo.GetRecipes(ctx, []entity.ID{entity.NewID("recipe-1")})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(found))
+	assert.Equal(t, "crepes", found[0].Name)
+	assert.Equal(t, []string{"cook thin"}, found[0].Steps)
+	assert.Equal(t, 2, len(found[0].Ingredients))
+	assert.Equal(t, []string{}, found[0].Tags)
+	assert.Equal(t, updated, found[0].UpdatedAt)
+	assert.Equal(t, entity.NewID("user-456"), found[0].UpdatedBy)
+
+	// Delete the recipe
+	assert.NoError(t, repo.DeleteRecipe(ctx, entity.NewID("recipe-1")))
+
+	found, err = repo.GetRecipes(ctx, []entity.ID{entity.NewID("recipe-1")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.Recipe{}, found)
+
+	// Get, update, and delete a missing recipe
+	_, err = repo.GetRecipe(ctx, entity.NewID("recipe-1"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateRecipe(ctx, test), entity.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteRecipe(ctx, entity.NewID("recipe-1")), entity.ErrNotFound)
+}
+
+func TestFindRecipes(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []*recipe.Recipe{
+		newRecipe(t, "1", "Banana Bread", created, recipe.AddIngredient("banana", 3, entity.NewID("unit"))),
+		newRecipe(t, "2", "apple pie", created.Add(time.Hour), recipe.AddIngredient("Apple", 6, entity.NewID("unit"))),
+		newRecipe(
+			t, "3", "apple banana smoothie", created.Add(2*time.Hour),
+			recipe.AddIngredient("banana", 1, entity.NewID("unit")),
+			recipe.AddIngredient("apple", 1, entity.NewID("unit")),
+		),
+		newRecipe(t, "4", "100% toast", created.Add(3*time.Hour)),
+	} {
+		assert.NoError(t, repo.CreateRecipe(ctx, test))
+	}
+
+	assert.NoError(t, repo.AddFavorite(ctx, entity.NewID("user-123"), entity.NewID("2")))
+	assert.NoError(t, repo.AddFavorite(ctx, entity.NewID("user-123"), entity.NewID("4")))
+	assert.NoError(t, repo.AddFavorite(ctx, entity.NewID("someone-else"), entity.NewID("1")))
+
+	str := func(value string) *string { return &value }
+	boolean := func(value bool) *bool { return &value }
+	id := func(value string) *entity.ID {
+		result := entity.NewID(value)
+
+		return &result
+	}
+
+	type testCase struct {
+		filter query.RecipeFilter
+		page   query.Pagination
+		order  query.Order
+		result []string
+	}
+
+	tests := map[string]testCase{
+		"newest first": {
+			page:   query.Pagination{Size: 10},
+			result: []string{"4", "3", "2", "1"},
+		},
+		"oldest first": {
+			page:   query.Pagination{Size: 10},
+			order:  query.Order{Sort: query.CreatedSort, Direction: query.AscDirection},
+			result: []string{"1", "2", "3", "4"},
+		},
+		"by name": {
+			page:   query.Pagination{Size: 10},
+			order:  query.Order{Sort: query.NameSort, Direction: query.AscDirection},
+			result: []string{"4", "3", "2", "1"},
+		},
+		"limit": {
+			page:   query.Pagination{Size: 2},
+			result: []string{"4", "3"},
+		},
+		"negative limit": {
+			page:   query.Pagination{Size: -10},
+			result: []string{},
+		},
+		"from cursor": {
+			page:   query.Pagination{Size: 2, Cursor: &query.Cursor{ID: entity.NewID("3"), Time: created.Add(2 * time.Hour)}},
+			result: []string{"3", "2"},
+		},
+		"from cursor by name": {
+			page: query.Pagination{
+				Size:   10,
+				Cursor: &query.Cursor{ID: entity.NewID("2"), Sort: query.NameSort, Name: "apple pie"},
+			},
+			order:  query.Order{Sort: query.NameSort, Direction: query.AscDirection},
+			result: []string{"2", "1"},
+		},
+		"deleted cursor": {
+			page: query.Pagination{
+				Size:   10,
+				Cursor: &query.Cursor{ID: entity.NewID("gone"), Time: created.Add(150 * time.Minute)},
+			},
+			result: []string{"3", "2", "1"},
+		},
+		"name filter": {
+			filter: query.RecipeFilter{Name: str("APPLE")},
+			page:   query.Pagination{Size: 10},
+			result: []string{"3", "2"},
+		},
+		"name filter wildcard": {
+			filter: query.RecipeFilter{Name: str("%")},
+			page:   query.Pagination{Size: 10},
+			result: []string{"4"},
+		},
+		"ingredient filter": {
+			filter: query.RecipeFilter{Ingredients: []string{"apple", "banana"}},
+			page:   query.Pagination{Size: 10},
+			result: []string{"3"},
+		},
+		"ids filter": {
+			filter: query.RecipeFilter{IDs: []entity.ID{entity.NewID("1"), entity.NewID("3"), entity.NewID("unknown")}},
+			page:   query.Pagination{Size: 10},
+			result: []string{"3", "1"},
+		},
+		"created by filter": {
+			filter: query.RecipeFilter{CreatedBy: id("someone-else")},
+			page:   query.Pagination{Size: 10},
+			result: []string{},
+		},
+		"favorite filter": {
+			filter: query.RecipeFilter{IsFavorite: boolean(true), FavoriteOf: entity.NewID("user-123")},
+			page:   query.Pagination{Size: 10},
+			result: []string{"4", "2"},
+		},
+		"not favorite filter": {
+			filter: query.RecipeFilter{IsFavorite: boolean(false), FavoriteOf: entity.NewID("user-123")},
+			page:   query.Pagination{Size: 10},
+			result: []string{"3", "1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := repo.FindRecipes(ctx, test.filter, test.page, test.order)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, recipeIDs(result))
+		})
+	}
+}
+
+func TestCountRecipes(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []*recipe.Recipe{
+		newRecipe(t, "1", "Banana Bread", created, recipe.AddIngredient("banana", 3, entity.NewID("unit"))),
+		newRecipe(t, "2", "apple pie", created.Add(time.Hour)),
+		newRecipe(t, "3", "apple crumble", created.Add(2*time.Hour)),
+	} {
+		assert.NoError(t, repo.CreateRecipe(ctx, test))
+	}
+
+	str := func(value string) *string { return &value }
+
+	type testCase struct {
+		filter query.RecipeFilter
+		result int
+	}
+
+	tests := map[string]testCase{
+		"all": {
+			filter: query.RecipeFilter{},
+			result: 3,
+		},
+		"name filter": {
+			filter: query.RecipeFilter{Name: str("apple")},
+			result: 2,
+		},
+		"ingredient filter": {
+			filter: query.RecipeFilter{Ingredients: []string{"banana"}},
+			result: 1,
+		},
+		"ids filter": {
+			filter: query.RecipeFilter{IDs: []entity.ID{entity.NewID("1"), entity.NewID("3")}},
+			result: 2,
+		},
+		"no match": {
+			filter: query.RecipeFilter{Name: str("toast")},
+			result: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := repo.CountRecipes(ctx, test.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestFindTags(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(
+		t, "1", "one", time.Now(), recipe.AddTag("Vegan"), recipe.AddTag("quick"),
+	)))
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(
+		t, "2", "two", time.Now(), recipe.AddTag("vegan"), recipe.AddTag("dessert"),
+	)))
+
+	result, err := repo.FindTags(ctx, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Vegan", "quick", "dessert"}, result)
+
+	filter := "VEG"
+	result, err = repo.FindTags(ctx, &filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Vegan"}, result)
+}
@@ -0,0 +1,163 @@
+// Package sqlite implements all data repositories on an embedded SQLite database.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/mealplan"
+	"github.com/b-sea/supply-run-api/internal/migrate"
+	"github.com/b-sea/supply-run-api/internal/pantry"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/recipe"
+	"github.com/b-sea/supply-run-api/internal/shoppinglist"
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/b-sea/supply-run-api/internal/user"
+
+	_ "modernc.org/sqlite" // Register the sqlite database/sql driver.
+)
+
+const driverName = "sqlite"
+
+var (
+	_ recipe.Repository            = (*Repository)(nil)
+	_ unit.Repository              = (*Repository)(nil)
+	_ user.Repository              = (*Repository)(nil)
+	_ shoppinglist.Repository      = (*Repository)(nil)
+	_ pantry.Repository            = (*Repository)(nil)
+	_ mealplan.Repository          = (*Repository)(nil)
+	_ query.RecipeRepository       = (*Repository)(nil)
+	_ query.UnitRepository         = (*Repository)(nil)
+	_ query.UserRepository         = (*Repository)(nil)
+	_ query.ShoppingListRepository = (*Repository)(nil)
+	_ query.PantryRepository       = (*Repository)(nil)
+	_ query.MealRepository         = (*Repository)(nil)
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrations, _ = fs.Sub(migrationFiles, "migrations") //nolint: gochecknoglobals
+
+// ErrSQLite is raised when the database fails.
+var ErrSQLite = errors.New("sqlite error")
+
+func sqliteError(err error) error {
+	return fmt.Errorf("%w: %w", ErrSQLite, err)
+}
+
+// Repository is a SQLite-backed data repository.
+type Repository struct {
+	db       *sql.DB
+	migrator *migrate.Migrator
+}
+
+// New opens, or creates, a SQLite database at the given path and creates a new Repository.
+func New(path string) (*Repository, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+
+	db, err := sql.Open(driverName, "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+
+	migrator, err := migrate.New(db, migrations)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, sqliteError(err)
+	}
+
+	return &Repository{
+		db:       db,
+		migrator: migrator,
+	}, nil
+}
+
+// Migrator returns the schema Migrator for the database.
+func (r *Repository) Migrator() *migrate.Migrator {
+	return r.migrator
+}
+
+// Close closes the underlying database.
+func (r *Repository) Close() error {
+	if err := r.db.Close(); err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// HealthCheck reports if the database is reachable.
+func (r *Repository) HealthCheck() error {
+	if err := r.db.PingContext(context.Background()); err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+func placeholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", count), ",")
+}
+
+func toTimestamp(value time.Time) int64 {
+	return value.UnixNano()
+}
+
+func fromTimestamp(value int64) time.Time {
+	return time.Unix(0, value).UTC()
+}
+
+func toNullTimestamp(value *time.Time) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{Int64: 0, Valid: false}
+	}
+
+	return sql.NullInt64{Int64: toTimestamp(*value), Valid: true}
+}
+
+func likePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	return "%" + replacer.Replace(value) + "%"
+}
+
+func idArgs(ids []entity.ID) []any {
+	args := make([]any, len(ids))
+	for i := range ids {
+		args[i] = ids[i].String()
+	}
+
+	return args
+}
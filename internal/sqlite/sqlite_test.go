@@ -0,0 +1,46 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateExistingSchema(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	// Databases created before migrations existed already have the initial tables.
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.ExecContext(
+		context.Background(),
+		`CREATE TABLE users (id TEXT PRIMARY KEY, username TEXT NOT NULL);
+		INSERT INTO users (id, username) VALUES ('user-123', 'tester');`,
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	repo, err := sqlite.New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = repo.Close() })
+
+	_, err = repo.Migrator().Up(context.Background())
+	assert.NoError(t, err)
+
+	found, err := repo.GetUser(context.Background(), entity.NewID("user-123"))
+	assert.NoError(t, err)
+	assert.Equal(t, "tester", found.Username())
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/user"
+)
+
+// GetUser returns a single stored user.
+func (r *Repository) GetUser(ctx context.Context, id entity.ID) (*user.User, error) {
+	var username string
+
+	err := r.db.QueryRowContext(ctx, `SELECT username FROM users WHERE id = ?`, id.String()).Scan(&username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, sqliteError(err)
+	}
+
+	return user.New(id, username), nil
+}
+
+// CreateUser stores a new user.
+func (r *Repository) CreateUser(ctx context.Context, user *user.User) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO users (id, username) VALUES (?, ?)`,
+		user.ID().String(),
+		user.Username(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// UpdateUser updates an existing user.
+func (r *Repository) UpdateUser(ctx context.Context, user *user.User) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE users SET username = ? WHERE id = ?`,
+		user.Username(),
+		user.ID().String(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// DeleteUser removes a user.
+func (r *Repository) DeleteUser(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id.String())
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return expectAffected(result)
+}
+
+// GetUsers returns all users matching the given ids.
+func (r *Repository) GetUsers(ctx context.Context, ids []entity.ID) ([]*query.User, error) {
+	result := make([]*query.User, 0, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	args := idArgs(ids)
+
+	err := r.eachRow(
+		ctx,
+		`SELECT id, username FROM users WHERE id IN (`+placeholders(len(ids))+`)`,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				id   string
+				user = &query.User{}
+			)
+
+			if err := rows.Scan(&id, &user.Username); err != nil {
+				return err
+			}
+
+			user.ID = entity.NewID(id)
+			result = append(result, user)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddFavorite marks a recipe as a favorite of a user.
+func (r *Repository) AddFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO favorites (user_id, recipe_id) VALUES (?, ?) ON CONFLICT DO NOTHING`,
+		userID.String(),
+		recipeID.String(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// RemoveFavorite unmarks a recipe as a favorite of a user.
+func (r *Repository) RemoveFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM favorites WHERE user_id = ? AND recipe_id = ?`,
+		userID.String(),
+		recipeID.String(),
+	)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	return nil
+}
+
+// GetFavorites returns which of the given recipe ids are favorites of a user.
+func (r *Repository) GetFavorites(ctx context.Context, userID entity.ID, recipeIDs []entity.ID) ([]entity.ID, error) {
+	result := make([]entity.ID, 0, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return result, nil
+	}
+
+	err := r.eachRow(
+		ctx,
+		`SELECT recipe_id FROM favorites WHERE user_id = ? AND recipe_id IN (`+placeholders(len(recipeIDs))+`)`,
+		append([]any{userID.String()}, idArgs(recipeIDs)...),
+		func(rows *sql.Rows) error {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+
+			result = append(result, entity.NewID(id))
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
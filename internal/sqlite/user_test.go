@@ -0,0 +1,97 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+	"github.com/b-sea/supply-run-api/internal/query"
+	"github.com/b-sea/supply-run-api/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsers(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	// Create a user
+	assert.NoError(t, repo.CreateUser(ctx, user.New(entity.NewID("user-123"), "tester")))
+
+	// Create the same user again
+	assert.Error(t, repo.CreateUser(ctx, user.New(entity.NewID("user-123"), "tester")))
+
+	found, err := repo.GetUsers(ctx, []entity.ID{entity.NewID("user-123"), entity.NewID("unknown")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.User{{ID: entity.NewID("user-123"), Username: "tester"}}, found)
+
+	// Update the user
+	assert.NoError(t, repo.UpdateUser(ctx, user.New(entity.NewID("user-123"), "renamed")))
+
+	loaded, err := repo.GetUser(ctx, entity.NewID("user-123"))
+	assert.NoError(t, err)
+	assert.Equal(t, user.New(entity.NewID("user-123"), "renamed"), loaded)
+
+	// Delete the user
+	assert.NoError(t, repo.DeleteUser(ctx, entity.NewID("user-123")))
+
+	found, err = repo.GetUsers(ctx, []entity.ID{entity.NewID("user-123")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*query.User{}, found)
+
+	// Get, update, and delete a missing user
+	_, err = repo.GetUser(ctx, entity.NewID("user-123"))
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateUser(ctx, user.New(entity.NewID("user-123"), "tester")), entity.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteUser(ctx, entity.NewID("user-123")), entity.ErrNotFound)
+
+	// Check the database health
+	assert.NoError(t, repo.HealthCheck())
+}
+
+func TestFavorites(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository(t)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	userID := entity.NewID("user-123")
+	ids := []entity.ID{entity.NewID("1"), entity.NewID("2")}
+
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(t, "1", "pancakes", created)))
+	assert.NoError(t, repo.CreateRecipe(ctx, newRecipe(t, "2", "waffles", created)))
+
+	// Favorite a recipe, twice
+	assert.NoError(t, repo.AddFavorite(ctx, userID, entity.NewID("1")))
+	assert.NoError(t, repo.AddFavorite(ctx, userID, entity.NewID("1")))
+
+	found, err := repo.GetFavorites(ctx, userID, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ID{entity.NewID("1")}, found)
+
+	// Favorites are per user
+	found, err = repo.GetFavorites(ctx, entity.NewID("someone-else"), ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ID{}, found)
+
+	// Favorite a missing recipe
+	assert.Error(t, repo.AddFavorite(ctx, userID, entity.NewID("unknown")))
+
+	// Unfavorite a recipe, twice
+	assert.NoError(t, repo.RemoveFavorite(ctx, userID, entity.NewID("1")))
+	assert.NoError(t, repo.RemoveFavorite(ctx, userID, entity.NewID("1")))
+
+	found, err = repo.GetFavorites(ctx, userID, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ID{}, found)
+
+	// Deleting a recipe removes its favorites
+	assert.NoError(t, repo.AddFavorite(ctx, userID, entity.NewID("2")))
+	assert.NoError(t, repo.DeleteRecipe(ctx, entity.NewID("2")))
+
+	found, err = repo.GetFavorites(ctx, userID, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ID{}, found)
+}
@@ -1,7 +1,6 @@
 package unit
 
 import (
-	"errors"
 	"fmt"
 	"math"
 
@@ -22,16 +21,20 @@ func NewConversion(from *Unit, to *Unit, ratio float64) (*Conversion, error) {
 	}
 
 	if from.ID() == to.ID() {
-		validation.InnerErrors = append(validation.InnerErrors, errors.New("cannot convert to the same unit"))
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("to", "cannot convert to the same unit"))
 	}
 
 	if from.BaseType() != to.BaseType() {
 		validation.InnerErrors = append(
 			validation.InnerErrors,
-			fmt.Errorf("cannot convert %q to %q", from.BaseType(), to.BaseType()),
+			entity.NewFieldError("to", fmt.Sprintf("cannot convert %q to %q", from.BaseType(), to.BaseType())),
 		)
 	}
 
+	if ratio <= 0 {
+		validation.InnerErrors = append(validation.InnerErrors, entity.NewFieldError("ratio", "ratio must be greater than 0"))
+	}
+
 	if !validation.IsEmpty() {
 		return nil, validation
 	}
@@ -53,7 +56,7 @@ func (c *Conversion) To() *Unit {
 	return c.to
 }
 
-// Ratio is the amount of "to" units in a single "from" unit.
+// Ratio is the amount of "from" units in a single "to" unit.
 func (c *Conversion) Ratio() float64 {
 	return c.ratio
 }
@@ -29,6 +29,10 @@ func TestNewConversion(t *testing.T) {
 	_, err = unit.NewConversion(from, unit.New("liter", "l", unit.Volume), float64(ratio))
 	assert.Error(t, err)
 
+	// Create a unit conversion with a bad ratio
+	_, err = unit.NewConversion(from, to, 0)
+	assert.Error(t, err)
+
 }
 
 func TestKilo(t *testing.T) {
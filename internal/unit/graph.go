@@ -0,0 +1,155 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
+
+// tolerance absorbs floating point drift when comparing converted quantities.
+const tolerance = 1e-9
+
+// ErrNoConversion is raised when there is no way to convert between two units.
+var ErrNoConversion = errors.New("no conversion")
+
+type edge struct {
+	to         entity.ID
+	factor     float64
+	conversion *Conversion
+}
+
+// Graph connects units through their conversions.
+// Every conversion can be walked in both directions.
+type Graph struct {
+	units map[entity.ID]*Unit
+	edges map[entity.ID][]edge
+}
+
+// NewGraph creates a new Graph from a set of conversions.
+func NewGraph(conversions ...*Conversion) *Graph {
+	graph := &Graph{
+		units: make(map[entity.ID]*Unit),
+		edges: make(map[entity.ID][]edge),
+	}
+
+	for _, conversion := range conversions {
+		graph.Add(conversion)
+	}
+
+	return graph
+}
+
+// Add adds a conversion to the Graph.
+func (g *Graph) Add(conversion *Conversion) {
+	from := conversion.From().ID()
+	to := conversion.To().ID()
+
+	g.units[from] = conversion.From()
+	g.units[to] = conversion.To()
+
+	g.edges[from] = append(g.edges[from], edge{to: to, factor: 1 / conversion.Ratio(), conversion: conversion})
+	g.edges[to] = append(g.edges[to], edge{to: from, factor: conversion.Ratio(), conversion: conversion})
+}
+
+// Convert converts a quantity of one unit into another unit.
+func (g *Graph) Convert(quantity float64, from *Unit, to *Unit) (float64, error) {
+	if from.ID() == to.ID() {
+		return quantity, nil
+	}
+
+	factor, ok := g.walk(from.ID())[to.ID()]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q to %q", ErrNoConversion, from.Name(), to.Name())
+	}
+
+	return quantity * factor, nil
+}
+
+// Path returns the shortest chain of conversions from one unit to another.
+// Conversions keep their stored direction, so some may need to be walked in reverse.
+func (g *Graph) Path(from *Unit, to *Unit) ([]*Conversion, error) {
+	if from.ID() == to.ID() {
+		return []*Conversion{}, nil
+	}
+
+	previous := map[entity.ID]edge{from.ID(): {}}
+	queue := []entity.ID{from.ID()}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.edges[current] {
+			if _, ok := previous[next.to]; ok {
+				continue
+			}
+
+			previous[next.to] = edge{to: current, factor: next.factor, conversion: next.conversion}
+			queue = append(queue, next.to)
+		}
+	}
+
+	if _, ok := previous[to.ID()]; !ok {
+		return nil, fmt.Errorf("%w: %q to %q", ErrNoConversion, from.Name(), to.Name())
+	}
+
+	result := make([]*Conversion, 0)
+
+	for current := to.ID(); current != from.ID(); current = previous[current].to {
+		result = append(result, previous[current].conversion)
+	}
+
+	slices.Reverse(result)
+
+	return result, nil
+}
+
+// Simplify expresses a quantity in the largest unit of the same system that keeps it at or above 1.
+// Quantities below 1 of every reachable unit are expressed in the smallest unit instead.
+func (g *Graph) Simplify(quantity float64, from *Unit) (float64, *Unit) {
+	bestQuantity, bestUnit := quantity, from
+
+	for id, factor := range g.walk(from.ID()) {
+		unit := g.units[id]
+		if unit == nil || unit.System() != from.System() {
+			continue
+		}
+
+		converted := quantity * factor
+
+		switch {
+		case bestQuantity < 1-tolerance && converted > bestQuantity:
+			// Too small in the current best, anything larger is an improvement.
+			bestQuantity, bestUnit = converted, unit
+		case converted >= 1-tolerance && converted < bestQuantity:
+			// Still at least 1, but in a larger unit.
+			bestQuantity, bestUnit = converted, unit
+		}
+	}
+
+	return bestQuantity, bestUnit
+}
+
+// walk returns the factor to convert a quantity of a unit into every unit reachable from it.
+func (g *Graph) walk(from entity.ID) map[entity.ID]float64 {
+	factors := map[entity.ID]float64{from: 1}
+	queue := []entity.ID{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.edges[current] {
+			if _, ok := factors[next.to]; ok {
+				continue
+			}
+
+			factors[next.to] = factors[current] * next.factor
+			queue = append(queue, next.to)
+		}
+	}
+
+	return factors
+}
@@ -0,0 +1,264 @@
+package unit_test
+
+import (
+	"testing"
+
+	"github.com/b-sea/supply-run-api/internal/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+type kitchen struct {
+	graph      *unit.Graph
+	teaspoon   *unit.Unit
+	tablespoon *unit.Unit
+	cup        *unit.Unit
+	milliliter *unit.Unit
+	liter      *unit.Unit
+	gram       *unit.Unit
+	kilogram   *unit.Unit
+}
+
+func newKitchen(t *testing.T) kitchen {
+	t.Helper()
+
+	result := kitchen{
+		teaspoon:   unit.New("teaspoon", "tsp", unit.US, unit.Volume),
+		tablespoon: unit.New("tablespoon", "tbsp", unit.US, unit.Volume),
+		cup:        unit.New("cup", "c", unit.US, unit.Volume),
+		liter:      unit.New("liter", "l", unit.Metric, unit.Volume),
+		gram:       unit.New("gram", "g", unit.Metric, unit.Mass),
+	}
+
+	milli := unit.Milli(result.liter)
+	kilo := unit.Kilo(result.gram)
+	result.milliliter = milli.To()
+	result.kilogram = kilo.To()
+
+	conversions := []*unit.Conversion{milli, kilo}
+
+	for _, item := range []struct {
+		from  *unit.Unit
+		to    *unit.Unit
+		ratio float64
+	}{
+		{result.teaspoon, result.tablespoon, 3},
+		{result.tablespoon, result.cup, 16},
+		{result.milliliter, result.teaspoon, 4.92892},
+	} {
+		conversion, err := unit.NewConversion(item.from, item.to, item.ratio)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conversions = append(conversions, conversion)
+	}
+
+	result.graph = unit.NewGraph(conversions...)
+
+	return result
+}
+
+func TestGraphConvert(t *testing.T) {
+	t.Parallel()
+
+	units := newKitchen(t)
+
+	type testCase struct {
+		quantity float64
+		from     *unit.Unit
+		to       *unit.Unit
+		result   float64
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"same unit": {
+			quantity: 2,
+			from:     units.cup,
+			to:       units.cup,
+			result:   2,
+		},
+		"direct": {
+			quantity: 6,
+			from:     units.teaspoon,
+			to:       units.tablespoon,
+			result:   2,
+		},
+		"inverse": {
+			quantity: 2,
+			from:     units.tablespoon,
+			to:       units.teaspoon,
+			result:   6,
+		},
+		"multiple steps": {
+			quantity: 1,
+			from:     units.cup,
+			to:       units.teaspoon,
+			result:   48,
+		},
+		"across systems": {
+			quantity: 1,
+			from:     units.liter,
+			to:       units.teaspoon,
+			result:   1000 / 4.92892,
+		},
+		"derived unit": {
+			quantity: 1500,
+			from:     units.gram,
+			to:       units.kilogram,
+			result:   1.5,
+		},
+		"no path": {
+			quantity: 1,
+			from:     units.cup,
+			to:       units.gram,
+			err:      unit.ErrNoConversion,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := units.graph.Convert(test.quantity, test.from, test.to)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, test.result, result, 1e-9)
+		})
+	}
+}
+
+func TestGraphSimplify(t *testing.T) {
+	t.Parallel()
+
+	units := newKitchen(t)
+
+	type testCase struct {
+		quantity float64
+		from     *unit.Unit
+		result   float64
+		unit     *unit.Unit
+	}
+
+	tests := map[string]testCase{
+		"promote": {
+			quantity: 48,
+			from:     units.teaspoon,
+			result:   1,
+			unit:     units.cup,
+		},
+		"promote partially": {
+			quantity: 9,
+			from:     units.teaspoon,
+			result:   3,
+			unit:     units.tablespoon,
+		},
+		"demote": {
+			quantity: 0.5,
+			from:     units.tablespoon,
+			result:   1.5,
+			unit:     units.teaspoon,
+		},
+		"already simple": {
+			quantity: 2,
+			from:     units.tablespoon,
+			result:   2,
+			unit:     units.tablespoon,
+		},
+		"stays in system": {
+			quantity: 0.25,
+			from:     units.liter,
+			result:   250,
+			unit:     units.milliliter,
+		},
+		"unknown unit": {
+			quantity: 3,
+			from:     unit.New("pinch", "pinch"),
+			result:   3,
+			unit:     unit.New("pinch", "pinch"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, resultUnit := units.graph.Simplify(test.quantity, test.from)
+
+			assert.InDelta(t, test.result, result, 1e-9)
+			assert.Equal(t, test.unit, resultUnit)
+		})
+	}
+}
+
+func TestGraphPath(t *testing.T) {
+	t.Parallel()
+
+	units := newKitchen(t)
+
+	type testCase struct {
+		from   *unit.Unit
+		to     *unit.Unit
+		result []string
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"same unit": {
+			from:   units.cup,
+			to:     units.cup,
+			result: []string{},
+		},
+		"direct": {
+			from:   units.teaspoon,
+			to:     units.tablespoon,
+			result: []string{"teaspoon>tablespoon"},
+		},
+		"inverse": {
+			from:   units.cup,
+			to:     units.tablespoon,
+			result: []string{"tablespoon>cup"},
+		},
+		"shortest": {
+			from:   units.liter,
+			to:     units.cup,
+			result: []string{"liter>milliliter", "milliliter>teaspoon", "teaspoon>tablespoon", "tablespoon>cup"},
+		},
+		"derived unit": {
+			from:   units.kilogram,
+			to:     units.gram,
+			result: []string{"gram>kilogram"},
+		},
+		"no path": {
+			from: units.cup,
+			to:   units.gram,
+			err:  unit.ErrNoConversion,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := units.graph.Path(test.from, test.to)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			steps := make([]string, len(result))
+			for i, conversion := range result {
+				steps[i] = conversion.From().Name() + ">" + conversion.To().Name()
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, steps)
+		})
+	}
+}
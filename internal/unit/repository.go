@@ -1,9 +1,14 @@
 package unit
 
-import "context"
+import (
+	"context"
+
+	"github.com/b-sea/supply-run-api/internal/entity"
+)
 
 // Repository defines all data interactions required for units.
 type Repository interface {
+	GetUnit(ctx context.Context, id entity.ID) (*Unit, error)
 	CreateUnit(ctx context.Context, unit *Unit) error
 	CreateConversion(ctx context.Context, conversion *Conversion) error
 }
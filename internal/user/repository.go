@@ -8,6 +8,10 @@ import (
 
 // Repository defines all data interactions required for users.
 type Repository interface {
+	GetUser(ctx context.Context, id entity.ID) (*User, error)
 	CreateUser(ctx context.Context, user *User) error
+	UpdateUser(ctx context.Context, user *User) error
 	DeleteUser(ctx context.Context, id entity.ID) error
+	AddFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error
+	RemoveFavorite(ctx context.Context, userID entity.ID, recipeID entity.ID) error
 }